@@ -0,0 +1,111 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BurstRequestPhase describes whether a BurstRequest currently grants capacity
+type BurstRequestPhase string
+
+const (
+	// BurstRequestPhaseActive means the extra runners are currently granted
+	BurstRequestPhaseActive BurstRequestPhase = "Active"
+	// BurstRequestPhaseExpired means the request has run out and no longer grants capacity
+	BurstRequestPhaseExpired BurstRequestPhase = "Expired"
+)
+
+// BurstRequestSpec defines the desired state of BurstRequest.
+// +kubebuilder:validation:XValidation:rule="has(self.until) || has(self.duration)",message="one of until or duration must be set"
+type BurstRequestSpec struct {
+	// RunnerGroupName is the name of the RunnerGroup in the same namespace to boost
+	// +kubebuilder:validation:Required
+	RunnerGroupName string `json:"runnerGroupName"`
+
+	// AdditionalRunners is added to the group's MaxActiveRunners while the request is active
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	AdditionalRunners int `json:"additionalRunners"`
+
+	// Until is the time at which the boost expires
+	// +optional
+	Until *metav1.Time `json:"until,omitempty"`
+
+	// Duration is how long the boost lasts after the request is created. Ignored if Until is set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// BurstRequestStatus defines the observed state of BurstRequest.
+type BurstRequestStatus struct {
+	// Phase is Active while the extra runners are granted and Expired afterwards
+	// +optional
+	Phase BurstRequestPhase `json:"phase,omitempty"`
+
+	// ExpiresAt is the time at which the boost ends
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.runnerGroupName`
+// +kubebuilder:printcolumn:name="Additional",type=integer,JSONPath=`.spec.additionalRunners`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+
+// BurstRequest is the Schema for the burstrequests API.
+// It temporarily raises the capacity of a RunnerGroup without editing it.
+type BurstRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BurstRequestSpec   `json:"spec,omitempty"`
+	Status BurstRequestStatus `json:"status,omitempty"`
+}
+
+// ExpiryTime returns when the boost granted by the BurstRequest ends
+func (b *BurstRequest) ExpiryTime() metav1.Time {
+	if b.Spec.Until != nil {
+		return *b.Spec.Until
+	}
+	var duration metav1.Duration
+	if b.Spec.Duration != nil {
+		duration = *b.Spec.Duration
+	}
+	return metav1.NewTime(b.CreationTimestamp.Add(duration.Duration))
+}
+
+// +kubebuilder:object:root=true
+
+// BurstRequestList contains a list of BurstRequest.
+type BurstRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BurstRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BurstRequest{}, &BurstRequestList{})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GiteaInstanceSpec defines settings shared by all RunnerGroups of a Gitea instance.
+type GiteaInstanceSpec struct {
+	// URL is the base URL of the Gitea instance. RunnerGroups whose giteaURL matches it,
+	// ignoring a trailing slash, use this instance's settings.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// MaintenanceWindows are planned periods during which the operator neither polls Gitea nor
+	// spawns runners for the instance's RunnerGroups
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// WebhookSecretRef references the secret the instance's webhooks sign deliveries with.
+	// Deliveries to the operator's webhook receiver are only accepted if signed with it.
+	// +optional
+	WebhookSecretRef *SecretKeyReference `json:"webhookSecretRef,omitempty"`
+
+	// FallbackPollInterval is how often the instance's RunnerGroups poll Gitea while the operator
+	// receives its webhooks, to catch deliveries that were missed. Defaults to 1m.
+	// +optional
+	FallbackPollInterval *metav1.Duration `json:"fallbackPollInterval,omitempty"`
+
+	// TLS configures how the operator connects to the instance over HTTPS
+	// +optional
+	TLS *GiteaInstanceTLS `json:"tls,omitempty"`
+
+	// RequestLimits caps the operator's requests to the instance, shared by all its RunnerGroups,
+	// so many groups polling at once don't overload a small server
+	// +optional
+	RequestLimits *RequestLimits `json:"requestLimits,omitempty"`
+}
+
+// RequestLimits caps the requests sent to a Gitea instance. Unset fields don't limit.
+type RequestLimits struct {
+	// RequestsPerSecond is the sustained rate of requests to the instance
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+
+	// Burst is how many requests may be sent at once above requestsPerSecond. Defaults to
+	// requestsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int `json:"burst,omitempty"`
+
+	// MaxInFlight is how many requests to the instance may await a response at the same time
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInFlight int `json:"maxInFlight,omitempty"`
+}
+
+// GiteaInstanceTLS configures TLS for a Gitea instance
+type GiteaInstanceTLS struct {
+	// InsecureSkipVerify disables verifying the instance's TLS certificate in the operator's
+	// requests, exposing its auth tokens to anyone able to intercept the connection. RunnerGroups
+	// of the instance report an InsecureTLS condition while it is set. Runner pods are unaffected.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// SecretKeyReference selects a key of a Secret in any namespace
+type SecretKeyReference struct {
+	// Namespace of the Secret
+	Namespace string `json:"namespace"`
+
+	// Name of the Secret
+	Name string `json:"name"`
+
+	// Key within the Secret
+	Key string `json:"key"`
+}
+
+// MaintenanceWindow is a planned Gitea maintenance period
+type MaintenanceWindow struct {
+	// Start is when the maintenance begins
+	Start metav1.Time `json:"start"`
+
+	// End is when the maintenance is over
+	End metav1.Time `json:"end"`
+
+	// Reason is shown in the Maintenance condition of affected RunnerGroups
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.url`
+
+// GiteaInstance is the Schema for the giteainstances API.
+type GiteaInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GiteaInstanceSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GiteaInstanceList contains a list of GiteaInstance.
+type GiteaInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GiteaInstance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GiteaInstance{}, &GiteaInstanceList{})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobClaimSpec identifies a queued Gitea job and the RunnerGroup provisioning a runner for it.
+type JobClaimSpec struct {
+	// GiteaURL is the base URL of the Gitea instance the job belongs to
+	GiteaURL string `json:"giteaURL"`
+
+	// JobID is the Gitea workflow job ID
+	JobID int64 `json:"jobID"`
+
+	// RunnerGroupNamespace is the namespace of the RunnerGroup holding the claim
+	RunnerGroupNamespace string `json:"runnerGroupNamespace"`
+
+	// RunnerGroupName is the name of the RunnerGroup holding the claim
+	RunnerGroupName string `json:"runnerGroupName"`
+
+	// ClaimedAt is when the claim was taken. A claim that is older than the spawn retry
+	// timeout can be taken over, so a runner that never started doesn't block the job.
+	ClaimedAt metav1.Time `json:"claimedAt"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Job",type=integer,JSONPath=`.spec.jobID`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.runnerGroupNamespace`
+// +kubebuilder:printcolumn:name="RunnerGroup",type=string,JSONPath=`.spec.runnerGroupName`
+// +kubebuilder:printcolumn:name="Claimed",type=date,JSONPath=`.spec.claimedAt`
+
+// JobClaim is the Schema for the jobclaims API.
+// It is managed by the operator: RunnerGroups create a JobClaim before spawning a runner,
+// so a queued job is provisioned at most once across groups and operator replicas.
+type JobClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec JobClaimSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JobClaimList contains a list of JobClaim.
+type JobClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JobClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JobClaim{}, &JobClaimList{})
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RunnerPhase is the lifecycle phase of a runner
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type RunnerPhase string
+
+const (
+	// RunnerPhasePending means the runner's Job or pod hasn't started running yet
+	RunnerPhasePending RunnerPhase = "Pending"
+	// RunnerPhaseRunning means the runner pod is running
+	RunnerPhaseRunning RunnerPhase = "Running"
+	// RunnerPhaseSucceeded means the runner finished its job and exited
+	RunnerPhaseSucceeded RunnerPhase = "Succeeded"
+	// RunnerPhaseFailed means the runner Job failed
+	RunnerPhaseFailed RunnerPhase = "Failed"
+)
+
+// RunnerSpec describes a single ephemeral runner spawned by a RunnerGroup
+type RunnerSpec struct {
+	// RunnerGroupName is the RunnerGroup in the same namespace that spawned the runner
+	RunnerGroupName string `json:"runnerGroupName"`
+
+	// GiteaJobID is the queued Gitea job the runner was spawned for
+	// +optional
+	GiteaJobID int64 `json:"giteaJobID,omitempty"`
+
+	// Repository is the "owner/name" of the Gitea job's repository
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Labels are the labels the runner registers with
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// NodeSelector constrains the nodes the runner pod is scheduled on
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Image is the runner container image
+	Image string `json:"image"`
+}
+
+// RunnerStatus is the observed state of a runner
+type RunnerStatus struct {
+	// Phase is where the runner is in its lifecycle
+	// +optional
+	Phase RunnerPhase `json:"phase,omitempty"`
+
+	// JobName is the batch Job running the runner; it has the same name as the Runner, which
+	// is also the name the runner registers with in Gitea
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// PodName is the latest pod of the runner Job
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// NodeName is the node the runner pod is scheduled on
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// GiteaRunnerID is the ID the runner was registered with in Gitea
+	// +optional
+	GiteaRunnerID int64 `json:"giteaRunnerID,omitempty"`
+
+	// StartTime is when the runner Job started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the runner Job finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// LastFailure is the latest exit of the runner container with an error
+	// +optional
+	LastFailure *RunnerFailure `json:"lastFailure,omitempty"`
+}
+
+// RunnerFailure describes an exit of the runner container with an error
+type RunnerFailure struct {
+	// FinishedAt is when the container exited
+	FinishedAt metav1.Time `json:"finishedAt"`
+
+	// ExitCode is the exit code of the container
+	ExitCode int32 `json:"exitCode"`
+
+	// Reason is the reason Kubernetes gives for the exit, e.g. Error or OOMKilled
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Log is the end of the container's log, truncated to 1KiB
+	// +optional
+	Log string `json:"log,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="RunnerGroup",type=string,JSONPath=`.spec.runnerGroupName`
+// +kubebuilder:printcolumn:name="Job",type=integer,JSONPath=`.spec.giteaJobID`
+// +kubebuilder:printcolumn:name="Repository",type=string,JSONPath=`.spec.repository`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.status.podName`,priority=1
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.status.nodeName`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Runner is the Schema for the runners API.
+// It is managed by the operator: a RunnerGroup creates a Runner for every runner it spawns, and
+// the Runner controller creates the runner's Job and reports its lifecycle.
+type Runner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RunnerSpec   `json:"spec,omitempty"`
+	Status RunnerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RunnerList contains a list of Runner.
+type RunnerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Runner `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Runner{}, &RunnerList{})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RunnerFleetName is the name of the singleton RunnerFleet maintained by the operator
+const RunnerFleetName = "default"
+
+// RunnerFleetSpec defines the desired state of RunnerFleet.
+type RunnerFleetSpec struct {
+	// TopRepos is how many repositories with the most queued jobs are listed in status
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	// +optional
+	TopRepos int `json:"topRepos,omitempty"`
+
+	// EmergencyStop halts runner creation in every RunnerGroup while set, e.g. during an
+	// incident where CI is exhausting cluster resources. Remove it to resume.
+	// +optional
+	EmergencyStop *EmergencyStopSpec `json:"emergencyStop,omitempty"`
+}
+
+// EmergencyStopSpec defines a fleet-wide halt of runner creation
+type EmergencyStopSpec struct {
+	// Drain also deletes all running runners, cancelling the CI jobs they run
+	// +optional
+	Drain bool `json:"drain,omitempty"`
+
+	// Reason is shown in the EmergencyStop condition of every RunnerGroup
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// RunnerFleetStatus summarizes every RunnerGroup in the cluster.
+type RunnerFleetStatus struct {
+	// LastUpdateTime is when the summary was last refreshed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// TotalGroups is the number of RunnerGroups
+	TotalGroups int `json:"totalGroups"`
+
+	// ActiveRunners is the number of active runners across all groups
+	ActiveRunners int `json:"activeRunners"`
+
+	// MaxActiveRunners is the combined capacity of all groups, including burst capacity
+	MaxActiveRunners int `json:"maxActiveRunners"`
+
+	// QueuedJobs is the number of matching queued jobs seen by all groups in their last poll
+	QueuedJobs int `json:"queuedJobs"`
+
+	// UnreachableGroups is the number of groups that currently can't reach Gitea
+	UnreachableGroups int `json:"unreachableGroups"`
+
+	// Groups has a summary line per RunnerGroup
+	// +optional
+	Groups []RunnerGroupSummary `json:"groups,omitempty"`
+
+	// TopRepos lists the repositories with the most queued jobs across all groups
+	// +optional
+	TopRepos []RepoQueue `json:"topRepos,omitempty"`
+}
+
+// RunnerGroupSummary is the fleet-level view of a single RunnerGroup
+type RunnerGroupSummary struct {
+	// Namespace of the RunnerGroup
+	Namespace string `json:"namespace"`
+
+	// Name of the RunnerGroup
+	Name string `json:"name"`
+
+	// Scope of the RunnerGroup
+	Scope RunnerGroupScope `json:"scope"`
+
+	// ActiveRunners is the group's current number of active runners
+	ActiveRunners int `json:"activeRunners"`
+
+	// MaxActiveRunners is the group's capacity, including burst capacity
+	MaxActiveRunners int `json:"maxActiveRunners"`
+
+	// QueuedJobs is the number of matching queued jobs in the group's last poll
+	QueuedJobs int `json:"queuedJobs"`
+
+	// GiteaReachable is false while the group can't poll Gitea
+	GiteaReachable bool `json:"giteaReachable"`
+
+	// LastCheckTime is the group's last poll to Gitea
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// RepoQueue is the number of queued jobs for a repository
+type RepoQueue struct {
+	// Repo is the repository as "owner/name"
+	Repo string `json:"repo"`
+
+	// QueuedJobs is the number of matching queued jobs for the repository
+	QueuedJobs int `json:"queuedJobs"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="RunnerFleet is a singleton named 'default'"
+// +kubebuilder:printcolumn:name="Groups",type=integer,JSONPath=`.status.totalGroups`
+// +kubebuilder:printcolumn:name="Active",type=integer,JSONPath=`.status.activeRunners`
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.status.maxActiveRunners`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queuedJobs`
+// +kubebuilder:printcolumn:name="Unreachable",type=integer,JSONPath=`.status.unreachableGroups`
+
+// RunnerFleet is the Schema for the runnerfleets API.
+// It is a cluster-wide singleton the operator keeps up to date with a summary
+// of all RunnerGroups, so dashboards can follow the whole fleet with a single watch.
+type RunnerFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RunnerFleetSpec   `json:"spec,omitempty"`
+	Status RunnerFleetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RunnerFleetList contains a list of RunnerFleet.
+type RunnerFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RunnerFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RunnerFleet{}, &RunnerFleetList{})
+}
@@ -63,6 +63,11 @@ type RunnerGroupSpec struct {
 	// +optional
 	Repo string `json:"repo,omitempty"`
 
+	// Sudo is a Gitea user the operator acts on behalf of in all Gitea API requests for this group,
+	// e.g. a bot account owning an organization. Requires AuthTokenRef to hold a site admin token.
+	// +optional
+	Sudo string `json:"sudo,omitempty"`
+
 	// GiteaURL is the base URL of the Gitea instance
 	// +kubebuilder:validation:Required
 	GiteaURL string `json:"giteaURL"`
@@ -71,32 +76,852 @@ type RunnerGroupSpec struct {
 	// +optional
 	Labels []string `json:"labels,omitempty"`
 
+	// LabelMatching relaxes how job labels are matched against Labels
+	// +optional
+	LabelMatching *LabelMatchingSpec `json:"labelMatching,omitempty"`
+
+	// JobLabelSelector decides which queued jobs the group serves based on their runs-on labels.
+	// When set, it replaces matching jobs against Labels; runners still register with Labels.
+	// +optional
+	JobLabelSelector *JobLabelSelector `json:"jobLabelSelector,omitempty"`
+
+	// EventFilter restricts the group to jobs of workflow runs triggered by matching events and branches
+	// +optional
+	EventFilter *EventFilterSpec `json:"eventFilter,omitempty"`
+
+	// LabelNodeSelectors maps a job label to the node selector applied to runner pods
+	// spawned for jobs requesting that label, e.g. arm64: {kubernetes.io/arch: arm64}
+	// +optional
+	LabelNodeSelectors map[string]map[string]string `json:"labelNodeSelectors,omitempty"`
+
 	// MaxActiveRunners is the maximum number of concurrent jobs
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Required
 	MaxActiveRunners int `json:"maxActiveRunners"`
 
-	// RegistrationTokenRef references the secret containing the runner registration token
+	// MaxRunnersPerRepo caps how many of the group's runners a single repository can use at once.
+	// Zero means no per-repository limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRunnersPerRepo int `json:"maxRunnersPerRepo,omitempty"`
+
+	// Priority decides which group serves a queued job when several RunnerGroups on the same
+	// Gitea instance match it. Groups with a lower priority only spawn runners for jobs that
+	// higher-priority groups have no free capacity for. Defaults to 0.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// RegistrationTokenRef references the secret containing the runner registration token. If
+	// unset, runners register with a token the controller fetches from Gitea using the auth token,
+	// which then needs the permission to manage the scope's runners.
+	// +optional
+	RegistrationTokenRef *corev1.SecretKeySelector `json:"registrationToken,omitempty"`
+
+	// RegistrationTokenRefreshInterval is how long a registration token fetched from Gitea is
+	// reused before it is fetched again. A token is also fetched again before every batch of new
+	// runners. Only used without registrationToken. Defaults to 1h.
+	// +optional
+	RegistrationTokenRefreshInterval *metav1.Duration `json:"registrationTokenRefreshInterval,omitempty"`
+
+	// PerRunnerCredentials has the controller register every runner in Gitea itself and hand the
+	// runner pod only that runner's credentials, stored in a Secret named after the runner, instead
+	// of the shared registration token. Runners are registered as ephemeral, so Gitea 1.23 or later
+	// removes them after their job.
+	// +optional
+	PerRunnerCredentials bool `json:"perRunnerCredentials,omitempty"`
+
+	// AuthTokenRef references the secret containing the Gitea API token for polling. Required
+	// unless sharedAuthToken, authTokenFile, authTokenEnv, basicAuth or oauth2 is set.
+	// +optional
+	AuthTokenRef corev1.SecretKeySelector `json:"authToken"`
+
+	// SharedAuthTokenRef references a Secret in any namespace holding the Gitea API token, e.g. a
+	// centrally managed admin token serving groups across the cluster. It takes precedence over
+	// authToken. Secrets outside the group's namespace are only read if the operator runs with
+	// --allow-cross-namespace-secrets and the Secret's gitea.bpg.pw/shared-with-namespaces
+	// annotation lists the group's namespace.
+	// +optional
+	SharedAuthTokenRef *SecretKeyReference `json:"sharedAuthToken,omitempty"`
+
+	// AuthTokenFile is the name of a file holding the Gitea API token in the operator's
+	// --auth-token-dir, e.g. a token a secrets-store CSI driver or Vault Agent provides. The file
+	// is read on every use, so rotated tokens are picked up. It takes precedence over authToken.
+	// +optional
+	AuthTokenFile string `json:"authTokenFile,omitempty"`
+
+	// AuthTokenEnv is the name of an environment variable of the operator holding the Gitea API
+	// token. Only variables starting with GITEA_AUTH_TOKEN_ can be used. It takes precedence over
+	// authToken.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^GITEA_AUTH_TOKEN_[A-Z0-9_]+$`
+	AuthTokenEnv string `json:"authTokenEnv,omitempty"`
+
+	// BasicAuthRef references a Secret with username and password keys, e.g. of type
+	// kubernetes.io/basic-auth, to authenticate to Gitea with instead of an API token. It takes
+	// precedence over authToken.
+	// +optional
+	BasicAuthRef *corev1.LocalObjectReference `json:"basicAuth,omitempty"`
+
+	// OAuth2Ref references a Secret with the clientID and clientSecret of a Gitea OAuth2
+	// application and a refreshToken issued to it. The operator polls with short-lived access
+	// tokens it refreshes itself, writing rotated refresh tokens back to the Secret. It takes
+	// precedence over authToken.
+	// +optional
+	OAuth2Ref *corev1.LocalObjectReference `json:"oauth2,omitempty"`
+
+	// Proxy routes the operator's Gitea requests for the group through an HTTP or SOCKS proxy,
+	// and optionally the group's runner pods too. When unset, the operator's HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables apply.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// SpawnOrder decides which queued jobs get runners first when there are more
+	// queued jobs than available slots
+	// +optional
+	SpawnOrder *SpawnOrderSpec `json:"spawnOrder,omitempty"`
+
+	// FairShare distributes new runners across repositories when there are more
+	// queued jobs than available slots, instead of following the order Gitea returns them in
+	// +optional
+	FairShare *FairShareSpec `json:"fairShare,omitempty"`
+
+	// OutageRecovery controls how the backlog is worked through once Gitea becomes
+	// reachable again after an outage. When unset, scaling resumes at full capacity immediately.
+	// +optional
+	OutageRecovery *OutageRecoverySpec `json:"outageRecovery,omitempty"`
+
+	// Autoscaling tunes how fast the group scales up. When unset, every queued job gets a
+	// runner as soon as there is a free slot.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// NodeFailureRecovery replaces runners whose node has stopped being Ready. When unset,
+	// such runners are left to the Job controller and the spawn retry timeout.
+	// +optional
+	NodeFailureRecovery *NodeFailureRecoverySpec `json:"nodeFailureRecovery,omitempty"`
+
+	// Spot runs the group's runners on spot or preemptible nodes: runner pods tolerate the
+	// provider's spot taint and are steered to its spot nodes, and runners whose node is reclaimed
+	// are replaced without counting as failures
+	// +optional
+	Spot *SpotSpec `json:"spot,omitempty"`
+
+	// NodeAutoscaling helps node autoscalers such as the cluster-autoscaler or Karpenter provision
+	// nodes for the group's runners, and keeps them from removing nodes under busy runners
+	// +optional
+	NodeAutoscaling *NodeAutoscalingSpec `json:"nodeAutoscaling,omitempty"`
+
+	// MaxRunnerLifetime is how long a runner may live, whatever it is doing; older runners are
+	// drained and replaced on the next poll. A job still running on such a runner is cancelled.
+	// +optional
+	MaxRunnerLifetime *metav1.Duration `json:"maxRunnerLifetime,omitempty"`
+
+	// MaxJobDuration is how long a runner may run before it is considered stuck; stuck runners
+	// are deregistered from Gitea and their Job is deleted, freeing the slot for a fresh runner.
+	// +optional
+	MaxJobDuration *metav1.Duration `json:"maxJobDuration,omitempty"`
+
+	// StartupClaimTimeout is how long a registered runner may stay idle without picking up a job,
+	// e.g. because the job it was spawned for was cancelled or taken by another runner. Idle
+	// runners are deregistered from Gitea and their Job is deleted.
+	// +optional
+	StartupClaimTimeout *metav1.Duration `json:"startupClaimTimeout,omitempty"`
+
+	// FailureBudget stops spawning runners for a while when they keep failing, e.g. because of a
+	// typo in the runner image. When unset, the defaults of FailureBudgetSpec apply.
+	// +optional
+	FailureBudget *FailureBudgetSpec `json:"failureBudget,omitempty"`
+
+	// DeletionGracePeriod is how long deleting the group waits for its runners to finish the jobs
+	// they are running before their Jobs are deleted anyway. Defaults to 30m.
+	// +optional
+	DeletionGracePeriod *metav1.Duration `json:"deletionGracePeriod,omitempty"`
+
+	// Drain stops the group from spawning runners, lets runners finish the jobs they are running and
+	// deregisters and removes all other runners, e.g. ahead of Gitea maintenance or an operator
+	// upgrade. The progress is reported in status.drain. Set it back to false to resume.
+	// +optional
+	Drain bool `json:"drain,omitempty"`
+
+	// Paused stops the group from polling Gitea and spawning runners, leaving its runners and the
+	// status of its last poll as they are. The gitea.bpg.pw/paused: "true" annotation pauses the
+	// group too, for tools that don't own the spec.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// ReportOnly makes the group poll Gitea and report its queued jobs in status without spawning
+	// runners, e.g. for a KEDA-scaled runner Deployment that serves the jobs instead
+	// +optional
+	ReportOnly bool `json:"reportOnly,omitempty"`
+
+	// CostCenterLabels are stamped on the group's runner Jobs and pods, e.g. team: platform, so
+	// their cost can be attributed. They can't override the labels the operator sets itself.
+	// +optional
+	CostCenterLabels map[string]string `json:"costCenterLabels,omitempty"`
+
+	// Alerting tunes the thresholds of the PrometheusRule generated for the group when the
+	// operator runs with --enable-prometheus-rules
+	// +optional
+	Alerting *AlertingSpec `json:"alerting,omitempty"`
+
+	// ExternalProvisioners hand queued jobs with certain labels to provisioners outside the cluster
+	// instead of spawning runner pods, e.g. Windows VMs for jobs Windows nodes can't run without DinD
+	// +optional
+	ExternalProvisioners []ExternalProvisioner `json:"externalProvisioners,omitempty"`
+}
+
+// ExternalProvisioner is a webhook that provisions runners outside the cluster
+type ExternalProvisioner struct {
+	// Name identifies the provisioner in status
 	// +kubebuilder:validation:Required
-	RegistrationTokenRef corev1.SecretKeySelector `json:"registrationToken"`
+	Name string `json:"name"`
+
+	// Labels are the job label names handed to this provisioner. A queued job requesting any
+	// of them is handed off.
+	// +kubebuilder:validation:MinItems=1
+	Labels []string `json:"labels"`
 
-	// AuthTokenRef references the secret containing the Gitea API token for polling
+	// URL receives a POST with the job and a runner registration token for every job handed off.
+	// A 2xx response means the provisioner accepted the job.
 	// +kubebuilder:validation:Required
-	AuthTokenRef corev1.SecretKeySelector `json:"authToken"`
+	URL string `json:"url"`
+
+	// SecretRef references the secret requests are signed with; the hex HMAC-SHA256 of the body
+	// is sent in the X-Runner-Operator-Signature header
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// DelegatedJob is a queued job handed to an external provisioner
+type DelegatedJob struct {
+	// GiteaJobID is the ID of the Gitea job
+	GiteaJobID int64 `json:"giteaJobID"`
+
+	// Provisioner is the name of the external provisioner the job was handed to
+	Provisioner string `json:"provisioner"`
+
+	// DelegatedAt is when the job was handed off
+	DelegatedAt metav1.Time `json:"delegatedAt"`
+}
+
+// AlertingSpec defines the thresholds of the generated alerts
+type AlertingSpec struct {
+	// MaxJobWait is how long the oldest matching queued job may wait before alerting
+	// +kubebuilder:default="15m"
+	// +optional
+	MaxJobWait metav1.Duration `json:"maxJobWait,omitempty"`
+
+	// MaxFailedRunnersPercent is the share of the group's runners that may be failed before alerting
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	// +optional
+	MaxFailedRunnersPercent int `json:"maxFailedRunnersPercent,omitempty"`
+
+	// MaxGiteaUnreachable is how long polling Gitea may keep failing before alerting
+	// +kubebuilder:default="10m"
+	// +optional
+	MaxGiteaUnreachable metav1.Duration `json:"maxGiteaUnreachable,omitempty"`
+
+	// MaxBacklogDuration is how long matching queued jobs may outnumber maxActiveRunners before
+	// the BacklogExceeded condition is set and the backlog alert fires
+	// +kubebuilder:default="30m"
+	// +optional
+	MaxBacklogDuration metav1.Duration `json:"maxBacklogDuration,omitempty"`
+}
+
+// LabelMatchingSpec defines how job labels are compared with the runner labels
+type LabelMatchingSpec struct {
+	// CaseInsensitive compares label names ignoring case
+	// +optional
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+
+	// Aliases maps a runner label name to other names jobs may request it by,
+	// e.g. ubuntu-latest: [ubuntu-22.04]. Runners spawned for such jobs also advertise
+	// the requested spelling, so Gitea assigns the job to them.
+	// +optional
+	Aliases map[string][]string `json:"aliases,omitempty"`
+}
+
+// JobLabelSelectorOperator is how a requirement matches a job's labels
+type JobLabelSelectorOperator string
+
+const (
+	// JobLabelSelectorOpIn requires at least one job label to match one of the values
+	JobLabelSelectorOpIn JobLabelSelectorOperator = "In"
+	// JobLabelSelectorOpNotIn requires no job label to match any of the values
+	JobLabelSelectorOpNotIn JobLabelSelectorOperator = "NotIn"
+	// JobLabelSelectorOpExists requires every value to be matched by some job label
+	JobLabelSelectorOpExists JobLabelSelectorOperator = "Exists"
+)
+
+// JobLabelSelector matches queued jobs by their labels. All requirements must be met.
+type JobLabelSelector struct {
+	// MatchExpressions are the requirements a job's labels must satisfy
+	// +kubebuilder:validation:MinItems=1
+	MatchExpressions []JobLabelSelectorRequirement `json:"matchExpressions"`
+}
+
+// JobLabelSelectorRequirement is a single requirement on a job's labels.
+// Values are glob patterns, e.g. ubuntu-*, compared with the label name without any ":schema" suffix.
+type JobLabelSelectorRequirement struct {
+	// Operator is In, NotIn or Exists
+	// +kubebuilder:validation:Enum=In;NotIn;Exists
+	Operator JobLabelSelectorOperator `json:"operator"`
+
+	// Values are the label patterns the operator applies to
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// EventFilterSpec selects queued jobs by the workflow run that triggered them.
+// Empty lists match anything.
+type EventFilterSpec struct {
+	// Events are the accepted trigger events, e.g. push, pull_request, release, schedule
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// Branches are glob patterns matched against the run's head branch, e.g. main or release/*.
+	// For tag pushes Gitea reports the tag name, so v* selects tag builds.
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+}
+
+// SpawnOrderStrategy defines how queued jobs are ordered before runners are spawned
+type SpawnOrderStrategy string
+
+const (
+	// SpawnOrderOldest serves the longest-waiting jobs first
+	SpawnOrderOldest SpawnOrderStrategy = "Oldest"
+	// SpawnOrderPriority serves jobs with the highest priority label first, then the oldest
+	SpawnOrderPriority SpawnOrderStrategy = "Priority"
+)
+
+// SpawnOrderSpec defines the order in which queued jobs get runners
+type SpawnOrderSpec struct {
+	// Strategy is Oldest (by job creation time) or Priority (by PriorityLabels, then creation time)
+	// +kubebuilder:validation:Enum=Oldest;Priority
+	// +kubebuilder:default=Oldest
+	// +optional
+	Strategy SpawnOrderStrategy `json:"strategy,omitempty"`
+
+	// PriorityLabels maps job labels to a priority; jobs with higher values are served first.
+	// A job's priority is the highest value among its labels, or 0 if none are listed.
+	// +optional
+	PriorityLabels map[string]int `json:"priorityLabels,omitempty"`
+}
+
+// FairShareSpec defines how new runners are shared between repositories
+type FairShareSpec struct {
+	// RepoWeights gives repositories ("owner/name") a proportionally larger share of new runners.
+	// Repositories not listed have a weight of 1.
+	// +optional
+	RepoWeights map[string]int `json:"repoWeights,omitempty"`
+}
+
+// OutageRecoverySpec defines the ramp applied after a Gitea outage
+type OutageRecoverySpec struct {
+	// RampStep is the number of additional concurrent runners allowed per poll interval while recovering
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	RampStep int `json:"rampStep,omitempty"`
+}
+
+// AutoscalingSpec describes the scale-up rules of a RunnerGroup
+type AutoscalingSpec struct {
+	// JobsPerRunner is how many queued jobs waiting for a runner call for one new runner, rounding up.
+	// Values above 1 spawn fewer runners than there are queued jobs per poll, working through a
+	// backlog more slowly.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	JobsPerRunner int `json:"jobsPerRunner,omitempty"`
+
+	// ScaleUpCooldown is the minimum time between two scale-ups
+	// +optional
+	ScaleUpCooldown *metav1.Duration `json:"scaleUpCooldown,omitempty"`
+
+	// MaxScaleUpStep is the most runners spawned in a single poll. Zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScaleUpStep int `json:"maxScaleUpStep,omitempty"`
+
+	// Schedules override maxActiveRunners during recurring time windows. The first schedule
+	// covering the current time applies.
+	// +optional
+	Schedules []AutoscalingSchedule `json:"schedules,omitempty"`
+}
+
+// AutoscalingSchedule overrides a RunnerGroup's capacity during a recurring time window
+type AutoscalingSchedule struct {
+	// Name identifies the schedule in logs
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Days are the days of the week the window recurs on. Empty means every day.
+	// +optional
+	Days []ScheduleDay `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, as HH:MM
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the window closes, as HH:MM. A window ending before it starts
+	// runs past midnight.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// TimeZone is the IANA time zone Start and End are in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// MaxActiveRunners replaces the group's maxActiveRunners while the window is open
+	// +kubebuilder:validation:Minimum=0
+	MaxActiveRunners int `json:"maxActiveRunners"`
+}
+
+// ScheduleDay is a day of the week
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type ScheduleDay string
+
+// Condition types reported on RunnerGroups
+const (
+	// ConditionMaintenance is True while the group's Gitea instance is in a maintenance window
+	ConditionMaintenance = "Maintenance"
+	// ConditionAdopted is True once the group's existing runners were adopted after an operator start
+	ConditionAdopted = "Adopted"
+	// ConditionEmergencyStop is True while a fleet-wide emergency stop halts runner creation
+	ConditionEmergencyStop = "EmergencyStop"
+	// ConditionConflict is True while an older RunnerGroup has the same Gitea instance, scope and
+	// labels, so both provision runners for the same jobs
+	ConditionConflict = "Conflict"
+	// ConditionScopeVerified is False while Gitea reports the group's org, user or repo missing,
+	// or the auth token unable to read its Actions jobs
+	ConditionScopeVerified = "ScopeVerified"
+	// ConditionScaleUpBlocked is True while queued jobs the group should serve get no runners,
+	// with the reason explaining why
+	ConditionScaleUpBlocked = "ScaleUpBlocked"
+	// ConditionBacklogExceeded is True while matching queued jobs have outnumbered
+	// maxActiveRunners for longer than the group's maxBacklogDuration
+	ConditionBacklogExceeded = "BacklogExceeded"
+	// ConditionGiteaReachable is False while the group's Gitea instance doesn't answer its version
+	// check, telling an outage apart from an empty queue
+	ConditionGiteaReachable = "GiteaReachable"
+	// ConditionTokenValid is False while Gitea rejects the group's auth token, with the operation
+	// that failed in the message
+	ConditionTokenValid = "TokenValid"
+	// ConditionInsecureTLS is True while the group's GiteaInstance skips TLS certificate verification
+	ConditionInsecureTLS = "InsecureTLS"
+	// ConditionDegraded is True while requests to the group's Gitea host are short-circuited
+	// after repeated failures, or while the group's runners keep failing
+	ConditionDegraded = "Degraded"
+	// ConditionDraining is True while spec.drain is set, with reason Draining until the group's
+	// last runner is removed and Drained after
+	ConditionDraining = "Draining"
+	// ConditionPaused is True while spec.paused or the gitea.bpg.pw/paused annotation pauses the group
+	ConditionPaused = "Paused"
+	// ConditionRunnersUnschedulable is True while runner pods wait for nodes to be scheduled on,
+	// with the scheduler's message telling which resources are missing
+	ConditionRunnersUnschedulable = "RunnersUnschedulable"
+)
+
+// ProxySpec configures the proxy a RunnerGroup reaches Gitea through
+type ProxySpec struct {
+	// URL of the proxy, e.g. http://proxy.corp:3128 or socks5://proxy.corp:1080
+	// +kubebuilder:validation:Pattern=`^(http|https|socks5)://`
+	URL string `json:"url"`
+
+	// NoProxy is a comma-separated list of hosts, domains and CIDRs runner pods reach directly,
+	// passed to them as NO_PROXY
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// InjectIntoRunners sets HTTP_PROXY, HTTPS_PROXY and NO_PROXY, in upper and lower case, in the
+	// group's runner pods
+	// +optional
+	InjectIntoRunners bool `json:"injectIntoRunners,omitempty"`
+}
+
+// NodeFailureRecoverySpec defines how runners on failed nodes are recovered
+type NodeFailureRecoverySpec struct {
+	// GracePeriod is how long a runner's node must be NotReady before the runner is
+	// force-deleted, deregistered from Gitea and its job made available for a replacement
+	// +kubebuilder:default="2m"
+	// +optional
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// NodeAutoscaler is a node autoscaler whose pod annotations runner pods get
+// +kubebuilder:validation:Enum=ClusterAutoscaler;Karpenter
+type NodeAutoscaler string
+
+const (
+	// NodeAutoscalerClusterAutoscaler marks runner pods as not safe to evict for the Kubernetes
+	// cluster-autoscaler
+	NodeAutoscalerClusterAutoscaler NodeAutoscaler = "ClusterAutoscaler"
+	// NodeAutoscalerKarpenter marks runner pods as not to be disrupted by Karpenter
+	NodeAutoscalerKarpenter NodeAutoscaler = "Karpenter"
+)
+
+// NodeAutoscalingSpec defines how runner pods are prepared for node autoscalers
+type NodeAutoscalingSpec struct {
+	// Autoscaler sets the annotation that keeps the autoscaler from removing a node while a runner
+	// runs on it: cluster-autoscaler.kubernetes.io/safe-to-evict: "false" for ClusterAutoscaler,
+	// karpenter.sh/do-not-disrupt: "true" for Karpenter
+	// +optional
+	Autoscaler NodeAutoscaler `json:"autoscaler,omitempty"`
+
+	// PodAnnotations are set on runner pods, e.g. provisioner-specific scheduling hints
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are set on runner pods, e.g. to match the pods of a dedicated node pool. They
+	// can't override the labels the operator sets itself.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// Resources are the runner container's resource requests and limits. Autoscalers size the
+	// nodes they add by the requests of pending pods, so they should cover a typical job.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SpotProvider is the platform whose spot node labels and taints runner pods are scheduled with
+// +kubebuilder:validation:Enum=GKE;EKS;Karpenter;AKS
+type SpotProvider string
+
+const (
+	// SpotProviderGKE schedules runners on GKE Spot VMs
+	SpotProviderGKE SpotProvider = "GKE"
+	// SpotProviderEKS schedules runners on the Spot instances of EKS managed node groups
+	SpotProviderEKS SpotProvider = "EKS"
+	// SpotProviderKarpenter schedules runners on spot capacity provisioned by Karpenter
+	SpotProviderKarpenter SpotProvider = "Karpenter"
+	// SpotProviderAKS schedules runners on AKS Spot node pools
+	SpotProviderAKS SpotProvider = "AKS"
+)
+
+// SpotSpec defines how runners are run on spot or preemptible nodes
+type SpotSpec struct {
+	// Provider selects the node label and taint the platform marks its spot nodes with
+	Provider SpotProvider `json:"provider"`
+
+	// Required keeps runners off on-demand nodes. By default runners prefer spot nodes but run on
+	// on-demand ones when no spot capacity is available.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Tolerations are added to runner pods besides the provider's spot taint, e.g. for the
+	// taints of a dedicated spot node pool
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// FailureBudgetSpec defines how many runner failures a group tolerates before it backs off
+type FailureBudgetSpec struct {
+	// MaxFailures is how many runners may fail within the window, without a runner succeeding
+	// in between, before spawning backs off
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxFailures int `json:"maxFailures,omitempty"`
+
+	// Window is how far back failures are counted
+	// +kubebuilder:default="10m"
+	// +optional
+	Window metav1.Duration `json:"window,omitempty"`
+
+	// Backoff is how long spawning pauses after the latest failure once the budget is spent
+	// +kubebuilder:default="5m"
+	// +optional
+	Backoff metav1.Duration `json:"backoff,omitempty"`
 }
 
 // RunnerGroupStatus defines the observed state of RunnerGroup.
 type RunnerGroupStatus struct {
+	// ObservedGeneration is the RunnerGroup generation the controller last processed
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// ActiveRunners is the current number of running jobs
 	ActiveRunners int `json:"activeRunners"`
 
-	// LastCheckTime is the timestamp of the last poll to Gitea
+	// BurstRunners is the extra capacity currently granted by active BurstRequests
+	// +optional
+	BurstRunners int `json:"burstRunners,omitempty"`
+
+	// QueuedJobs is the number of matching queued jobs in the last poll
+	// +optional
+	QueuedJobs int `json:"queuedJobs,omitempty"`
+
+	// DesiredRunners is how many runners the last poll asked for: the active runners plus one
+	// for every queued job without a runner, capped at the group's capacity
+	// +optional
+	DesiredRunners int `json:"desiredRunners,omitempty"`
+
+	// WaitingJobs is how many of the matching queued jobs of the last poll have no runner yet and
+	// aren't left to a higher-priority group. Unlike queuedJobs it is kept current while the
+	// group is at capacity.
+	// +optional
+	WaitingJobs int `json:"waitingJobs,omitempty"`
+
+	// PendingRunners is the number of runner Jobs whose pod is not ready yet
+	// +optional
+	PendingRunners int `json:"pendingRunners,omitempty"`
+
+	// RunningRunners is the number of runner Jobs with a ready pod
+	// +optional
+	RunningRunners int `json:"runningRunners,omitempty"`
+
+	// FailedRunners is the number of runner Jobs that failed and haven't been cleaned up yet
+	// +optional
+	FailedRunners int `json:"failedRunners,omitempty"`
+
+	// RunnerFailureReasons counts the group's runner pods that can't run, by why: Unschedulable,
+	// Evicted, ImagePull, ContainerConfig, OOMKilled or Error. Capacity problems show up as
+	// Unschedulable and Evicted, image and token problems as ImagePull and Error.
+	// +optional
+	RunnerFailureReasons map[string]int `json:"runnerFailureReasons,omitempty"`
+
+	// LastCheckTime is the timestamp of the last poll to Gitea. Polls that change nothing else
+	// only refresh it every 5 minutes.
 	// +optional
 	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// LastKnownQueuedJobs is the number of matching queued jobs seen in the last successful poll
+	// +optional
+	LastKnownQueuedJobs int `json:"lastKnownQueuedJobs,omitempty"`
+
+	// GiteaUnreachableSince is set while polling Gitea keeps failing
+	// +optional
+	GiteaUnreachableSince *metav1.Time `json:"giteaUnreachableSince,omitempty"`
+
+	// Gitea is the result of the last connectivity check of the group's Gitea instance
+	// +optional
+	Gitea *GiteaStatus `json:"gitea,omitempty"`
+
+	// RegisteredRunners counts the group's runners registered in Gitea, as of the last poll.
+	// Fewer online runners than runningRunners means runners failed to register.
+	// +optional
+	RegisteredRunners *RegisteredRunners `json:"registeredRunners,omitempty"`
+
+	// RecoveryStartTime is set while the controller ramps capacity back up after an outage
+	// +optional
+	RecoveryStartTime *metav1.Time `json:"recoveryStartTime,omitempty"`
+
+	// BacklogSince is set while matching queued jobs outnumber maxActiveRunners
+	// +optional
+	BacklogSince *metav1.Time `json:"backlogSince,omitempty"`
+
+	// LastScaleDecision records which queued jobs got runners in the most recent scale-up
+	// +optional
+	LastScaleDecision *ScaleDecision `json:"lastScaleDecision,omitempty"`
+
+	// TopQueuedRepos lists the repositories with the most matching queued jobs in the last poll
+	// +optional
+	TopQueuedRepos []RepoQueue `json:"topQueuedRepos,omitempty"`
+
+	// DelegatedJobs are the queued jobs currently handed to external provisioners
+	// +optional
+	DelegatedJobs []DelegatedJob `json:"delegatedJobs,omitempty"`
+
+	// EffectiveConfig is the runner configuration the controller creates runners with, after
+	// defaults and the operator config are applied
+	// +optional
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+
+	// DeletionPreview lists what deleting the RunnerGroup would affect. It is only reported while
+	// the RunnerGroup has the gitea.bpg.pw/deletion-preview: "true" annotation.
+	// +optional
+	DeletionPreview *DeletionPreview `json:"deletionPreview,omitempty"`
+
+	// Drain reports the progress of draining the group while spec.drain is set
+	// +optional
+	Drain *DrainStatus `json:"drain,omitempty"`
+
+	// Utilization reports how many of the group's runners ran a job rather than exiting idle,
+	// to guide sizing maxActiveRunners
+	// +optional
+	Utilization *RunnerUtilization `json:"utilization,omitempty"`
+
+	// Usage is how long the group's runners have run, to attribute their cost
+	// +optional
+	Usage *RunnerUsage `json:"usage,omitempty"`
+
+	// Conditions represent the latest available observations of the RunnerGroup's state
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GiteaStatus is what the controller's connectivity check found out about a Gitea instance
+type GiteaStatus struct {
+	// Version is the Gitea version reported by the last successful check
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Compatibility is how well the detected version supports the operator: Full, Limited when
+	// it lacks features the operator works around, or Unknown when the version couldn't be read
+	// +optional
+	// +kubebuilder:validation:Enum=Full;Limited;Unknown
+	Compatibility string `json:"compatibility,omitempty"`
+
+	// Latency is how long a successful check took, refreshed along with lastProbeTime
+	// +optional
+	Latency metav1.Duration `json:"latency,omitempty"`
+
+	// LastProbeTime is when Gitea was checked. Unless the outcome of the check changed, it is only
+	// refreshed every 5 minutes.
+	LastProbeTime metav1.Time `json:"lastProbeTime"`
+}
+
+// RegisteredRunners counts runners registered in Gitea by their state
+type RegisteredRunners struct {
+	// Online is the number of registered runners connected to Gitea, busy or idle
+	Online int `json:"online"`
+
+	// Offline is the number of registered runners not connected to Gitea
+	Offline int `json:"offline"`
+
+	// Busy is the number of online runners running a job
+	Busy int `json:"busy"`
+}
+
+// EffectiveConfig summarizes what the controller will create for new runners
+type EffectiveConfig struct {
+	// Image is the runner image for runners that don't select a node architecture
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ArchImages maps node architectures to their runner image, when images are configured per
+	// architecture
+	// +optional
+	ArchImages map[string]string `json:"archImages,omitempty"`
+
+	// Labels are the runner labels, including the default labels
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Env is the runner container's environment, without the registration token and the
+	// per-runner name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// PollInterval is how often the group polls Gitea
+	PollInterval metav1.Duration `json:"pollInterval"`
+
+	// MaxActiveRunners is the group's current capacity, after autoscaling schedules and BurstRequests
+	MaxActiveRunners int `json:"maxActiveRunners"`
+
+	// MaxRunnerLifetime is how long a runner may live, if limited
+	// +optional
+	MaxRunnerLifetime *metav1.Duration `json:"maxRunnerLifetime,omitempty"`
+}
+
+// DeletionPreview is a dry-run report of the impact of deleting a RunnerGroup
+type DeletionPreview struct {
+	// GeneratedAt is when the affected runners last changed
+	GeneratedAt metav1.Time `json:"generatedAt"`
+
+	// ActiveRunners are the runners that would be terminated, with the Gitea jobs they run
+	// +optional
+	ActiveRunners []AffectedRunner `json:"activeRunners,omitempty"`
+}
+
+// DrainStatus is the progress of draining a RunnerGroup
+type DrainStatus struct {
+	// StartTime is when the drain started
+	StartTime metav1.Time `json:"startTime"`
+
+	// RunningRunners is the number of runners the drain waits for to finish their jobs
+	RunningRunners int `json:"runningRunners"`
+
+	// RemovedRunners is the number of runners the drain removed so far
+	RemovedRunners int `json:"removedRunners"`
+
+	// CompletionTime is when the group's last runner was removed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// RunnerUtilization counts the group's finished runners by whether they ran a job
+type RunnerUtilization struct {
+	// RunnersWithJob is how many runners completed after running a job
+	RunnersWithJob int64 `json:"runnersWithJob"`
+
+	// IdleRunners is how many runners were deleted without picking up a job, after staying idle
+	// for spec.startupClaimTimeout
+	IdleRunners int64 `json:"idleRunners"`
+
+	// UtilizationPercent is the share of runnersWithJob among all finished runners
+	UtilizationPercent int `json:"utilizationPercent"`
+
+	// AverageJobDuration is how long the runners that ran a job ran on average, from the start
+	// of their Job to its completion
+	// +optional
+	AverageJobDuration metav1.Duration `json:"averageJobDuration,omitempty"`
+}
+
+// RunnerUsage is the accumulated run time of the group's finished runners
+type RunnerUsage struct {
+	// RunnerSeconds is the run time of all finished runner Jobs, from their start to their
+	// completion or failure, or until they were deleted for being idle
+	RunnerSeconds int64 `json:"runnerSeconds"`
+
+	// RunnerMinutes is runnerSeconds in whole minutes
+	RunnerMinutes int64 `json:"runnerMinutes"`
+
+	// Since is when the group started accounting its runners' run time
+	Since metav1.Time `json:"since"`
+}
+
+// AffectedRunner is an active runner that deleting its RunnerGroup would terminate
+type AffectedRunner struct {
+	// Name is both the runner Job and the name the runner is registered with in Gitea
+	Name string `json:"name"`
+
+	// GiteaJobID is the queued Gitea job the runner was spawned for
+	// +optional
+	GiteaJobID int64 `json:"giteaJobID,omitempty"`
+
+	// Repo is the repository of the Gitea job, as "owner/name"
+	// +optional
+	Repo string `json:"repo,omitempty"`
+
+	// StartTime is when the runner Job started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// ScaleDecision is an audit record of a single scale-up
+type ScaleDecision struct {
+	// Time is when the decision was made
+	Time metav1.Time `json:"time"`
+
+	// QueuedJobs is the number of matching queued jobs at the time
+	QueuedJobs int `json:"queuedJobs"`
+
+	// AvailableSlots is the number of runners that could be spawned
+	AvailableSlots int `json:"availableSlots"`
+
+	// SpawnedJobIDs are the Gitea job IDs that runners were spawned for, in spawn order
+	// +optional
+	SpawnedJobIDs []int64 `json:"spawnedJobIDs,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Scope",type=string,JSONPath=`.spec.scope`
+// +kubebuilder:printcolumn:name="Active",type=integer,JSONPath=`.status.activeRunners`
+// +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.maxActiveRunners`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queuedJobs`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredRunners`
+// +kubebuilder:printcolumn:name="Pending",type=integer,JSONPath=`.status.pendingRunners`,priority=1
+// +kubebuilder:printcolumn:name="Running",type=integer,JSONPath=`.status.runningRunners`,priority=1
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedRunners`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // RunnerGroup is the Schema for the runnergroups API.
 type RunnerGroup struct {
@@ -27,9 +27,974 @@ SOFTWARE.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AffectedRunner) DeepCopyInto(out *AffectedRunner) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AffectedRunner.
+func (in *AffectedRunner) DeepCopy() *AffectedRunner {
+	if in == nil {
+		return nil
+	}
+	out := new(AffectedRunner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingSpec) DeepCopyInto(out *AlertingSpec) {
+	*out = *in
+	out.MaxJobWait = in.MaxJobWait
+	out.MaxGiteaUnreachable = in.MaxGiteaUnreachable
+	out.MaxBacklogDuration = in.MaxBacklogDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingSpec.
+func (in *AlertingSpec) DeepCopy() *AlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSchedule) DeepCopyInto(out *AutoscalingSchedule) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]ScheduleDay, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSchedule.
+func (in *AutoscalingSchedule) DeepCopy() *AutoscalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.ScaleUpCooldown != nil {
+		in, out := &in.ScaleUpCooldown, &out.ScaleUpCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]AutoscalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurstRequest) DeepCopyInto(out *BurstRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurstRequest.
+func (in *BurstRequest) DeepCopy() *BurstRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BurstRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BurstRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurstRequestList) DeepCopyInto(out *BurstRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BurstRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurstRequestList.
+func (in *BurstRequestList) DeepCopy() *BurstRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(BurstRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BurstRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurstRequestSpec) DeepCopyInto(out *BurstRequestSpec) {
+	*out = *in
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurstRequestSpec.
+func (in *BurstRequestSpec) DeepCopy() *BurstRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BurstRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurstRequestStatus) DeepCopyInto(out *BurstRequestStatus) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurstRequestStatus.
+func (in *BurstRequestStatus) DeepCopy() *BurstRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BurstRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegatedJob) DeepCopyInto(out *DelegatedJob) {
+	*out = *in
+	in.DelegatedAt.DeepCopyInto(&out.DelegatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegatedJob.
+func (in *DelegatedJob) DeepCopy() *DelegatedJob {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegatedJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionPreview) DeepCopyInto(out *DeletionPreview) {
+	*out = *in
+	in.GeneratedAt.DeepCopyInto(&out.GeneratedAt)
+	if in.ActiveRunners != nil {
+		in, out := &in.ActiveRunners, &out.ActiveRunners
+		*out = make([]AffectedRunner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionPreview.
+func (in *DeletionPreview) DeepCopy() *DeletionPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainStatus) DeepCopyInto(out *DrainStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainStatus.
+func (in *DrainStatus) DeepCopy() *DrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfig) DeepCopyInto(out *EffectiveConfig) {
+	*out = *in
+	if in.ArchImages != nil {
+		in, out := &in.ArchImages, &out.ArchImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.PollInterval = in.PollInterval
+	if in.MaxRunnerLifetime != nil {
+		in, out := &in.MaxRunnerLifetime, &out.MaxRunnerLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfig.
+func (in *EffectiveConfig) DeepCopy() *EffectiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyStopSpec) DeepCopyInto(out *EmergencyStopSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyStopSpec.
+func (in *EmergencyStopSpec) DeepCopy() *EmergencyStopSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyStopSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventFilterSpec) DeepCopyInto(out *EventFilterSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventFilterSpec.
+func (in *EventFilterSpec) DeepCopy() *EventFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalProvisioner) DeepCopyInto(out *ExternalProvisioner) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalProvisioner.
+func (in *ExternalProvisioner) DeepCopy() *ExternalProvisioner {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalProvisioner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureBudgetSpec) DeepCopyInto(out *FailureBudgetSpec) {
+	*out = *in
+	out.Window = in.Window
+	out.Backoff = in.Backoff
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureBudgetSpec.
+func (in *FailureBudgetSpec) DeepCopy() *FailureBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FairShareSpec) DeepCopyInto(out *FairShareSpec) {
+	*out = *in
+	if in.RepoWeights != nil {
+		in, out := &in.RepoWeights, &out.RepoWeights
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairShareSpec.
+func (in *FairShareSpec) DeepCopy() *FairShareSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FairShareSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaInstance) DeepCopyInto(out *GiteaInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaInstance.
+func (in *GiteaInstance) DeepCopy() *GiteaInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GiteaInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaInstanceList) DeepCopyInto(out *GiteaInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GiteaInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaInstanceList.
+func (in *GiteaInstanceList) DeepCopy() *GiteaInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GiteaInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaInstanceSpec) DeepCopyInto(out *GiteaInstanceSpec) {
+	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WebhookSecretRef != nil {
+		in, out := &in.WebhookSecretRef, &out.WebhookSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.FallbackPollInterval != nil {
+		in, out := &in.FallbackPollInterval, &out.FallbackPollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GiteaInstanceTLS)
+		**out = **in
+	}
+	if in.RequestLimits != nil {
+		in, out := &in.RequestLimits, &out.RequestLimits
+		*out = new(RequestLimits)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaInstanceSpec.
+func (in *GiteaInstanceSpec) DeepCopy() *GiteaInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaInstanceTLS) DeepCopyInto(out *GiteaInstanceTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaInstanceTLS.
+func (in *GiteaInstanceTLS) DeepCopy() *GiteaInstanceTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaInstanceTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaStatus) DeepCopyInto(out *GiteaStatus) {
+	*out = *in
+	out.Latency = in.Latency
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaStatus.
+func (in *GiteaStatus) DeepCopy() *GiteaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobClaim) DeepCopyInto(out *JobClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobClaim.
+func (in *JobClaim) DeepCopy() *JobClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(JobClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobClaimList) DeepCopyInto(out *JobClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JobClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobClaimList.
+func (in *JobClaimList) DeepCopy() *JobClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobClaimSpec) DeepCopyInto(out *JobClaimSpec) {
+	*out = *in
+	in.ClaimedAt.DeepCopyInto(&out.ClaimedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobClaimSpec.
+func (in *JobClaimSpec) DeepCopy() *JobClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobLabelSelector) DeepCopyInto(out *JobLabelSelector) {
+	*out = *in
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]JobLabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobLabelSelector.
+func (in *JobLabelSelector) DeepCopy() *JobLabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(JobLabelSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobLabelSelectorRequirement) DeepCopyInto(out *JobLabelSelectorRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobLabelSelectorRequirement.
+func (in *JobLabelSelectorRequirement) DeepCopy() *JobLabelSelectorRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(JobLabelSelectorRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelMatchingSpec) DeepCopyInto(out *LabelMatchingSpec) {
+	*out = *in
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelMatchingSpec.
+func (in *LabelMatchingSpec) DeepCopy() *LabelMatchingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelMatchingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAutoscalingSpec) DeepCopyInto(out *NodeAutoscalingSpec) {
+	*out = *in
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAutoscalingSpec.
+func (in *NodeAutoscalingSpec) DeepCopy() *NodeAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailureRecoverySpec) DeepCopyInto(out *NodeFailureRecoverySpec) {
+	*out = *in
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureRecoverySpec.
+func (in *NodeFailureRecoverySpec) DeepCopy() *NodeFailureRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailureRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutageRecoverySpec) DeepCopyInto(out *OutageRecoverySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutageRecoverySpec.
+func (in *OutageRecoverySpec) DeepCopy() *OutageRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OutageRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySpec) DeepCopyInto(out *ProxySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySpec.
+func (in *ProxySpec) DeepCopy() *ProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegisteredRunners) DeepCopyInto(out *RegisteredRunners) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisteredRunners.
+func (in *RegisteredRunners) DeepCopy() *RegisteredRunners {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisteredRunners)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoQueue) DeepCopyInto(out *RepoQueue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoQueue.
+func (in *RepoQueue) DeepCopy() *RepoQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestLimits) DeepCopyInto(out *RequestLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestLimits.
+func (in *RequestLimits) DeepCopy() *RequestLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Runner) DeepCopyInto(out *Runner) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Runner.
+func (in *Runner) DeepCopy() *Runner {
+	if in == nil {
+		return nil
+	}
+	out := new(Runner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Runner) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerFailure) DeepCopyInto(out *RunnerFailure) {
+	*out = *in
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerFailure.
+func (in *RunnerFailure) DeepCopy() *RunnerFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerFleet) DeepCopyInto(out *RunnerFleet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerFleet.
+func (in *RunnerFleet) DeepCopy() *RunnerFleet {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerFleet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunnerFleet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerFleetList) DeepCopyInto(out *RunnerFleetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RunnerFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerFleetList.
+func (in *RunnerFleetList) DeepCopy() *RunnerFleetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerFleetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunnerFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerFleetSpec) DeepCopyInto(out *RunnerFleetSpec) {
+	*out = *in
+	if in.EmergencyStop != nil {
+		in, out := &in.EmergencyStop, &out.EmergencyStop
+		*out = new(EmergencyStopSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerFleetSpec.
+func (in *RunnerFleetSpec) DeepCopy() *RunnerFleetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerFleetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerFleetStatus) DeepCopyInto(out *RunnerFleetStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]RunnerGroupSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopRepos != nil {
+		in, out := &in.TopRepos, &out.TopRepos
+		*out = make([]RepoQueue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerFleetStatus.
+func (in *RunnerFleetStatus) DeepCopy() *RunnerFleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerFleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerGroup) DeepCopyInto(out *RunnerGroup) {
 	*out = *in
@@ -97,8 +1062,149 @@ func (in *RunnerGroupSpec) DeepCopyInto(out *RunnerGroupSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	in.RegistrationTokenRef.DeepCopyInto(&out.RegistrationTokenRef)
+	if in.LabelMatching != nil {
+		in, out := &in.LabelMatching, &out.LabelMatching
+		*out = new(LabelMatchingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobLabelSelector != nil {
+		in, out := &in.JobLabelSelector, &out.JobLabelSelector
+		*out = new(JobLabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventFilter != nil {
+		in, out := &in.EventFilter, &out.EventFilter
+		*out = new(EventFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelNodeSelectors != nil {
+		in, out := &in.LabelNodeSelectors, &out.LabelNodeSelectors
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.RegistrationTokenRef != nil {
+		in, out := &in.RegistrationTokenRef, &out.RegistrationTokenRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistrationTokenRefreshInterval != nil {
+		in, out := &in.RegistrationTokenRefreshInterval, &out.RegistrationTokenRefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	in.AuthTokenRef.DeepCopyInto(&out.AuthTokenRef)
+	if in.SharedAuthTokenRef != nil {
+		in, out := &in.SharedAuthTokenRef, &out.SharedAuthTokenRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.BasicAuthRef != nil {
+		in, out := &in.BasicAuthRef, &out.BasicAuthRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.OAuth2Ref != nil {
+		in, out := &in.OAuth2Ref, &out.OAuth2Ref
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		**out = **in
+	}
+	if in.SpawnOrder != nil {
+		in, out := &in.SpawnOrder, &out.SpawnOrder
+		*out = new(SpawnOrderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FairShare != nil {
+		in, out := &in.FairShare, &out.FairShare
+		*out = new(FairShareSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutageRecovery != nil {
+		in, out := &in.OutageRecovery, &out.OutageRecovery
+		*out = new(OutageRecoverySpec)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFailureRecovery != nil {
+		in, out := &in.NodeFailureRecovery, &out.NodeFailureRecovery
+		*out = new(NodeFailureRecoverySpec)
+		**out = **in
+	}
+	if in.Spot != nil {
+		in, out := &in.Spot, &out.Spot
+		*out = new(SpotSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeAutoscaling != nil {
+		in, out := &in.NodeAutoscaling, &out.NodeAutoscaling
+		*out = new(NodeAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxRunnerLifetime != nil {
+		in, out := &in.MaxRunnerLifetime, &out.MaxRunnerLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxJobDuration != nil {
+		in, out := &in.MaxJobDuration, &out.MaxJobDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StartupClaimTimeout != nil {
+		in, out := &in.StartupClaimTimeout, &out.StartupClaimTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureBudget != nil {
+		in, out := &in.FailureBudget, &out.FailureBudget
+		*out = new(FailureBudgetSpec)
+		**out = **in
+	}
+	if in.DeletionGracePeriod != nil {
+		in, out := &in.DeletionGracePeriod, &out.DeletionGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CostCenterLabels != nil {
+		in, out := &in.CostCenterLabels, &out.CostCenterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(AlertingSpec)
+		**out = **in
+	}
+	if in.ExternalProvisioners != nil {
+		in, out := &in.ExternalProvisioners, &out.ExternalProvisioners
+		*out = make([]ExternalProvisioner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerGroupSpec.
@@ -114,10 +1220,88 @@ func (in *RunnerGroupSpec) DeepCopy() *RunnerGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerGroupStatus) DeepCopyInto(out *RunnerGroupStatus) {
 	*out = *in
+	if in.RunnerFailureReasons != nil {
+		in, out := &in.RunnerFailureReasons, &out.RunnerFailureReasons
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.LastCheckTime != nil {
 		in, out := &in.LastCheckTime, &out.LastCheckTime
 		*out = (*in).DeepCopy()
 	}
+	if in.GiteaUnreachableSince != nil {
+		in, out := &in.GiteaUnreachableSince, &out.GiteaUnreachableSince
+		*out = (*in).DeepCopy()
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(GiteaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegisteredRunners != nil {
+		in, out := &in.RegisteredRunners, &out.RegisteredRunners
+		*out = new(RegisteredRunners)
+		**out = **in
+	}
+	if in.RecoveryStartTime != nil {
+		in, out := &in.RecoveryStartTime, &out.RecoveryStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BacklogSince != nil {
+		in, out := &in.BacklogSince, &out.BacklogSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScaleDecision != nil {
+		in, out := &in.LastScaleDecision, &out.LastScaleDecision
+		*out = new(ScaleDecision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopQueuedRepos != nil {
+		in, out := &in.TopQueuedRepos, &out.TopQueuedRepos
+		*out = make([]RepoQueue, len(*in))
+		copy(*out, *in)
+	}
+	if in.DelegatedJobs != nil {
+		in, out := &in.DelegatedJobs, &out.DelegatedJobs
+		*out = make([]DelegatedJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(EffectiveConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionPreview != nil {
+		in, out := &in.DeletionPreview, &out.DeletionPreview
+		*out = new(DeletionPreview)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Utilization != nil {
+		in, out := &in.Utilization, &out.Utilization
+		*out = new(RunnerUtilization)
+		**out = **in
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(RunnerUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerGroupStatus.
@@ -129,3 +1313,221 @@ func (in *RunnerGroupStatus) DeepCopy() *RunnerGroupStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerGroupSummary) DeepCopyInto(out *RunnerGroupSummary) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerGroupSummary.
+func (in *RunnerGroupSummary) DeepCopy() *RunnerGroupSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerGroupSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerList) DeepCopyInto(out *RunnerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Runner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerList.
+func (in *RunnerList) DeepCopy() *RunnerList {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunnerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerSpec) DeepCopyInto(out *RunnerSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerSpec.
+func (in *RunnerSpec) DeepCopy() *RunnerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerStatus) DeepCopyInto(out *RunnerStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(RunnerFailure)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerStatus.
+func (in *RunnerStatus) DeepCopy() *RunnerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerUsage) DeepCopyInto(out *RunnerUsage) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerUsage.
+func (in *RunnerUsage) DeepCopy() *RunnerUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerUtilization) DeepCopyInto(out *RunnerUtilization) {
+	*out = *in
+	out.AverageJobDuration = in.AverageJobDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerUtilization.
+func (in *RunnerUtilization) DeepCopy() *RunnerUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDecision) DeepCopyInto(out *ScaleDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.SpawnedJobIDs != nil {
+		in, out := &in.SpawnedJobIDs, &out.SpawnedJobIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDecision.
+func (in *ScaleDecision) DeepCopy() *ScaleDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpawnOrderSpec) DeepCopyInto(out *SpawnOrderSpec) {
+	*out = *in
+	if in.PriorityLabels != nil {
+		in, out := &in.PriorityLabels, &out.PriorityLabels
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpawnOrderSpec.
+func (in *SpawnOrderSpec) DeepCopy() *SpawnOrderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpawnOrderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotSpec) DeepCopyInto(out *SpotSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotSpec.
+func (in *SpotSpec) DeepCopy() *SpotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
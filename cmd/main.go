@@ -23,10 +23,13 @@ SOFTWARE.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -34,18 +37,26 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
 	"github.com/bapung/gitea-runner-operator/internal/controller"
+	"github.com/bapung/gitea-runner-operator/internal/dashboard"
 	"github.com/bapung/gitea-runner-operator/internal/gitea"
+	"github.com/bapung/gitea-runner-operator/internal/kedascaler"
+	"github.com/bapung/gitea-runner-operator/internal/metricspush"
+	"github.com/bapung/gitea-runner-operator/internal/operatorconfig"
+	"github.com/bapung/gitea-runner-operator/internal/receiver"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -67,9 +78,29 @@ func main() {
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var dashboardAddr, dashboardCredentialsFile string
+	var startupConcurrency int
+	var startupQPS float64
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var metricsPushURL, metricsPushJob, metricsOTLPEndpoint string
+	var metricsPushInterval time.Duration
+	var enablePrometheusRules bool
+	var instancePollers bool
+	var giteaWebhookAddr string
+	var kedaScalerAddr string
+	var emergencyStop, emergencyStopDrain bool
+	var runnerImages, defaultRunnerArch string
+	var configFile string
+	var giteaHTTPDump bool
+	var authTokenSources controller.AuthTokenSources
+	var pprofAddr string
+	var workqueueOptions controller.WorkqueueOptions
+	var watchNamespaces string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -77,6 +108,12 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long standby replicas wait before taking over a lease the leader stopped renewing.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader retries renewing its lease before it gives up leadership and exits.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often replicas try to acquire or renew the lease.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
@@ -88,9 +125,74 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&dashboardAddr, "dashboard-bind-address", "0", "The address the read-only web dashboard binds to, "+
+		"e.g. :8082. Leave as 0 to disable the dashboard.")
+	flag.StringVar(&dashboardCredentialsFile, "dashboard-credentials-file", "",
+		"File containing 'username:password' for basic auth on the dashboard. Required if the dashboard is enabled.")
+	flag.IntVar(&startupConcurrency, "startup-concurrency", 4,
+		"How many RunnerGroups have their existing runners adopted in parallel on startup.")
+	flag.Float64Var(&startupQPS, "startup-qps", 10,
+		"Maximum Kubernetes API requests per second made while adopting existing runners on startup.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 50,
+		"Maximum Kubernetes API requests per second of the operator's client. Raise it if large scale-ups "+
+			"are throttled client-side.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 100,
+		"Maximum burst of Kubernetes API requests of the operator's client.")
+	flag.StringVar(&metricsPushURL, "metrics-push-url", "",
+		"Prometheus Pushgateway URL to push metrics to, for clusters where the metrics endpoint isn't scraped. "+
+			"Leave empty to disable pushing.")
+	flag.StringVar(&metricsPushJob, "metrics-push-job", "gitea-runner-operator", "The job name metrics are pushed under.")
+	flag.StringVar(&metricsOTLPEndpoint, "metrics-otlp-endpoint", "",
+		"OpenTelemetry collector OTLP/HTTP endpoint to export metrics to, e.g. http://otel-collector:4318. "+
+			"Leave empty to disable exporting.")
+	flag.DurationVar(&metricsPushInterval, "metrics-push-interval", time.Minute,
+		"How often metrics are pushed to the Pushgateway or exported over OTLP.")
+	flag.BoolVar(&enablePrometheusRules, "enable-prometheus-rules", false,
+		"If set, a PrometheusRule with alerts is generated for every RunnerGroup. Requires the Prometheus Operator.")
+	flag.BoolVar(&instancePollers, "instance-pollers", false,
+		"If set, a poller per GiteaInstance crawls queued jobs in the background and enqueues RunnerGroups whose "+
+			"queue changed, instead of RunnerGroups waiting for Gitea while they reconcile.")
+	flag.StringVar(&giteaWebhookAddr, "gitea-webhook-bind-address", "0", "The address the Gitea webhook receiver "+
+		"binds to, e.g. :8083. Leave as 0 to disable the receiver and rely on polling only.")
+	flag.StringVar(&kedaScalerAddr, "keda-scaler-bind-address", "0", "The address the KEDA external scaler gRPC "+
+		"service binds to, e.g. :9090. Leave as 0 to disable the scaler.")
+	flag.BoolVar(&emergencyStop, "emergency-stop", false,
+		"If set, no RunnerGroup creates runners. Prefer spec.emergencyStop on the RunnerFleet, which needs no restart.")
+	flag.BoolVar(&emergencyStopDrain, "emergency-stop-drain", false,
+		"With --emergency-stop, also delete all running runners.")
 	opts := zap.Options{
 		Development: true,
 	}
+	flag.StringVar(&runnerImages, "runner-images", "",
+		"Runner images per node architecture as arch=image pairs, e.g. amd64=img1,arm64=img2. "+
+			"Leave empty to use the multi-arch default image everywhere.")
+	flag.StringVar(&defaultRunnerArch, "default-runner-arch", "amd64",
+		"The architecture runners are pinned to when --runner-images is set and a job doesn't select one.")
+	flag.StringVar(&configFile, "config", "",
+		"A YAML file with operator-wide defaults, reloaded when it changes. Leave empty to use the built-in defaults.")
+	flag.BoolVar(&giteaHTTPDump, "gitea-http-dump", false,
+		"If set, every Gitea API request and response is logged with credentials redacted, for troubleshooting.")
+	flag.BoolVar(&authTokenSources.AllowCrossNamespaceSecrets, "allow-cross-namespace-secrets", false,
+		"If set, a RunnerGroup's sharedAuthToken may reference a Secret in another namespace, provided the Secret's "+
+			"gitea.bpg.pw/shared-with-namespaces annotation lists the group's namespace.")
+	flag.StringVar(&authTokenSources.Dir, "auth-token-dir", "",
+		"A directory of Gitea API token files, e.g. a secrets-store CSI volume, RunnerGroups may name in "+
+			"spec.authTokenFile. Leave empty to disallow authTokenFile.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "0", "The address pprof profiles are served on, "+
+		"e.g. 127.0.0.1:8084. Leave as 0 to disable them.")
+	flag.IntVar(&workqueueOptions.MaxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"How many RunnerGroups, and separately Runners, are reconciled in parallel.")
+	flag.DurationVar(&workqueueOptions.RetryBaseDelay, "reconcile-retry-base-delay", 5*time.Millisecond,
+		"The delay before retrying a failed reconcile, doubled on every further failure of the same object.")
+	flag.DurationVar(&workqueueOptions.RetryMaxDelay, "reconcile-retry-max-delay", 1000*time.Second,
+		"The longest delay before retrying a failed reconcile.")
+	flag.Float64Var(&workqueueOptions.RetryQPS, "reconcile-retry-qps", 10,
+		"The retries of failed reconciles allowed per second across all objects of a controller.")
+	flag.IntVar(&workqueueOptions.RetryBurst, "reconcile-retry-burst", 100,
+		"The burst of retries of failed reconciles allowed above --reconcile-retry-qps.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma-separated namespaces the operator manages RunnerGroups, Runners and BurstRequests in. "+
+			"All namespaces are watched if empty.")
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -185,40 +287,212 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = float32(kubeAPIQPS)
+	restConfig.Burst = kubeAPIBurst
+
+	var cacheOptions cache.Options
+	for _, namespace := range strings.Split(watchNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace == "" {
+			continue
+		}
+		if cacheOptions.DefaultNamespaces == nil {
+			cacheOptions.DefaultNamespaces = make(map[string]cache.Config)
+		}
+		cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+	}
+	if cacheOptions.DefaultNamespaces != nil {
+		setupLog.Info("Watching selected namespaces only", "namespaces", watchNamespaces)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "570e4a1e.bpg.pw",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// The program ends as soon as the manager stops, so the leader can hand over its
+		// lease right away instead of making the next leader wait LeaseDuration.
+		LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	if err := (&controller.RunnerGroupReconciler{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		GiteaClient: gitea.NewHTTPClient(),
-	}).SetupWithManager(mgr); err != nil {
+	giteaClient := gitea.NewHTTPClient()
+	giteaClient.SetDump(giteaHTTPDump)
+	var operatorConfig *operatorconfig.Watcher
+	if configFile != "" {
+		operatorConfig = &operatorconfig.Watcher{Path: configFile}
+		operatorConfig.OnChange(func(config *operatorconfig.Config) {
+			giteaClient.Configure(config.Gitea.Timeout.Duration, config.Gitea.MaxIdleConnsPerHost, config.Gitea.MaxRetries)
+			giteaClient.SetRepoConcurrency(config.Gitea.RepoConcurrency)
+		})
+		if err := operatorConfig.Load(); err != nil {
+			setupLog.Error(err, "unable to load operator config")
+			os.Exit(1)
+		}
+		if err := mgr.Add(operatorConfig); err != nil {
+			setupLog.Error(err, "unable to add operator config watcher to manager")
+			os.Exit(1)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create Kubernetes clientset")
+		os.Exit(1)
+	}
+
+	registrationTokens := &controller.RegistrationTokenCache{}
+	authTokenSources.OAuth2 = &controller.OAuth2TokenCache{Client: mgr.GetClient(), GiteaClient: giteaClient}
+	// A sharedAuthToken in another namespace may be outside the namespaces the cache watches
+	authTokenSources.SecretReader = mgr.GetAPIReader()
+	runnerGroupReconciler := &controller.RunnerGroupReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		GiteaClient:           giteaClient,
+		RegistrationTokens:    registrationTokens,
+		AuthTokenSources:      authTokenSources,
+		Config:                operatorConfig,
+		Recorder:              mgr.GetEventRecorderFor("runnergroup-controller"),
+		EmergencyStop:         emergencyStop,
+		EmergencyStopDrain:    emergencyStopDrain,
+		EnablePrometheusRules: enablePrometheusRules,
+		StartupConcurrency:    startupConcurrency,
+		StartupQPS:            startupQPS,
+		Elected:               mgr.Elected(),
+		Workqueue:             workqueueOptions,
+		DefaultRunnerArch:     defaultRunnerArch,
+		WebhooksEnabled:       giteaWebhookAddr != "" && giteaWebhookAddr != "0",
+		InstancePollers:       instancePollers,
+	}
+	if runnerGroupReconciler.RunnerImages, err = controller.ParseRunnerImages(runnerImages); err != nil {
+		setupLog.Error(err, "invalid --runner-images")
+		os.Exit(1)
+	}
+	if err := runnerGroupReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RunnerGroup")
 		os.Exit(1)
 	}
+	if err := (&controller.RunnerReconciler{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GiteaClient:        giteaClient,
+		RegistrationTokens: registrationTokens,
+		AuthTokenSources:   authTokenSources,
+		PodLogs:            &controller.ClientsetPodLogReader{Clientset: clientset},
+		Recorder:           mgr.GetEventRecorderFor("runner-controller"),
+		Workqueue:          workqueueOptions,
+		APIReader:          mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Runner")
+		os.Exit(1)
+	}
+	if err := (&controller.BurstRequestReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BurstRequest")
+		os.Exit(1)
+	}
+	// The RunnerFleet is a cluster-wide singleton; an operator serving some namespaces only would
+	// overwrite its status with their groups alone
+	if cacheOptions.DefaultNamespaces == nil {
+		if err := (&controller.RunnerFleetReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "RunnerFleet")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Not running the RunnerFleet controller, since only selected namespaces are watched")
+	}
 	// +kubebuilder:scaffold:builder
 
+	if dashboardAddr != "" && dashboardAddr != "0" {
+		if dashboardCredentialsFile == "" {
+			setupLog.Error(nil, "--dashboard-credentials-file is required when the dashboard is enabled")
+			os.Exit(1)
+		}
+		username, password, err := dashboard.LoadCredentials(dashboardCredentialsFile)
+		if err != nil {
+			setupLog.Error(err, "unable to load dashboard credentials")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&dashboard.Server{
+			Client:      mgr.GetClient(),
+			BindAddress: dashboardAddr,
+			Username:    username,
+			Password:    password,
+		}); err != nil {
+			setupLog.Error(err, "unable to add dashboard to manager")
+			os.Exit(1)
+		}
+	}
+
+	if giteaWebhookAddr != "" && giteaWebhookAddr != "0" {
+		if err := mgr.Add(&receiver.Server{
+			Client: mgr.GetClient(),
+			// Webhook secrets are usually in the operator's namespace, which --watch-namespaces
+			// may leave out of the cache
+			SecretReader: mgr.GetAPIReader(),
+			BindAddress:  giteaWebhookAddr,
+			Trigger: func(ctx context.Context, event receiver.WorkflowJobEvent) {
+				runnerGroupReconciler.TriggerPoll(ctx, event.GiteaURL, event.Repository, event.Delivery)
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to add Gitea webhook receiver to manager")
+			os.Exit(1)
+		}
+	}
+
+	if kedaScalerAddr != "" && kedaScalerAddr != "0" {
+		if err := mgr.Add(&kedascaler.Server{
+			Client:      mgr.GetClient(),
+			BindAddress: kedaScalerAddr,
+		}); err != nil {
+			setupLog.Error(err, "unable to add KEDA external scaler to manager")
+			os.Exit(1)
+		}
+	}
+
+	if metricsPushURL != "" {
+		instance, _ := os.Hostname()
+		if err := mgr.Add(&metricspush.Pusher{
+			URL:      metricsPushURL,
+			Job:      metricsPushJob,
+			Instance: instance,
+			Interval: metricsPushInterval,
+			Gatherer: metrics.Registry,
+		}); err != nil {
+			setupLog.Error(err, "unable to add metrics pusher to manager")
+			os.Exit(1)
+		}
+	}
+
+	if metricsOTLPEndpoint != "" {
+		instance, _ := os.Hostname()
+		if err := mgr.Add(&metricspush.OTLPExporter{
+			Endpoint:    metricsOTLPEndpoint,
+			ServiceName: metricsPushJob,
+			Instance:    instance,
+			Interval:    metricsPushInterval,
+			Gatherer:    metrics.Registry,
+		}); err != nil {
+			setupLog.Error(err, "unable to add OTLP metrics exporter to manager")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
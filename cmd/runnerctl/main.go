@@ -0,0 +1,258 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Command runnerctl inspects the queued jobs of a Gitea scope and explains which RunnerGroup,
+// if any, serves them, for troubleshooting jobs that don't get a runner.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/controller"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+const usage = `Usage: runnerctl [flags] <command>
+
+Commands:
+  jobs            list the queued jobs of the scope and the RunnerGroup serving each
+  explain JOB_ID  explain for every RunnerGroup of the Gitea instance whether it serves the job
+
+Flags:
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(giteav1alpha1.AddToScheme(scheme))
+}
+
+// options are the command line flags shared by all commands
+type options struct {
+	giteaURL           string
+	token              string
+	scope              string
+	org, user, repo    string
+	labels             string
+	sudo               string
+	insecureSkipVerify bool
+	namespace          string
+	noCluster          bool
+	timeout            time.Duration
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.giteaURL, "gitea-url", "", "The base URL of the Gitea instance, e.g. https://gitea.example.com.")
+	flag.StringVar(&opts.token, "token", os.Getenv("GITEA_TOKEN"),
+		"The Gitea API token. Defaults to the GITEA_TOKEN environment variable.")
+	flag.StringVar(&opts.scope, "scope", string(giteav1alpha1.RunnerGroupScopeGlobal),
+		"The scope to list queued jobs of: global, org, user or repo.")
+	flag.StringVar(&opts.org, "org", "", "The organization, for org scope or the owner of a repo scope.")
+	flag.StringVar(&opts.user, "user", "", "The user, for user scope or the owner of a repo scope.")
+	flag.StringVar(&opts.repo, "repo", "", "The repository name, for repo scope.")
+	flag.StringVar(&opts.labels, "labels", "",
+		"Comma-separated runner labels; only queued jobs these labels satisfy are listed. Lists all queued jobs if empty.")
+	flag.StringVar(&opts.sudo, "sudo", "", "A Gitea user to act as, like a RunnerGroup's spec.sudo.")
+	flag.BoolVar(&opts.insecureSkipVerify, "insecure-skip-verify", false,
+		"If set, the Gitea instance's TLS certificate isn't verified.")
+	flag.StringVar(&opts.namespace, "namespace", "",
+		"The namespace to read RunnerGroups from. RunnerGroups of all namespaces are read if empty.")
+	flag.BoolVar(&opts.noCluster, "no-cluster", false,
+		"If set, RunnerGroups aren't read from the cluster and only the queued jobs are listed.")
+	flag.DurationVar(&opts.timeout, "timeout", time.Minute, "How long the command may take.")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(opts, flag.Args(), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "runnerctl:", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the command named by the first argument
+func run(opts options, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("no command given")
+	}
+	if opts.giteaURL == "" {
+		return fmt.Errorf("--gitea-url is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+	if opts.sudo != "" {
+		ctx = gitea.WithSudo(ctx, opts.sudo)
+	}
+	if opts.insecureSkipVerify {
+		ctx = gitea.WithInsecureSkipVerify(ctx, true)
+	}
+
+	switch args[0] {
+	case "jobs":
+		return listJobs(ctx, opts, out)
+	case "explain":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: runnerctl [flags] explain JOB_ID")
+		}
+		jobID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid job ID %q: %w", args[1], err)
+		}
+		return explainJob(ctx, opts, jobID, out)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// listJobs prints the queued jobs of the scope with the RunnerGroup that serves each
+func listJobs(ctx context.Context, opts options, out io.Writer) error {
+	jobs, err := queuedJobs(ctx, opts)
+	if err != nil {
+		return err
+	}
+	runnerGroups, err := listRunnerGroups(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tREPOSITORY\tNAME\tLABELS\tWAITING\tRUNNERGROUP")
+	for _, job := range jobs {
+		served := "-"
+		if !opts.noCluster {
+			served = "<none>"
+			if matches := controller.ExplainJob(opts.giteaURL, runnerGroups, job); len(matches) > 0 && matches[0].Matches {
+				served = matches[0].RunnerGroup.String()
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", job.ID, job.RepoFullName(), job.Name,
+			strings.Join(job.Labels, ","), time.Since(job.CreatedAt).Round(time.Second), served)
+	}
+	return w.Flush()
+}
+
+// explainJob prints for every RunnerGroup of the Gitea instance whether it serves the queued job
+func explainJob(ctx context.Context, opts options, jobID int64, out io.Writer) error {
+	jobs, err := queuedJobs(ctx, opts)
+	if err != nil {
+		return err
+	}
+	var job *gitea.ActionWorkflowJob
+	for i := range jobs {
+		if jobs[i].ID == jobID {
+			job = &jobs[i]
+			break
+		}
+	}
+	if job == nil {
+		return fmt.Errorf("job %d is not queued in the scope", jobID)
+	}
+	fmt.Fprintf(out, "Job %d %q of %s requests labels %v\n", job.ID, job.Name, job.RepoFullName(), job.Labels)
+
+	if opts.noCluster {
+		return nil
+	}
+	runnerGroups, err := listRunnerGroups(ctx, opts)
+	if err != nil {
+		return err
+	}
+	matches := controller.ExplainJob(opts.giteaURL, runnerGroups, *job)
+	if len(matches) == 0 {
+		fmt.Fprintf(out, "No RunnerGroup polls %s\n", opts.giteaURL)
+		return nil
+	}
+	if !matches[0].Matches {
+		fmt.Fprintln(out, "No RunnerGroup serves the job")
+	}
+	for i, match := range matches {
+		switch {
+		case i == 0 && match.Matches:
+			fmt.Fprintf(out, "  %s (priority %d): serves the job", match.RunnerGroup, match.Priority)
+		case match.Matches:
+			fmt.Fprintf(out, "  %s (priority %d): matches, but only gets jobs the groups above have no free slots for",
+				match.RunnerGroup, match.Priority)
+		default:
+			fmt.Fprintf(out, "  %s (priority %d): doesn't match, %s", match.RunnerGroup, match.Priority, match.Reason)
+		}
+		if match.Matches && match.Reason != "" {
+			fmt.Fprintf(out, "; blocked by %s", match.Reason)
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprintln(out, "RunnerGroups with an eventFilter may still skip the job by the event or branch of its workflow run")
+	return nil
+}
+
+// queuedJobs lists the queued jobs of the scope that the --labels satisfy
+func queuedJobs(ctx context.Context, opts options) ([]gitea.ActionWorkflowJob, error) {
+	var labels []string
+	for _, label := range strings.Split(opts.labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	stats, err := gitea.NewHTTPClient().GetRunnerStats(ctx, opts.giteaURL, opts.token,
+		giteav1alpha1.RunnerGroupScope(opts.scope), opts.org, opts.user, opts.repo, labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued jobs: %w", err)
+	}
+	return stats.QueuedJobs, nil
+}
+
+// listRunnerGroups reads the RunnerGroups from the cluster of the current kubeconfig context
+func listRunnerGroups(ctx context.Context, opts options) ([]giteav1alpha1.RunnerGroup, error) {
+	if opts.noCluster {
+		return nil, nil
+	}
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig, use --no-cluster to list jobs only: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := c.List(ctx, runnerGroupList, client.InNamespace(opts.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list RunnerGroups: %w", err)
+	}
+	return runnerGroupList.Items, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// defaultStartupConcurrency is how many RunnerGroups are adopted in parallel on startup
+	defaultStartupConcurrency = 4
+	// defaultStartupQPS limits the API requests made while adopting runners on startup
+	defaultStartupQPS = 10
+	// adoptionWaitInterval is how often reconciles check whether startup adoption has finished
+	adoptionWaitInterval = 5 * time.Second
+)
+
+// runnerAdopter rebuilds the spawned jobs cache from the runner Jobs that already exist when the
+// operator starts, so jobs provisioned before a restart aren't provisioned again
+type runnerAdopter struct {
+	reconciler  *RunnerGroupReconciler
+	concurrency int
+	qps         float64
+}
+
+// Start implements manager.Runnable
+func (a *runnerAdopter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("runner-adoption")
+	r := a.reconciler
+	defer r.adoptionPending.Store(false)
+
+	concurrency := a.concurrency
+	if concurrency <= 0 {
+		concurrency = defaultStartupConcurrency
+	}
+	qps := a.qps
+	if qps <= 0 {
+		qps = defaultStartupQPS
+	}
+	limiter := flowcontrol.NewTokenBucketRateLimiter(float32(qps), concurrency)
+	defer limiter.Stop()
+
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		// Not fatal: reconciles fall back to JobClaims for deduplication
+		logger.Error(err, "Failed to list RunnerGroups, skipping runner adoption")
+		return nil
+	}
+
+	start := time.Now()
+	total := len(runnerGroupList.Items)
+	logger.Info("Adopting runners", "runnerGroups", total, "concurrency", concurrency, "qps", qps)
+
+	var wg sync.WaitGroup
+	var done atomic.Int32
+	sem := make(chan struct{}, concurrency)
+	for i := range runnerGroupList.Items {
+		runnerGroup := &runnerGroupList.Items[i]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			adopted, err := r.adoptRunners(ctx, limiter, runnerGroup)
+			if err != nil {
+				logger.Error(err, "Failed to adopt runners", "runnerGroup", client.ObjectKeyFromObject(runnerGroup))
+			}
+			logger.V(1).Info("Adopted runners", "runnerGroup", client.ObjectKeyFromObject(runnerGroup),
+				"runners", adopted, "progress", fmt.Sprintf("%d/%d", done.Add(1), total))
+		}()
+	}
+	wg.Wait()
+
+	logger.Info("Runner adoption finished", "runnerGroups", total, "duration", time.Since(start))
+	return nil
+}
+
+// adoptRunners records the Gitea jobs the RunnerGroup's existing runner Jobs were spawned for
+// and reports the outcome in the group's Adopted condition
+func (r *RunnerGroupReconciler) adoptRunners(ctx context.Context, limiter flowcontrol.RateLimiter, runnerGroup *giteav1alpha1.RunnerGroup) (int, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	jobs, err := r.listRunnerJobs(ctx, runnerGroup)
+	if err != nil {
+		return 0, err
+	}
+
+	adopted := 0
+	for _, job := range jobs {
+		if job.Status.CompletionTime != nil {
+			continue
+		}
+		id, err := strconv.ParseInt(job.Annotations[giteaJobIDAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		r.SpawnedJobsCache.LoadOrStore(id, job.CreationTimestamp.Time)
+		adopted++
+	}
+
+	// The whole status is written, since a merge patch would create the status of a group that
+	// was never reconciled without its required activeRunners
+	if err := limiter.Wait(ctx); err != nil {
+		return adopted, err
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(runnerGroup), runnerGroup); err != nil {
+		return adopted, err
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionAdopted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RunnersAdopted",
+		Message:            fmt.Sprintf("Adopted %d runner(s) on operator startup", adopted),
+		ObservedGeneration: runnerGroup.Generation,
+	})
+	if err := limiter.Wait(ctx); err != nil {
+		return adopted, err
+	}
+	return adopted, r.Status().Update(ctx, runnerGroup)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// tokenRetryInterval is how often a RunnerGroup whose auth token Gitea rejected polls again.
+// An expired or revoked token doesn't recover by itself, so hot-polling only floods the logs.
+const tokenRetryInterval = 5 * time.Minute
+
+// authTokenEnvPrefix is the prefix of the operator environment variables RunnerGroups may read
+// their token from, so a RunnerGroup can't send any other variable of the operator to Gitea
+const authTokenEnvPrefix = "GITEA_AUTH_TOKEN_"
+
+// sharedWithNamespacesAnnotation lists, comma-separated, the namespaces whose RunnerGroups may
+// use a Secret as sharedAuthToken. "*" shares it with every namespace.
+const sharedWithNamespacesAnnotation = "gitea.bpg.pw/shared-with-namespaces"
+
+// AuthTokenSources configures where RunnerGroups may read their Gitea API token from besides a
+// Secret in their own namespace. A RunnerGroup author chooses its giteaURL, so every source
+// opened here is one the author can send to a server of their choosing.
+type AuthTokenSources struct {
+	// AllowCrossNamespaceSecrets lets sharedAuthToken reference a Secret outside the group's
+	// namespace, provided the Secret is annotated as shared with the group's namespace
+	AllowCrossNamespaceSecrets bool
+
+	// SecretReader reads Secrets outside the group's namespace, which may be missing from a cache
+	// restricted to the watched namespaces. The reconciler's client is used if nil.
+	SecretReader client.Reader
+
+	// Dir is the directory authTokenFile names a file in, e.g. a secrets-store CSI volume or a
+	// directory Vault Agent renders into. authTokenFile is refused if empty.
+	Dir string
+
+	// OAuth2 refreshes the access tokens of groups with oauth2 credentials
+	OAuth2 *OAuth2TokenCache
+}
+
+// readAuthToken returns the group's Gitea API token from the first source it sets, in order:
+// sharedAuthToken, authTokenFile, authTokenEnv, basicAuth, oauth2 and authToken. Files and variables are
+// read on every call, so tokens rotated by a secrets driver are picked up right away. For
+// basicAuth and oauth2 the "token" is gitea.BasicAuth or gitea.BearerAuth credentials, which the
+// Gitea client accepts alike.
+func readAuthToken(ctx context.Context, c client.Reader, runnerGroup *giteav1alpha1.RunnerGroup, sources AuthTokenSources) (string, error) {
+	switch {
+	case runnerGroup.Spec.SharedAuthTokenRef != nil:
+		return readSharedAuthToken(ctx, c, runnerGroup, sources)
+	case runnerGroup.Spec.AuthTokenFile != "":
+		return readAuthTokenFile(sources.Dir, runnerGroup.Spec.AuthTokenFile)
+	case runnerGroup.Spec.AuthTokenEnv != "":
+		return readAuthTokenEnv(runnerGroup.Spec.AuthTokenEnv)
+	case runnerGroup.Spec.BasicAuthRef != nil:
+		return readBasicAuth(ctx, c, runnerGroup.Namespace, runnerGroup.Spec.BasicAuthRef.Name)
+	case runnerGroup.Spec.OAuth2Ref != nil:
+		accessToken, err := sources.OAuth2.accessToken(ctx, runnerGroup.Spec.GiteaURL,
+			types.NamespacedName{Namespace: runnerGroup.Namespace, Name: runnerGroup.Spec.OAuth2Ref.Name})
+		if err != nil {
+			return "", err
+		}
+		return gitea.BearerAuth(accessToken), nil
+	default:
+		return readSecretValue(ctx, c, runnerGroup.Namespace, runnerGroup.Spec.AuthTokenRef)
+	}
+}
+
+// readSharedAuthToken reads the group's sharedAuthToken. A Secret in another namespace is only
+// read if cross-namespace secrets are allowed and the Secret itself opts in through
+// sharedWithNamespacesAnnotation, so the flag doesn't expose every Secret in the cluster.
+func readSharedAuthToken(ctx context.Context, c client.Reader, runnerGroup *giteav1alpha1.RunnerGroup, sources AuthTokenSources) (string, error) {
+	ref := runnerGroup.Spec.SharedAuthTokenRef
+	if ref.Namespace == runnerGroup.Namespace {
+		return readSecretValue(ctx, c, ref.Namespace, corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		})
+	}
+	if !sources.AllowCrossNamespaceSecrets {
+		return "", fmt.Errorf("sharedAuthToken references a Secret in namespace %s, but cross-namespace secrets are not allowed", ref.Namespace)
+	}
+
+	reader := c
+	if sources.SecretReader != nil {
+		reader = sources.SecretReader
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	if !sharedWithNamespace(secret, runnerGroup.Namespace) {
+		return "", fmt.Errorf("secret %s/%s is not shared with namespace %s, annotate it with %s to allow it",
+			ref.Namespace, ref.Name, runnerGroup.Namespace, sharedWithNamespacesAnnotation)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+	}
+	return string(value), nil
+}
+
+// sharedWithNamespace returns whether secret's sharedWithNamespacesAnnotation lists namespace
+func sharedWithNamespace(secret *corev1.Secret, namespace string) bool {
+	for _, allowed := range strings.Split(secret.Annotations[sharedWithNamespacesAnnotation], ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// readAuthTokenFile reads a token from a file in dir. The name may not leave dir.
+func readAuthTokenFile(dir, name string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("authTokenFile is set, but the operator has no auth token directory configured")
+	}
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("authTokenFile %q must be a relative path within the auth token directory", name)
+	}
+	value, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read auth token file: %w", err)
+	}
+	token := strings.TrimSpace(string(value))
+	if token == "" {
+		return "", fmt.Errorf("auth token file %s is empty", name)
+	}
+	return token, nil
+}
+
+// readBasicAuth reads Gitea basic auth credentials from the username and password keys of a Secret
+func readBasicAuth(ctx context.Context, c client.Reader, namespace, name string) (string, error) {
+	credentials := make(map[string]string, 2)
+	for _, key := range []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey} {
+		value, err := readSecretValue(ctx, c, namespace, corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		})
+		if err != nil {
+			return "", err
+		}
+		credentials[key] = value
+	}
+	return gitea.BasicAuth(credentials[corev1.BasicAuthUsernameKey], credentials[corev1.BasicAuthPasswordKey]), nil
+}
+
+// readAuthTokenEnv reads a token from one of the operator's GITEA_AUTH_TOKEN_* environment variables
+func readAuthTokenEnv(name string) (string, error) {
+	if !strings.HasPrefix(name, authTokenEnvPrefix) {
+		return "", fmt.Errorf("authTokenEnv %q must start with %s", name, authTokenEnvPrefix)
+	}
+	token := strings.TrimSpace(os.Getenv(name))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return token, nil
+}
+
+// setTokenInvalid records that Gitea rejected the group's auth token during operation, emitting
+// a warning event when the token was considered valid before
+func (r *RunnerGroupReconciler) setTokenInvalid(runnerGroup *giteav1alpha1.RunnerGroup, operation string, err error) {
+	reason := "Unauthorized"
+	if errors.Is(err, gitea.ErrForbidden) {
+		reason = "Forbidden"
+	}
+	message := fmt.Sprintf("Gitea rejected the auth token while %s: %v", operation, err)
+	if !meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionTokenValid) && r.Recorder != nil {
+		r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "TokenRejected", message)
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionTokenValid,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
+
+// setTokenValid records that Gitea accepted the group's auth token
+func setTokenValid(runnerGroup *giteav1alpha1.RunnerGroup) {
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionTokenValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Accepted",
+		Message:            "Gitea accepted the auth token",
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
+
+// tokenPollInterval slows polling down to tokenRetryInterval while the group's auth token is rejected
+func tokenPollInterval(runnerGroup *giteav1alpha1.RunnerGroup, interval time.Duration) time.Duration {
+	if meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionTokenValid) {
+		return max(interval, tokenRetryInterval)
+	}
+	return interval
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Auth token validity", func() {
+	var (
+		recorder    *record.FakeRecorder
+		reconciler  *RunnerGroupReconciler
+		runnerGroup *giteav1alpha1.RunnerGroup
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &RunnerGroupReconciler{Recorder: recorder}
+		runnerGroup = &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default", Generation: 1}}
+	})
+
+	It("should record the operation Gitea rejected the token in", func() {
+		reconciler.setTokenInvalid(runnerGroup, "polling queued jobs", fmt.Errorf("fetch jobs: %w", gitea.ErrForbidden))
+		reconciler.setTokenInvalid(runnerGroup, "polling queued jobs", fmt.Errorf("fetch jobs: %w", gitea.ErrForbidden))
+
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionTokenValid)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("Forbidden"))
+		Expect(condition.Message).To(ContainSubstring("while polling queued jobs"))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("TokenRejected"))
+	})
+
+	It("should poll slowly while the token is rejected", func() {
+		Expect(tokenPollInterval(runnerGroup, 10*time.Second)).To(Equal(10 * time.Second))
+
+		reconciler.setTokenInvalid(runnerGroup, "verifying the scope", gitea.ErrUnauthorized)
+		Expect(tokenPollInterval(runnerGroup, 10*time.Second)).To(Equal(tokenRetryInterval))
+		Expect(tokenPollInterval(runnerGroup, time.Hour)).To(Equal(time.Hour))
+
+		setTokenValid(runnerGroup)
+		Expect(tokenPollInterval(runnerGroup, 10*time.Second)).To(Equal(10 * time.Second))
+	})
+})
+
+var _ = Describe("readAuthToken", func() {
+	ctx := context.Background()
+
+	var runnerGroup *giteav1alpha1.RunnerGroup
+
+	BeforeEach(func() {
+		for _, namespace := range []string{"default", "kube-system"} {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gitea-admin-token", Namespace: namespace},
+				StringData: map[string]string{"token": "token-from-" + namespace},
+			}
+			if err := k8sClient.Create(ctx, secret); err != nil && !errors.IsAlreadyExists(err) {
+				Expect(err).To(Succeed())
+			}
+		}
+		runnerGroup = &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				AuthTokenRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "gitea-admin-token"},
+					Key:                  "token",
+				},
+			},
+		}
+	})
+
+	It("should read the group's own Secret", func() {
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})).To(Equal("token-from-default"))
+	})
+
+	It("should only read a shared token from another namespace if allowed and shared with the group's namespace", func() {
+		runnerGroup.Spec.SharedAuthTokenRef = &giteav1alpha1.SecretKeyReference{
+			Namespace: "kube-system", Name: "gitea-admin-token", Key: "token",
+		}
+		_, err := readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})
+		Expect(err).To(MatchError(ContainSubstring("cross-namespace")))
+		_, err = readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{AllowCrossNamespaceSecrets: true})
+		Expect(err).To(MatchError(ContainSubstring("not shared with namespace default")))
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "kube-system", Name: "gitea-admin-token"}, secret)).To(Succeed())
+		secret.Annotations = map[string]string{sharedWithNamespacesAnnotation: "team-a"}
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		_, err = readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{AllowCrossNamespaceSecrets: true})
+		Expect(err).To(MatchError(ContainSubstring("not shared with namespace default")))
+
+		secret.Annotations[sharedWithNamespacesAnnotation] = "team-a, default"
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{AllowCrossNamespaceSecrets: true})).To(Equal("token-from-kube-system"))
+		DeferCleanup(func() {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			secret.Annotations = nil
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		})
+
+		runnerGroup.Spec.SharedAuthTokenRef.Namespace = "default"
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})).To(Equal("token-from-default"))
+	})
+	It("should read a shared token from another namespace through the SecretReader", func() {
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "kube-system", Name: "gitea-admin-token"}, secret)).To(Succeed())
+		secret.Annotations = map[string]string{sharedWithNamespacesAnnotation: "*"}
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			secret.Annotations = nil
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		})
+		runnerGroup.Spec.SharedAuthTokenRef = &giteav1alpha1.SecretKeyReference{
+			Namespace: "kube-system", Name: "gitea-admin-token", Key: "token",
+		}
+
+		reader := &countingReader{Reader: k8sClient}
+		sources := AuthTokenSources{AllowCrossNamespaceSecrets: true, SecretReader: reader}
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, sources)).To(Equal("token-from-kube-system"))
+		Expect(reader.gets).To(Equal(1))
+	})
+
+	It("should read a token file from the auth token directory only", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "gitea-token"), []byte("token-from-file\n"), 0o600)).To(Succeed())
+		runnerGroup.Spec.AuthTokenFile = "gitea-token"
+
+		_, err := readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})
+		Expect(err).To(MatchError(ContainSubstring("no auth token directory")))
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{Dir: dir})).To(Equal("token-from-file"))
+
+		runnerGroup.Spec.AuthTokenFile = "../etc/passwd"
+		_, err = readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{Dir: dir})
+		Expect(err).To(MatchError(ContainSubstring("relative path")))
+	})
+
+	It("should build basic auth credentials from a username and password Secret", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitea-ci-bot", Namespace: "default"},
+			Type:       corev1.SecretTypeBasicAuth,
+			StringData: map[string]string{"username": "ci-bot", "password": "hunter2"},
+		}
+		if err := k8sClient.Create(ctx, secret); err != nil && !errors.IsAlreadyExists(err) {
+			Expect(err).To(Succeed())
+		}
+		runnerGroup.Spec.BasicAuthRef = &corev1.LocalObjectReference{Name: "gitea-ci-bot"}
+
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})).To(Equal(gitea.BasicAuth("ci-bot", "hunter2")))
+	})
+
+	It("should poll with refreshed OAuth2 access tokens and store rotated refresh tokens", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitea-oauth2", Namespace: "default"},
+			StringData: map[string]string{"clientID": "client", "clientSecret": "secret", "refreshToken": "refresh"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, secret)
+		runnerGroup.Spec.OAuth2Ref = &corev1.LocalObjectReference{Name: "gitea-oauth2"}
+
+		_, err := readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})
+		Expect(err).To(HaveOccurred())
+
+		sources := AuthTokenSources{OAuth2: &OAuth2TokenCache{Client: k8sClient, GiteaClient: &fakeGiteaClient{}}}
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, sources)).To(Equal(gitea.BearerAuth("access-token")))
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, sources)).To(Equal(gitea.BearerAuth("access-token")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		Expect(string(secret.Data["refreshToken"])).To(Equal("refresh-rotated"))
+	})
+
+	It("should refresh each instance's OAuth2 token once, however many groups ask for it at the same time", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitea-oauth2-shared", Namespace: "default"},
+			StringData: map[string]string{"clientID": "client", "clientSecret": "secret", "refreshToken": "refresh"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, secret)
+		giteaClient := &refreshCountingGiteaClient{release: make(chan struct{})}
+		cache := &OAuth2TokenCache{Client: k8sClient, GiteaClient: giteaClient}
+		key := client.ObjectKeyFromObject(secret)
+
+		tokens := make(chan string, 3)
+		for range 3 {
+			go func() {
+				defer GinkgoRecover()
+				token, err := cache.accessToken(ctx, "https://gitea.example.com/", key)
+				Expect(err).NotTo(HaveOccurred())
+				tokens <- token
+			}()
+		}
+		Eventually(giteaClient.refreshCount).Should(Equal(1))
+		close(giteaClient.release)
+		for range 3 {
+			Eventually(tokens).Should(Receive(Equal("https://gitea.example.com-access-token")))
+		}
+		Expect(giteaClient.refreshCount()).To(Equal(1))
+
+		// Another instance's token isn't handed out for the same Secret
+		Expect(cache.accessToken(ctx, "https://other.example.com", key)).To(Equal("https://other.example.com-access-token"))
+		Expect(giteaClient.refreshCount()).To(Equal(2))
+	})
+
+	It("should only read prefixed environment variables", func() {
+		GinkgoT().Setenv("GITEA_AUTH_TOKEN_LINUX", "token-from-env")
+		runnerGroup.Spec.AuthTokenEnv = "GITEA_AUTH_TOKEN_LINUX"
+		Expect(readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})).To(Equal("token-from-env"))
+
+		runnerGroup.Spec.AuthTokenEnv = "HOME"
+		_, err := readAuthToken(ctx, k8sClient, runnerGroup, AuthTokenSources{})
+		Expect(err).To(MatchError(ContainSubstring("must start with")))
+	})
+})
+
+// refreshCountingGiteaClient counts OAuth2 token refreshes, which block until release is closed,
+// and issues access tokens naming the Gitea URL
+type refreshCountingGiteaClient struct {
+	fakeGiteaClient
+	release chan struct{}
+
+	mu        sync.Mutex
+	refreshes int
+}
+
+func (c *refreshCountingGiteaClient) RefreshOAuth2Token(ctx context.Context, giteaURL, clientID, clientSecret, refreshToken string) (*gitea.OAuth2Token, error) {
+	c.mu.Lock()
+	c.refreshes++
+	c.mu.Unlock()
+	<-c.release
+	return &gitea.OAuth2Token{AccessToken: giteaURL + "-access-token", RefreshToken: refreshToken, Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func (c *refreshCountingGiteaClient) refreshCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshes
+}
+
+// countingReader counts the objects read through it
+type countingReader struct {
+	client.Reader
+	gets int
+}
+
+func (r *countingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	r.gets++
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"slices"
+	"time"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// scheduleDays maps schedule days to weekdays
+var scheduleDays = map[giteav1alpha1.ScheduleDay]time.Weekday{
+	"Sun": time.Sunday, "Mon": time.Monday, "Tue": time.Tuesday, "Wed": time.Wednesday,
+	"Thu": time.Thursday, "Fri": time.Friday, "Sat": time.Saturday,
+}
+
+// activeAutoscalingSchedule returns the first schedule whose window covers now, if any.
+// Schedules with an unknown time zone or malformed times never apply.
+func activeAutoscalingSchedule(autoscaling *giteav1alpha1.AutoscalingSpec, now time.Time) *giteav1alpha1.AutoscalingSchedule {
+	if autoscaling == nil {
+		return nil
+	}
+	for i := range autoscaling.Schedules {
+		if scheduleCovers(&autoscaling.Schedules[i], now) {
+			return &autoscaling.Schedules[i]
+		}
+	}
+	return nil
+}
+
+// scheduleCovers reports whether the schedule's window is open at now. A window running past
+// midnight belongs to the day it opens on.
+func scheduleCovers(schedule *giteav1alpha1.AutoscalingSchedule, now time.Time) bool {
+	location := time.UTC
+	if schedule.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return false
+		}
+	}
+	start, err := time.Parse("15:04", schedule.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", schedule.End)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	day := local.Weekday()
+	switch {
+	case startMinute <= endMinute:
+		if minute < startMinute || minute >= endMinute {
+			return false
+		}
+	case minute >= startMinute:
+		// Before midnight of a window running past it
+	case minute < endMinute:
+		// After midnight; the window opened the day before
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+
+	return len(schedule.Days) == 0 || slices.ContainsFunc(schedule.Days, func(d giteav1alpha1.ScheduleDay) bool {
+		weekday, ok := scheduleDays[d]
+		return ok && weekday == day
+	})
+}
+
+// scaleUpBudget returns how many runners the autoscaling policy allows spawning for the
+// queued jobs still waiting for one, and, while the scale-up cooldown holds spawning back,
+// how long until it ends
+func scaleUpBudget(autoscaling *giteav1alpha1.AutoscalingSpec, waitingJobs int, lastScaleUp *time.Time, now time.Time) (int, time.Duration) {
+	if autoscaling == nil {
+		return waitingJobs, 0
+	}
+	if autoscaling.ScaleUpCooldown != nil && lastScaleUp != nil {
+		if remaining := autoscaling.ScaleUpCooldown.Duration - now.Sub(*lastScaleUp); remaining > 0 {
+			return 0, remaining
+		}
+	}
+
+	jobsPerRunner := max(autoscaling.JobsPerRunner, 1)
+	budget := (waitingJobs + jobsPerRunner - 1) / jobsPerRunner
+	if autoscaling.MaxScaleUpStep > 0 {
+		budget = min(budget, autoscaling.MaxScaleUpStep)
+	}
+	return budget, 0
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Autoscaling policy", func() {
+	// 2026-01-05 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 1, 5+day, hour, minute, 0, 0, time.UTC)
+	}
+
+	It("should apply schedules on their days and hours only", func() {
+		autoscaling := &giteav1alpha1.AutoscalingSpec{Schedules: []giteav1alpha1.AutoscalingSchedule{
+			{Name: "business-hours", Days: []giteav1alpha1.ScheduleDay{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "08:00", End: "18:00", MaxActiveRunners: 20},
+			{Name: "nights", Start: "22:00", End: "06:00", MaxActiveRunners: 2},
+		}}
+
+		Expect(activeAutoscalingSchedule(autoscaling, at(0, 8, 0)).Name).To(Equal("business-hours"))
+		Expect(activeAutoscalingSchedule(autoscaling, at(0, 17, 59)).Name).To(Equal("business-hours"))
+		Expect(activeAutoscalingSchedule(autoscaling, at(0, 18, 0))).To(BeNil())
+		Expect(activeAutoscalingSchedule(autoscaling, at(5, 12, 0))).To(BeNil())
+		Expect(activeAutoscalingSchedule(autoscaling, at(5, 23, 0)).Name).To(Equal("nights"))
+		Expect(activeAutoscalingSchedule(autoscaling, at(6, 5, 59)).Name).To(Equal("nights"))
+		Expect(activeAutoscalingSchedule(nil, at(0, 12, 0))).To(BeNil())
+	})
+
+	It("should attribute the hours after midnight to the day the window opened", func() {
+		schedule := &giteav1alpha1.AutoscalingSchedule{Days: []giteav1alpha1.ScheduleDay{"Fri"}, Start: "20:00", End: "04:00"}
+		Expect(scheduleCovers(schedule, at(4, 21, 0))).To(BeTrue())
+		Expect(scheduleCovers(schedule, at(5, 3, 0))).To(BeTrue())
+		Expect(scheduleCovers(schedule, at(4, 3, 0))).To(BeFalse())
+	})
+
+	It("should evaluate schedules in their time zone", func() {
+		schedule := &giteav1alpha1.AutoscalingSchedule{Start: "09:00", End: "10:00", TimeZone: "Asia/Tokyo"}
+		Expect(scheduleCovers(schedule, at(0, 0, 30))).To(BeTrue())
+		Expect(scheduleCovers(schedule, at(0, 9, 30))).To(BeFalse())
+
+		schedule.TimeZone = "Not/AZone"
+		Expect(scheduleCovers(schedule, at(0, 0, 30))).To(BeFalse())
+	})
+
+	It("should limit scale-ups by jobs per runner, step and cooldown", func() {
+		now := at(0, 12, 0)
+		Expect(scaleUpBudget(nil, 7, nil, now)).To(Equal(7))
+
+		autoscaling := &giteav1alpha1.AutoscalingSpec{JobsPerRunner: 3}
+		Expect(scaleUpBudget(autoscaling, 7, nil, now)).To(Equal(3))
+
+		autoscaling.MaxScaleUpStep = 2
+		Expect(scaleUpBudget(autoscaling, 7, nil, now)).To(Equal(2))
+
+		autoscaling.ScaleUpCooldown = &metav1.Duration{Duration: time.Minute}
+		recent := now.Add(-20 * time.Second)
+		budget, remaining := scaleUpBudget(autoscaling, 7, &recent, now)
+		Expect(budget).To(BeZero())
+		Expect(remaining).To(Equal(40 * time.Second))
+
+		earlier := now.Add(-2 * time.Minute)
+		Expect(scaleUpBudget(autoscaling, 7, &earlier, now)).To(Equal(2))
+	})
+})
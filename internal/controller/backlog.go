@@ -0,0 +1,87 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// defaultMaxBacklogDuration is how long the queue may outnumber the capacity before the
+// BacklogExceeded condition is set
+const defaultMaxBacklogDuration = 30 * time.Minute
+
+// maxBacklogDuration returns the group's backlog threshold with the default filled in
+func maxBacklogDuration(runnerGroup *giteav1alpha1.RunnerGroup) time.Duration {
+	if alerting := runnerGroup.Spec.Alerting; alerting != nil && alerting.MaxBacklogDuration.Duration > 0 {
+		return alerting.MaxBacklogDuration.Duration
+	}
+	return defaultMaxBacklogDuration
+}
+
+// updateBacklog tracks since when the matching queued jobs outnumber maxActiveRunners and sets
+// the BacklogExceeded condition once that lasted longer than the group's threshold. It returns
+// whether the backlog is exceeded.
+func (r *RunnerGroupReconciler) updateBacklog(runnerGroup *giteav1alpha1.RunnerGroup, queuedJobs, maxActiveRunners int, now time.Time) bool {
+	if queuedJobs <= maxActiveRunners {
+		runnerGroup.Status.BacklogSince = nil
+		if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionBacklogExceeded) {
+			meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+				Type:               giteav1alpha1.ConditionBacklogExceeded,
+				Status:             metav1.ConditionFalse,
+				Reason:             "WithinCapacity",
+				Message:            fmt.Sprintf("%d queued jobs fit into maxActiveRunners %d", queuedJobs, maxActiveRunners),
+				ObservedGeneration: runnerGroup.Generation,
+			})
+		}
+		return false
+	}
+
+	if runnerGroup.Status.BacklogSince == nil {
+		since := metav1.NewTime(now)
+		runnerGroup.Status.BacklogSince = &since
+	}
+	threshold := maxBacklogDuration(runnerGroup)
+	if now.Sub(runnerGroup.Status.BacklogSince.Time) < threshold {
+		return false
+	}
+
+	message := fmt.Sprintf("%d queued jobs have outnumbered maxActiveRunners %d for more than %s", queuedJobs, maxActiveRunners, threshold)
+	if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionBacklogExceeded) && r.Recorder != nil {
+		r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "BacklogExceeded", message)
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionBacklogExceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             "QueueExceedsCapacity",
+		Message:            message,
+		ObservedGeneration: runnerGroup.Generation,
+	})
+	return true
+}
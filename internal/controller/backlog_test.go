@@ -0,0 +1,88 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Backlog tracking", func() {
+	var (
+		reconciler  *RunnerGroupReconciler
+		recorder    *record.FakeRecorder
+		runnerGroup *giteav1alpha1.RunnerGroup
+		now         time.Time
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &RunnerGroupReconciler{Recorder: recorder}
+		runnerGroup = &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"}}
+		now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("should not flag a backlog within the threshold", func() {
+		Expect(reconciler.updateBacklog(runnerGroup, 5, 2, now)).To(BeFalse())
+		Expect(runnerGroup.Status.BacklogSince.Time).To(Equal(now))
+		Expect(reconciler.updateBacklog(runnerGroup, 5, 2, now.Add(29*time.Minute))).To(BeFalse())
+		Expect(runnerGroup.Status.BacklogSince.Time).To(Equal(now))
+		Expect(meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionBacklogExceeded)).To(BeNil())
+	})
+
+	It("should flag a backlog lasting longer than the threshold once", func() {
+		reconciler.updateBacklog(runnerGroup, 5, 2, now)
+		Expect(reconciler.updateBacklog(runnerGroup, 5, 2, now.Add(31*time.Minute))).To(BeTrue())
+		Expect(reconciler.updateBacklog(runnerGroup, 6, 2, now.Add(32*time.Minute))).To(BeTrue())
+
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionBacklogExceeded)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("QueueExceedsCapacity"))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("BacklogExceeded"))
+	})
+
+	It("should honour the group's threshold", func() {
+		runnerGroup.Spec.Alerting = &giteav1alpha1.AlertingSpec{MaxBacklogDuration: metav1.Duration{Duration: 5 * time.Minute}}
+		reconciler.updateBacklog(runnerGroup, 3, 2, now)
+		Expect(reconciler.updateBacklog(runnerGroup, 3, 2, now.Add(6*time.Minute))).To(BeTrue())
+	})
+
+	It("should clear the backlog once the queue fits", func() {
+		reconciler.updateBacklog(runnerGroup, 5, 2, now)
+		reconciler.updateBacklog(runnerGroup, 5, 2, now.Add(time.Hour))
+		Expect(reconciler.updateBacklog(runnerGroup, 2, 2, now.Add(2*time.Hour))).To(BeFalse())
+
+		Expect(runnerGroup.Status.BacklogSince).To(BeNil())
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionBacklogExceeded)).To(BeTrue())
+	})
+})
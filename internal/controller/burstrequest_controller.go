@@ -0,0 +1,109 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// BurstRequestReconciler reconciles a BurstRequest object
+type BurstRequestReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=burstrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=burstrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=burstrequests/finalizers,verbs=update
+
+// Reconcile keeps the phase and expiry of a BurstRequest up to date and requeues
+// it for the moment it expires.
+func (r *BurstRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	burstRequest := &giteav1alpha1.BurstRequest{}
+	if err := r.Get(ctx, req.NamespacedName, burstRequest); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get BurstRequest")
+		return ctrl.Result{}, err
+	}
+
+	expiresAt := burstRequest.ExpiryTime()
+	remaining := time.Until(expiresAt.Time)
+
+	phase := giteav1alpha1.BurstRequestPhaseActive
+	if remaining <= 0 {
+		phase = giteav1alpha1.BurstRequestPhaseExpired
+	}
+
+	if burstRequest.Status.Phase != phase || burstRequest.Status.ExpiresAt == nil || !burstRequest.Status.ExpiresAt.Equal(&expiresAt) {
+		if phase != burstRequest.Status.Phase {
+			logger.Info("BurstRequest phase changed", "phase", phase,
+				"runnerGroup", burstRequest.Spec.RunnerGroupName, "additionalRunners", burstRequest.Spec.AdditionalRunners)
+		}
+		burstRequest.Status.Phase = phase
+		burstRequest.Status.ExpiresAt = &expiresAt
+		if err := r.Status().Update(ctx, burstRequest); err != nil {
+			logger.Error(err, "Failed to update BurstRequest status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if phase == giteav1alpha1.BurstRequestPhaseActive {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// getBurstRunners returns the extra capacity granted to a RunnerGroup by its active BurstRequests
+func getBurstRunners(burstRequests []giteav1alpha1.BurstRequest, runnerGroupName string, now time.Time) int {
+	burstRunners := 0
+	for _, burstRequest := range burstRequests {
+		if burstRequest.Spec.RunnerGroupName != runnerGroupName || !burstRequest.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if expiresAt := burstRequest.ExpiryTime(); now.Before(expiresAt.Time) {
+			burstRunners += burstRequest.Spec.AdditionalRunners
+		}
+	}
+	return burstRunners
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BurstRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&giteav1alpha1.BurstRequest{}).
+		Named("burstrequest").
+		Complete(r)
+}
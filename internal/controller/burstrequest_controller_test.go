@@ -0,0 +1,134 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("BurstRequest Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-burst"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		AfterEach(func() {
+			resource := &giteav1alpha1.BurstRequest{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance BurstRequest")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should mark an unexpired request as active", func() {
+			resource := &giteav1alpha1.BurstRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: giteav1alpha1.BurstRequestSpec{
+					RunnerGroupName:   "test-resource",
+					AdditionalRunners: 5,
+					Duration:          &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			controllerReconciler := &BurstRequestReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", time.Hour))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(giteav1alpha1.BurstRequestPhaseActive))
+			Expect(resource.Status.ExpiresAt).NotTo(BeNil())
+		})
+
+		It("should mark a request past its deadline as expired", func() {
+			until := metav1.NewTime(time.Now().Add(-time.Minute))
+			resource := &giteav1alpha1.BurstRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: giteav1alpha1.BurstRequestSpec{
+					RunnerGroupName:   "test-resource",
+					AdditionalRunners: 5,
+					Until:             &until,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			controllerReconciler := &BurstRequestReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(giteav1alpha1.BurstRequestPhaseExpired))
+		})
+	})
+
+	Context("getBurstRunners", func() {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		later := metav1.NewTime(now.Add(time.Hour))
+		earlier := metav1.NewTime(now.Add(-time.Hour))
+
+		It("should only count active requests for the given group", func() {
+			burstRequests := []giteav1alpha1.BurstRequest{
+				{Spec: giteav1alpha1.BurstRequestSpec{RunnerGroupName: "a", AdditionalRunners: 3, Until: &later}},
+				{Spec: giteav1alpha1.BurstRequestSpec{RunnerGroupName: "a", AdditionalRunners: 2, Until: &later}},
+				{Spec: giteav1alpha1.BurstRequestSpec{RunnerGroupName: "a", AdditionalRunners: 7, Until: &earlier}},
+				{Spec: giteav1alpha1.BurstRequestSpec{RunnerGroupName: "b", AdditionalRunners: 4, Until: &later}},
+			}
+			Expect(getBurstRunners(burstRequests, "a", now)).To(Equal(5))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// circuitOpenRequeue returns whether a request was short-circuited because its Gitea host keeps
+// failing, and when to poll again: once the circuit lets a request through, but no sooner than
+// the poll interval
+func circuitOpenRequeue(err error, interval time.Duration) (time.Duration, bool) {
+	if !errors.Is(err, gitea.ErrCircuitOpen) {
+		return 0, false
+	}
+	return max(gitea.RetryAfter(err), interval), true
+}
+
+// updateDegraded sets the Degraded condition while polls are short-circuited by an open circuit,
+// emitting a warning event when it opens, and clears it once a poll gets through
+func (r *RunnerGroupReconciler) updateDegraded(runnerGroup *giteav1alpha1.RunnerGroup, err error) {
+	current := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)
+	circuitOpen := current != nil && current.Status == metav1.ConditionTrue && current.Reason == "CircuitOpen"
+	if errors.Is(err, gitea.ErrCircuitOpen) {
+		message := fmt.Sprintf("Not contacting Gitea at %s: %v", runnerGroup.Spec.GiteaURL, err)
+		if !circuitOpen && r.Recorder != nil {
+			r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "GiteaCircuitOpen", message)
+		}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             "CircuitOpen",
+			Message:            message,
+			ObservedGeneration: runnerGroup.Generation,
+		})
+		return
+	}
+	// Degraded for another reason is left to what set it
+	if err == nil && circuitOpen {
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionDegraded,
+			Status:             metav1.ConditionFalse,
+			Reason:             "CircuitClosed",
+			Message:            "Gitea answers requests again",
+			ObservedGeneration: runnerGroup.Generation,
+		})
+	}
+}
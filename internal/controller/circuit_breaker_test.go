@@ -0,0 +1,86 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Gitea circuit breaker", func() {
+	var (
+		reconciler  *RunnerGroupReconciler
+		recorder    *record.FakeRecorder
+		runnerGroup *giteav1alpha1.RunnerGroup
+		circuitErr  error
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &RunnerGroupReconciler{Recorder: recorder}
+		runnerGroup = &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"},
+			Spec:       giteav1alpha1.RunnerGroupSpec{GiteaURL: "https://gitea.example.com"},
+		}
+		circuitErr = fmt.Errorf("failed to fetch jobs: %w", &gitea.CircuitOpenError{Host: "gitea.example.com", RetryAfter: 25 * time.Second})
+	})
+
+	It("should poll again once the circuit lets requests through", func() {
+		delay, open := circuitOpenRequeue(circuitErr, 10*time.Second)
+		Expect(open).To(BeTrue())
+		Expect(delay).To(Equal(25 * time.Second))
+
+		delay, _ = circuitOpenRequeue(circuitErr, time.Minute)
+		Expect(delay).To(Equal(time.Minute))
+
+		_, open = circuitOpenRequeue(errors.New("timeout"), time.Minute)
+		Expect(open).To(BeFalse())
+	})
+
+	It("should report the group degraded while the circuit is open", func() {
+		reconciler.updateDegraded(runnerGroup, circuitErr)
+		reconciler.updateDegraded(runnerGroup, circuitErr)
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)).To(BeTrue())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("GiteaCircuitOpen"))
+
+		By("keeping the condition through other errors")
+		reconciler.updateDegraded(runnerGroup, errors.New("timeout"))
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)).To(BeTrue())
+
+		By("clearing it once a poll gets through")
+		reconciler.updateDegraded(runnerGroup, nil)
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)).To(BeTrue())
+	})
+})
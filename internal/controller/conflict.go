@@ -0,0 +1,80 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// runnerGroupTarget returns the org, user or repo the RunnerGroup polls, empty for global scope
+func runnerGroupTarget(runnerGroup *giteav1alpha1.RunnerGroup) string {
+	switch runnerGroup.Spec.Scope {
+	case giteav1alpha1.RunnerGroupScopeOrg:
+		return runnerGroup.Spec.Org
+	case giteav1alpha1.RunnerGroupScopeUser:
+		return runnerGroup.Spec.User
+	case giteav1alpha1.RunnerGroupScopeRepo:
+		return runnerGroup.Spec.Repo
+	}
+	return ""
+}
+
+// olderRunnerGroup reports whether a was created before b, breaking ties by namespace and name
+func olderRunnerGroup(a, b *giteav1alpha1.RunnerGroup) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// conflictingRunnerGroup returns the oldest of the other RunnerGroups that is older than the
+// RunnerGroup and polls the same Gitea instance and scope with the same effective labels, or nil.
+// Such groups see the same queued jobs and would each spawn runners for them.
+func (r *RunnerGroupReconciler) conflictingRunnerGroup(runnerGroup *giteav1alpha1.RunnerGroup, others []giteav1alpha1.RunnerGroup) *giteav1alpha1.RunnerGroup {
+	labels := slices.Sorted(slices.Values(getEffectiveLabels(runnerGroup.Spec.Labels)))
+
+	var conflict *giteav1alpha1.RunnerGroup
+	for i := range others {
+		other := &others[i]
+		if other.UID == runnerGroup.UID || !other.DeletionTimestamp.IsZero() || !olderRunnerGroup(other, runnerGroup) {
+			continue
+		}
+		if !sameGiteaURL(other.Spec.GiteaURL, runnerGroup.Spec.GiteaURL) ||
+			other.Spec.Scope != runnerGroup.Spec.Scope ||
+			runnerGroupTarget(other) != runnerGroupTarget(runnerGroup) {
+			continue
+		}
+		if !slices.Equal(slices.Sorted(slices.Values(getEffectiveLabels(other.Spec.Labels))), labels) {
+			continue
+		}
+		if conflict == nil || olderRunnerGroup(other, conflict) {
+			conflict = other
+		}
+	}
+	return conflict
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Conflicting RunnerGroups", func() {
+	created := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	reconciler := &RunnerGroupReconciler{}
+
+	orgGroup := func(name string, age time.Duration, labels ...string) giteav1alpha1.RunnerGroup {
+		return giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:    giteav1alpha1.RunnerGroupScopeOrg,
+				Org:      "myorg",
+				GiteaURL: "https://gitea.example.com",
+				Labels:   labels,
+			},
+		}
+	}
+
+	It("should flag the newer of two identical groups only", func() {
+		older := orgGroup("older", time.Hour, "linux", "docker")
+		newer := orgGroup("newer", 0, "docker", "linux")
+		newer.Spec.GiteaURL = "https://gitea.example.com/"
+		groups := []giteav1alpha1.RunnerGroup{older, newer}
+
+		conflict := reconciler.conflictingRunnerGroup(&newer, groups)
+		Expect(conflict).NotTo(BeNil())
+		Expect(conflict.Name).To(Equal("older"))
+		Expect(reconciler.conflictingRunnerGroup(&older, groups)).To(BeNil())
+	})
+
+	It("should not flag groups that differ in scope, target or labels", func() {
+		runnerGroup := orgGroup("group", 0, "linux")
+		otherOrg := orgGroup("other-org", time.Hour, "linux")
+		otherOrg.Spec.Org = "otherorg"
+		otherLabels := orgGroup("other-labels", time.Hour, "linux", "gpu")
+		repoScope := orgGroup("repo-scope", time.Hour, "linux")
+		repoScope.Spec.Scope = giteav1alpha1.RunnerGroupScopeRepo
+		repoScope.Spec.Repo = "myorg"
+
+		Expect(reconciler.conflictingRunnerGroup(&runnerGroup, []giteav1alpha1.RunnerGroup{runnerGroup, otherOrg, otherLabels, repoScope})).To(BeNil())
+	})
+})
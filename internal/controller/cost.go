@@ -0,0 +1,159 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// applyCostCenterLabels stamps the group's cost-center labels on the runner Job and its pods,
+// keeping the labels the operator set
+func applyCostCenterLabels(job *batchv1.Job, costCenterLabels map[string]string) {
+	for _, labels := range []map[string]string{job.Labels, job.Spec.Template.Labels} {
+		for key, value := range costCenterLabels {
+			if _, set := labels[key]; !set {
+				labels[key] = value
+			}
+		}
+	}
+}
+
+// runnerRunTime is how long the runner Job ran: until it completed or failed, or until now if
+// it hasn't finished
+func runnerRunTime(job *batchv1.Job, now time.Time) time.Duration {
+	if job.Status.StartTime == nil {
+		return 0
+	}
+	end := now
+	if job.Status.CompletionTime != nil {
+		end = job.Status.CompletionTime.Time
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && !condition.LastTransitionTime.IsZero() {
+			end = condition.LastTransitionTime.Time
+		}
+	}
+	return max(end.Sub(job.Status.StartTime.Time), 0)
+}
+
+// deletedRunnerRunTime is how long a runner Job the operator deleted ran until it was deleted.
+// Finished runners already counted by recordFinishedRunners count nothing.
+func deletedRunnerRunTime(job *batchv1.Job, now time.Time) time.Duration {
+	if job == nil || job.Annotations[utilizationRecordedAnnotation] == "true" {
+		return 0
+	}
+	return runnerRunTime(job, now)
+}
+
+// deletedRunTime is how long the runner Jobs the operator deleted ran until they were deleted
+func deletedRunTime(jobs []batchv1.Job, deleted map[string]bool, now time.Time) time.Duration {
+	var total time.Duration
+	for i := range jobs {
+		if deleted[jobs[i].Name] {
+			total += deletedRunnerRunTime(&jobs[i], now)
+		}
+	}
+	return total
+}
+
+// recordRunnerTime adds the run time of finished runners to the group's usage. The runner
+// seconds metric follows once the status is written, see recordRunnerSeconds.
+func recordRunnerTime(runnerGroup *giteav1alpha1.RunnerGroup, runTime time.Duration, now time.Time) {
+	if runTime <= 0 {
+		return
+	}
+	if runnerGroup.Status.Usage == nil {
+		runnerGroup.Status.Usage = &giteav1alpha1.RunnerUsage{Since: metav1.NewTime(now)}
+	}
+	usage := runnerGroup.Status.Usage
+	usage.RunnerSeconds += int64(runTime.Seconds())
+	usage.RunnerMinutes = usage.RunnerSeconds / 60
+}
+
+// recordRunnerSeconds adds the runner seconds a status write added to the group's usage to the
+// runner seconds metric
+func recordRunnerSeconds(namespacedName types.NamespacedName, before, after *giteav1alpha1.RunnerGroupStatus) {
+	if after.Usage == nil {
+		return
+	}
+	added := after.Usage.RunnerSeconds
+	if before.Usage != nil {
+		added -= before.Usage.RunnerSeconds
+	}
+	if added > 0 {
+		runnerSeconds.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Add(float64(added))
+	}
+}
+
+// runTimeLedger holds the run time of the runner Jobs the operator deleted, per RunnerGroup,
+// until a status write adds it to the group's usage. Jobs are deleted outside the group's
+// reconcile too, and a failed status write would otherwise lose their run time.
+type runTimeLedger struct {
+	mu       sync.Mutex
+	runTimes map[types.NamespacedName]time.Duration
+}
+
+// add holds the run time of deleted runner Jobs of a RunnerGroup
+func (l *runTimeLedger) add(key types.NamespacedName, runTime time.Duration) {
+	if runTime <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.runTimes == nil {
+		l.runTimes = make(map[types.NamespacedName]time.Duration)
+	}
+	l.runTimes[key] += runTime
+}
+
+// pending returns the run time held for a RunnerGroup
+func (l *runTimeLedger) pending(key types.NamespacedName) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.runTimes[key]
+}
+
+// settle drops run time that was written to the RunnerGroup's status
+func (l *runTimeLedger) settle(key types.NamespacedName, runTime time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if remaining := l.runTimes[key] - runTime; remaining > 0 {
+		l.runTimes[key] = remaining
+	} else {
+		delete(l.runTimes, key)
+	}
+}
+
+// forget drops the run time held for a deleted RunnerGroup
+func (l *runTimeLedger) forget(key types.NamespacedName) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.runTimes, key)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Cost attribution", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	startedAt := func(name string, started time.Time) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: started}},
+		}
+	}
+
+	It("should stamp cost-center labels on runner Jobs and pods without overriding the operator's", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				CostCenterLabels: map[string]string{"cost-center": "cc-42", runnerGroupNameLabel: "other"},
+			},
+		}
+		runner := &giteav1alpha1.Runner{ObjectMeta: metav1.ObjectMeta{Name: "team-a-abc12", Namespace: "default"}}
+
+		job := constructRunnerJob(runnerGroup, runner, "token")
+		Expect(job.Labels).To(HaveKeyWithValue("cost-center", "cc-42"))
+		Expect(job.Spec.Template.Labels).To(HaveKeyWithValue("cost-center", "cc-42"))
+		Expect(job.Spec.Template.Labels).To(HaveKeyWithValue(runnerGroupNameLabel, "team-a"))
+	})
+
+	It("should count a runner's run time until it finished, or until now", func() {
+		completed := startedAt("completed", now.Add(-time.Hour))
+		completed.Status.CompletionTime = &metav1.Time{Time: now.Add(-50 * time.Minute)}
+		failed := startedAt("failed", now.Add(-time.Hour))
+		failed.Status.Conditions = []batchv1.JobCondition{{
+			Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+			LastTransitionTime: metav1.Time{Time: now.Add(-30 * time.Minute)},
+		}}
+		running := startedAt("running", now.Add(-5*time.Minute))
+
+		Expect(runnerRunTime(&completed, now)).To(Equal(10 * time.Minute))
+		Expect(runnerRunTime(&failed, now)).To(Equal(30 * time.Minute))
+		Expect(deletedRunTime([]batchv1.Job{completed, running}, map[string]bool{"running": true}, now)).
+			To(Equal(5 * time.Minute))
+	})
+
+	It("should not count the run time of deleted runners already counted as finished", func() {
+		completed := startedAt("completed", now.Add(-time.Hour))
+		completed.Status.CompletionTime = &metav1.Time{Time: now.Add(-50 * time.Minute)}
+		recorded := *completed.DeepCopy()
+		recorded.Name = "recorded"
+		recorded.Annotations = map[string]string{utilizationRecordedAnnotation: "true"}
+
+		Expect(deletedRunTime([]batchv1.Job{completed, recorded}, map[string]bool{"completed": true, "recorded": true}, now)).
+			To(Equal(10 * time.Minute))
+		Expect(deletedRunnerRunTime(nil, now)).To(BeZero())
+	})
+
+	It("should accumulate runner minutes in status", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+
+		recordRunnerTime(runnerGroup, 0, now)
+		Expect(runnerGroup.Status.Usage).To(BeNil())
+
+		recordRunnerTime(runnerGroup, 90*time.Second, now)
+		recordRunnerTime(runnerGroup, 45*time.Second, now.Add(time.Minute))
+		Expect(runnerGroup.Status.Usage.RunnerSeconds).To(Equal(int64(135)))
+		Expect(runnerGroup.Status.Usage.RunnerMinutes).To(Equal(int64(2)))
+		Expect(runnerGroup.Status.Usage.Since.Time).To(Equal(now))
+	})
+
+	It("should count the runner seconds a status write added", func() {
+		key := types.NamespacedName{Namespace: "default", Name: "cost-group"}
+		DeferCleanup(forgetRunnerGroupMetrics, key)
+
+		recordRunnerSeconds(key, &giteav1alpha1.RunnerGroupStatus{}, &giteav1alpha1.RunnerGroupStatus{})
+		recordRunnerSeconds(key, &giteav1alpha1.RunnerGroupStatus{},
+			&giteav1alpha1.RunnerGroupStatus{Usage: &giteav1alpha1.RunnerUsage{RunnerSeconds: 90}})
+		recordRunnerSeconds(key, &giteav1alpha1.RunnerGroupStatus{Usage: &giteav1alpha1.RunnerUsage{RunnerSeconds: 90}},
+			&giteav1alpha1.RunnerGroupStatus{Usage: &giteav1alpha1.RunnerUsage{RunnerSeconds: 135}})
+		Expect(testutil.ToFloat64(runnerSeconds.WithLabelValues(key.Namespace, key.Name))).To(Equal(135.0))
+	})
+
+	It("should hold the run time of deleted runners until it is settled", func() {
+		key := types.NamespacedName{Namespace: "default", Name: "cost-group"}
+		ledger := &runTimeLedger{}
+		ledger.add(key, time.Minute)
+		ledger.add(key, 30*time.Second)
+		Expect(ledger.pending(key)).To(Equal(90 * time.Second))
+
+		// Run time deleted while the status was written stays for the next write
+		ledger.add(key, 10*time.Second)
+		ledger.settle(key, 90*time.Second)
+		Expect(ledger.pending(key)).To(Equal(10 * time.Second))
+
+		ledger.forget(key)
+		Expect(ledger.pending(key)).To(BeZero())
+	})
+})
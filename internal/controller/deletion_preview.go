@@ -0,0 +1,67 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// deletionPreviewAnnotation asks the controller to report in status what deleting the RunnerGroup would affect
+const deletionPreviewAnnotation = "gitea.bpg.pw/deletion-preview"
+
+// buildDeletionPreview lists the active runner Jobs that deleting the RunnerGroup would terminate.
+// The previous preview's timestamp is kept if the affected runners haven't changed, so the
+// status isn't rewritten on every reconcile.
+func buildDeletionPreview(jobs []batchv1.Job, previous *giteav1alpha1.DeletionPreview) *giteav1alpha1.DeletionPreview {
+	var runners []giteav1alpha1.AffectedRunner
+	for _, job := range jobs {
+		if job.Status.CompletionTime != nil {
+			continue
+		}
+		runner := giteav1alpha1.AffectedRunner{
+			Name:      job.Name,
+			Repo:      job.Annotations[repositoryAnnotation],
+			StartTime: job.Status.StartTime,
+		}
+		if id, err := strconv.ParseInt(job.Annotations[giteaJobIDAnnotation], 10, 64); err == nil {
+			runner.GiteaJobID = id
+		}
+		runners = append(runners, runner)
+	}
+	slices.SortFunc(runners, func(a, b giteav1alpha1.AffectedRunner) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if previous != nil && equality.Semantic.DeepEqual(previous.ActiveRunners, runners) {
+		return previous.DeepCopy()
+	}
+	return &giteav1alpha1.DeletionPreview{GeneratedAt: metav1.Now(), ActiveRunners: runners}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("buildDeletionPreview", func() {
+	finished := metav1.Now()
+	jobs := []batchv1.Job{
+		{ObjectMeta: metav1.ObjectMeta{Name: "group-b", Annotations: map[string]string{
+			giteaJobIDAnnotation: "42",
+			repositoryAnnotation: "org/api",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "group-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "group-c"}, Status: batchv1.JobStatus{CompletionTime: &finished}},
+	}
+
+	It("should list active runners with the Gitea jobs they run", func() {
+		preview := buildDeletionPreview(jobs, nil)
+		Expect(preview.ActiveRunners).To(HaveLen(2))
+		Expect(preview.ActiveRunners[0].Name).To(Equal("group-a"))
+		Expect(preview.ActiveRunners[1].Name).To(Equal("group-b"))
+		Expect(preview.ActiveRunners[1].GiteaJobID).To(Equal(int64(42)))
+		Expect(preview.ActiveRunners[1].Repo).To(Equal("org/api"))
+	})
+
+	It("should keep the previous timestamp while nothing changed", func() {
+		previous := buildDeletionPreview(jobs, nil)
+		previous.GeneratedAt = metav1.NewTime(previous.GeneratedAt.Add(-time.Hour))
+		Expect(buildDeletionPreview(jobs, previous).GeneratedAt).To(Equal(previous.GeneratedAt))
+		Expect(buildDeletionPreview(jobs[1:], previous).GeneratedAt).NotTo(Equal(previous.GeneratedAt))
+	})
+})
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// drainRecheckInterval is how often a draining group checks whether its runners finished
+const drainRecheckInterval = 10 * time.Second
+
+// drainableRunners splits the Runners of a draining group into those to remove and the number
+// still running a job. busy holds the runners Gitea reports busy, or is nil when Gitea couldn't
+// be asked; a runner with a ready pod is then assumed to be running a job.
+func drainableRunners(runners []giteav1alpha1.Runner, jobs []batchv1.Job, busy map[string]bool) ([]*giteav1alpha1.Runner, int) {
+	jobsByName := make(map[string]*batchv1.Job, len(jobs))
+	for i := range jobs {
+		jobsByName[jobs[i].Name] = &jobs[i]
+	}
+	var remove []*giteav1alpha1.Runner
+	running := 0
+	for i := range runners {
+		runner := &runners[i]
+		if !runner.DeletionTimestamp.IsZero() {
+			continue
+		}
+		job := jobsByName[runner.Name]
+		if job != nil && job.Status.CompletionTime == nil && !jobFailed(job) {
+			if busy[runner.Name] || (busy == nil && ptr.Deref(job.Status.Ready, 0) > 0) {
+				running++
+				continue
+			}
+		}
+		remove = append(remove, runner)
+	}
+	return remove, running
+}
+
+// drainRunnerGroup removes the runners of a RunnerGroup with spec.drain set that aren't running
+// a job: they are deregistered from Gitea first, so they pick up no job, then their Runner is
+// deleted along with its Job. Runners that can't be deregistered are kept, since they could
+// still pick up a job. It records the progress in status and returns how many runners are left.
+func (r *RunnerGroupReconciler) drainRunnerGroup(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup,
+	runners []giteav1alpha1.Runner, jobs []batchv1.Job, now time.Time) (int, error) {
+	logger := log.FromContext(ctx)
+
+	if runnerGroup.Status.Drain == nil {
+		logger.Info("Draining RunnerGroup")
+		runnerGroup.Status.Drain = &giteav1alpha1.DrainStatus{StartTime: metav1.NewTime(now)}
+		if r.Recorder != nil {
+			r.Recorder.Event(runnerGroup, corev1.EventTypeNormal, "Draining",
+				"Stopped spawning runners, removing runners once they finished their jobs")
+		}
+	}
+
+	// Gitea knows which runners are running a job; a ready pod is only a hint
+	var busy map[string]bool
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		logger.Error(err, "Failed to read auth token, draining without deregistering runners")
+	} else if registered, err := r.GiteaClient.ListRunners(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo); err != nil {
+		logger.Error(err, "Failed to list runners registered in Gitea, waiting for all ready runners")
+	} else {
+		busy = make(map[string]bool)
+		for _, runner := range registered {
+			if runner.Busy {
+				busy[runner.Name] = true
+			}
+		}
+	}
+
+	remove, running := drainableRunners(runners, jobs, busy)
+	// Jobs spawned before the Runner resource have no Runner to remove; wait for them to finish
+	for i := range jobs {
+		if owner := metav1.GetControllerOf(&jobs[i]); owner != nil && owner.UID == runnerGroup.UID &&
+			jobs[i].Status.CompletionTime == nil && !jobFailed(&jobs[i]) {
+			running++
+		}
+	}
+	jobsByName := make(map[string]*batchv1.Job, len(jobs))
+	for i := range jobs {
+		jobsByName[jobs[i].Name] = &jobs[i]
+	}
+	remaining := running
+	for _, runner := range remove {
+		if authToken != "" && mayBeRegistered(runner) {
+			if err := deregisterGiteaRunner(ctx, r.GiteaClient, runnerGroup, authToken, runner); err != nil {
+				logger.Error(err, "Failed to deregister runner from Gitea, keeping it", "runner", runner.Name)
+				if r.Recorder != nil {
+					r.Recorder.Eventf(runnerGroup, corev1.EventTypeWarning, "DeregistrationFailed",
+						"Failed to deregister runner %s from Gitea: %v", runner.Name, err)
+				}
+				remaining++
+				continue
+			}
+		}
+		if err := r.Delete(ctx, runner, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete Runner %s: %w", runner.Name, err)
+		}
+		r.deletedRunTimes.add(client.ObjectKeyFromObject(runnerGroup), deletedRunnerRunTime(jobsByName[runner.Name], now))
+		logger.Info("Removed runner of draining RunnerGroup", "runner", runner.Name)
+		runnerGroup.Status.Drain.RemovedRunners++
+	}
+	runnerGroup.Status.Drain.RunningRunners = running
+
+	condition := metav1.Condition{
+		Type:               giteav1alpha1.ConditionDraining,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Draining",
+		Message:            fmt.Sprintf("Waiting for %d runners to finish their jobs", remaining),
+		ObservedGeneration: runnerGroup.Generation,
+	}
+	if remaining == 0 {
+		if runnerGroup.Status.Drain.CompletionTime == nil {
+			logger.Info("RunnerGroup drained", "removedRunners", runnerGroup.Status.Drain.RemovedRunners)
+			runnerGroup.Status.Drain.CompletionTime = &metav1.Time{Time: now}
+			if r.Recorder != nil {
+				r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "Drained",
+					"All runners removed, %d of them by the drain", runnerGroup.Status.Drain.RemovedRunners)
+			}
+		}
+		condition.Reason = "Drained"
+		condition.Message = "All runners are removed and no new ones are spawned"
+	} else {
+		runnerGroup.Status.Drain.CompletionTime = nil
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, condition)
+	return remaining, nil
+}
+
+// endDrain clears the drain progress of a RunnerGroup whose spec.drain was unset
+func endDrain(runnerGroup *giteav1alpha1.RunnerGroup) {
+	runnerGroup.Status.Drain = nil
+	if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDraining) {
+		return
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionDraining,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotDraining",
+		Message:            "Runners are spawned for queued jobs",
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
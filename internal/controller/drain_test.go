@@ -0,0 +1,85 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Draining RunnerGroups", func() {
+	runner := func(name string) giteav1alpha1.Runner {
+		return giteav1alpha1.Runner{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	job := func(name string, ready int32) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     batchv1.JobStatus{Ready: ptr.To(ready)},
+		}
+	}
+	names := func(runners []*giteav1alpha1.Runner) []string {
+		var result []string
+		for _, runner := range runners {
+			result = append(result, runner.Name)
+		}
+		return result
+	}
+
+	runners := []giteav1alpha1.Runner{runner("busy"), runner("idle"), runner("starting"), runner("awaiting-job"), runner("finished")}
+	finished := job("finished", 0)
+	finished.Status.CompletionTime = &metav1.Time{}
+	jobs := []batchv1.Job{job("busy", 1), job("idle", 1), job("starting", 0), finished}
+
+	It("should keep the runners Gitea reports busy and remove all others", func() {
+		remove, running := drainableRunners(runners, jobs, map[string]bool{"busy": true})
+		Expect(running).To(Equal(1))
+		Expect(names(remove)).To(ConsistOf("idle", "starting", "awaiting-job", "finished"))
+	})
+
+	It("should keep every ready runner when Gitea can't tell which are busy", func() {
+		remove, running := drainableRunners(runners, jobs, nil)
+		Expect(running).To(Equal(2))
+		Expect(names(remove)).To(ConsistOf("starting", "awaiting-job", "finished"))
+	})
+
+	It("should clear the drain progress once the drain is lifted", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+		runnerGroup.Status.Drain = &giteav1alpha1.DrainStatus{RemovedRunners: 3}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:   giteav1alpha1.ConditionDraining,
+			Status: metav1.ConditionTrue,
+			Reason: "Drained",
+		})
+
+		endDrain(runnerGroup)
+		Expect(runnerGroup.Status.Drain).To(BeNil())
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDraining)).To(BeTrue())
+	})
+})
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// emergencyStop returns the active emergency stop, set by operator flag or on the RunnerFleet, or nil
+func (r *RunnerGroupReconciler) emergencyStop(ctx context.Context) (*giteav1alpha1.EmergencyStopSpec, error) {
+	if r.EmergencyStop {
+		return &giteav1alpha1.EmergencyStopSpec{Drain: r.EmergencyStopDrain, Reason: "operator runs with --emergency-stop"}, nil
+	}
+
+	fleet := &giteav1alpha1.RunnerFleet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: giteav1alpha1.RunnerFleetName}, fleet); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return fleet.Spec.EmergencyStop, nil
+}
+
+// drainRunners deletes the unfinished runner Jobs of a RunnerGroup and returns how many it deleted
+func (r *RunnerGroupReconciler) drainRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job) (int, error) {
+	drained := 0
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime != nil || job.DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return drained, fmt.Errorf("failed to delete runner Job %s: %w", job.Name, err)
+		}
+		r.deletedRunTimes.add(client.ObjectKeyFromObject(runnerGroup), deletedRunnerRunTime(job, time.Now()))
+		drained++
+	}
+	return drained, nil
+}
+
+// runnerGroupsForRunnerFleet maps the RunnerFleet to every RunnerGroup, so an emergency stop
+// applies right away
+func (r *RunnerGroupReconciler) runnerGroupsForRunnerFleet(ctx context.Context, obj client.Object) []reconcile.Request {
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(runnerGroupList.Items))
+	for _, runnerGroup := range runnerGroupList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&runnerGroup)})
+	}
+	return requests
+}
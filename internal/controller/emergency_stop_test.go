@@ -0,0 +1,100 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Emergency stop", func() {
+	ctx := context.Background()
+
+	It("should be active when set by flag", func() {
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, EmergencyStop: true, EmergencyStopDrain: true}
+		stop, err := reconciler.emergencyStop(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stop).NotTo(BeNil())
+		Expect(stop.Drain).To(BeTrue())
+	})
+
+	It("should be active when set on the RunnerFleet", func() {
+		reconciler := &RunnerGroupReconciler{Client: k8sClient}
+		stop, err := reconciler.emergencyStop(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stop).To(BeNil())
+
+		fleet := &giteav1alpha1.RunnerFleet{
+			ObjectMeta: metav1.ObjectMeta{Name: giteav1alpha1.RunnerFleetName},
+			Spec:       giteav1alpha1.RunnerFleetSpec{EmergencyStop: &giteav1alpha1.EmergencyStopSpec{Reason: "incident 42"}},
+		}
+		Expect(k8sClient.Create(ctx, fleet)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, fleet)).To(Succeed()) })
+
+		stop, err = reconciler.emergencyStop(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stop).To(Equal(&giteav1alpha1.EmergencyStopSpec{Reason: "incident 42"}))
+	})
+
+	It("should drain unfinished runners only", func() {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "drain-runner", Namespace: "default"},
+			Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyOnFailure,
+				Containers:    []corev1.Container{{Name: "runner", Image: "gitea/act_runner"}},
+			}}},
+		}
+		Expect(k8sClient.Create(ctx, job)).To(Succeed())
+		completed := metav1.Now()
+		finished := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "drain-finished", Namespace: "default"},
+			Status:     batchv1.JobStatus{CompletionTime: &completed},
+		}
+
+		running := *job
+		running.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "drain", Namespace: "default"}}
+		reconciler := &RunnerGroupReconciler{Client: k8sClient}
+		drained, err := reconciler.drainRunners(ctx, runnerGroup, []batchv1.Job{running, finished})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drained).To(Equal(1))
+		Expect(reconciler.deletedRunTimes.pending(client.ObjectKeyFromObject(runnerGroup))).To(BeNumerically(">=", time.Minute))
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+			return errors.IsNotFound(err)
+		}).Should(BeTrue())
+	})
+})
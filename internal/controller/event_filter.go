@@ -0,0 +1,97 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// workflowRuns are the workflow runs looked up during a reconcile, by workflowRunKey. A run that
+// couldn't be looked up is nil.
+type workflowRuns map[string]*gitea.ActionWorkflowRun
+
+// workflowRunKey identifies the workflow run of a job, or is empty if the job doesn't tell
+func workflowRunKey(job gitea.ActionWorkflowJob) string {
+	repo := job.RepoFullName()
+	if repo == "" || job.RunID == 0 {
+		return ""
+	}
+	return repo + "#" + strconv.FormatInt(job.RunID, 10)
+}
+
+// filterJobsByEvent keeps the queued jobs whose workflow run matches the RunnerGroup's event filter.
+// Runs are looked up once per reconcile and recorded in runs, and the Gitea client reuses them
+// across polls. Jobs whose run can't be looked up are left out.
+func (r *RunnerGroupReconciler) filterJobsByEvent(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, authToken string,
+	jobs []gitea.ActionWorkflowJob, runs workflowRuns) []gitea.ActionWorkflowJob {
+	logger := log.FromContext(ctx)
+
+	var matched []gitea.ActionWorkflowJob
+	for _, job := range jobs {
+		key := workflowRunKey(job)
+		if key == "" {
+			logger.V(1).Info("Cannot determine workflow run of job, skipping", "giteaJobID", job.ID)
+			continue
+		}
+		repo := job.RepoFullName()
+
+		run, ok := runs[key]
+		if !ok {
+			var err error
+			run, err = r.GiteaClient.GetWorkflowRun(ctx, runnerGroup.Spec.GiteaURL, authToken, repo, job.RunID)
+			if err != nil {
+				logger.Error(err, "Failed to get workflow run", "repo", repo, "runID", job.RunID)
+			}
+			runs[key] = run
+		}
+
+		if run != nil && matchesEventFilter(runnerGroup.Spec.EventFilter, run) {
+			matched = append(matched, job)
+		}
+	}
+	return matched
+}
+
+// matchesEventFilter reports whether a workflow run was triggered by an accepted event on an accepted branch
+func matchesEventFilter(filter *giteav1alpha1.EventFilterSpec, run *gitea.ActionWorkflowRun) bool {
+	if len(filter.Events) > 0 && !slices.Contains(filter.Events, run.Event) {
+		return false
+	}
+	if len(filter.Branches) == 0 {
+		return true
+	}
+	for _, pattern := range filter.Branches {
+		if ok, _ := path.Match(pattern, run.HeadBranch); ok {
+			return true
+		}
+	}
+	return false
+}
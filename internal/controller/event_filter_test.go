@@ -0,0 +1,79 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// runsGiteaClient serves workflow runs from a map keyed by run ID
+type runsGiteaClient struct {
+	fakeGiteaClient
+	runs map[int64]gitea.ActionWorkflowRun
+}
+
+func (c *runsGiteaClient) GetWorkflowRun(ctx context.Context, giteaURL, authToken, repo string, runID int64) (*gitea.ActionWorkflowRun, error) {
+	run := c.runs[runID]
+	return &run, nil
+}
+
+var _ = Describe("matchesEventFilter", func() {
+	It("should match events and branch globs", func() {
+		filter := &giteav1alpha1.EventFilterSpec{Events: []string{"push"}, Branches: []string{"main", "release/*"}}
+		Expect(matchesEventFilter(filter, &gitea.ActionWorkflowRun{Event: "push", HeadBranch: "main"})).To(BeTrue())
+		Expect(matchesEventFilter(filter, &gitea.ActionWorkflowRun{Event: "push", HeadBranch: "release/1.2"})).To(BeTrue())
+		Expect(matchesEventFilter(filter, &gitea.ActionWorkflowRun{Event: "push", HeadBranch: "feature"})).To(BeFalse())
+		Expect(matchesEventFilter(filter, &gitea.ActionWorkflowRun{Event: "pull_request", HeadBranch: "main"})).To(BeFalse())
+	})
+
+	It("should accept anything for empty lists", func() {
+		Expect(matchesEventFilter(&giteav1alpha1.EventFilterSpec{}, &gitea.ActionWorkflowRun{Event: "schedule"})).To(BeTrue())
+	})
+})
+
+var _ = Describe("filterJobsByEvent", func() {
+	It("should keep jobs of matching runs only", func() {
+		reconciler := &RunnerGroupReconciler{GiteaClient: &runsGiteaClient{runs: map[int64]gitea.ActionWorkflowRun{
+			10: {ID: 10, Event: "push", HeadBranch: "v1.0.0"},
+			11: {ID: 11, Event: "pull_request", HeadBranch: "feature"},
+		}}}
+		runnerGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{
+			EventFilter: &giteav1alpha1.EventFilterSpec{Branches: []string{"v*"}},
+		}}
+
+		jobs := []gitea.ActionWorkflowJob{queuedJob(1, "org/a"), queuedJob(2, "org/a"), queuedJob(3, "org/a")}
+		jobs[0].RunID = 10
+		jobs[1].RunID = 11
+
+		runs := workflowRuns{}
+		filtered := reconciler.filterJobsByEvent(context.Background(), runnerGroup, "token", jobs, runs)
+		Expect(jobIDs(filtered)).To(Equal([]int64{1}))
+		Expect(runs).To(HaveLen(2))
+	})
+})
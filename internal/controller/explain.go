@@ -0,0 +1,127 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// JobMatch explains whether a RunnerGroup serves a queued job
+type JobMatch struct {
+	// RunnerGroup is the namespace and name of the group
+	RunnerGroup types.NamespacedName
+	// Priority is the group's spec.priority
+	Priority int
+	// Matches is true if the group's scope covers the job's repository and its labels match the job
+	Matches bool
+	// Reason tells why the group doesn't serve the job, or, if it does, what currently keeps it
+	// from spawning runners. It is empty for a group that serves the job right away.
+	Reason string
+}
+
+// ExplainJob tells for every RunnerGroup of the Gitea instance whether it serves the queued job.
+// Groups that serve it come first, in the order they get to spawn a runner for it, i.e. by
+// descending priority. A group's eventFilter is not checked, as that needs the job's workflow run.
+func ExplainJob(giteaURL string, runnerGroups []giteav1alpha1.RunnerGroup, job gitea.ActionWorkflowJob) []JobMatch {
+	var matches []JobMatch
+	for i := range runnerGroups {
+		runnerGroup := &runnerGroups[i]
+		if !sameGiteaURL(runnerGroup.Spec.GiteaURL, giteaURL) {
+			continue
+		}
+		match := JobMatch{
+			RunnerGroup: types.NamespacedName{Namespace: runnerGroup.Namespace, Name: runnerGroup.Name},
+			Priority:    runnerGroup.Spec.Priority,
+		}
+		effectiveLabels := getEffectiveLabels(runnerGroup.Spec.Labels)
+		switch {
+		case !runnerGroup.DeletionTimestamp.IsZero():
+			match.Reason = "the RunnerGroup is being deleted"
+		case !scopeCoversRepo(runnerGroup, job.RepoFullName()):
+			match.Reason = fmt.Sprintf("its %s scope doesn't cover repository %s", runnerGroup.Spec.Scope, job.RepoFullName())
+		case !jobMatchesRunnerGroup(runnerGroup, effectiveLabels, job):
+			if runnerGroup.Spec.JobLabelSelector != nil {
+				match.Reason = fmt.Sprintf("its jobLabelSelector doesn't select the job's labels %v", job.Labels)
+			} else {
+				match.Reason = fmt.Sprintf("its labels %v don't satisfy the job's labels %v", effectiveLabels, job.Labels)
+			}
+		default:
+			match.Matches = true
+			match.Reason = blockingCondition(runnerGroup)
+		}
+		matches = append(matches, match)
+	}
+
+	slices.SortStableFunc(matches, func(a, b JobMatch) int {
+		if a.Matches != b.Matches {
+			if a.Matches {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(b.Priority, a.Priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.RunnerGroup.String(), b.RunnerGroup.String())
+	})
+	return matches
+}
+
+// blockingCondition returns the message of the first condition keeping the RunnerGroup from
+// spawning runners, or its runners from being scheduled, or an empty string if there is none
+func blockingCondition(runnerGroup *giteav1alpha1.RunnerGroup) string {
+	conditions := runnerGroup.Status.Conditions
+	for _, conditionType := range []string{
+		giteav1alpha1.ConditionEmergencyStop,
+		giteav1alpha1.ConditionDraining,
+		giteav1alpha1.ConditionPaused,
+		giteav1alpha1.ConditionMaintenance,
+		giteav1alpha1.ConditionConflict,
+		giteav1alpha1.ConditionDegraded,
+		giteav1alpha1.ConditionScaleUpBlocked,
+		giteav1alpha1.ConditionRunnersUnschedulable,
+	} {
+		if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil && condition.Status == metav1.ConditionTrue {
+			return fmt.Sprintf("%s: %s", condition.Type, condition.Message)
+		}
+	}
+	for _, conditionType := range []string{
+		giteav1alpha1.ConditionScopeVerified,
+		giteav1alpha1.ConditionTokenValid,
+		giteav1alpha1.ConditionGiteaReachable,
+	} {
+		if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil && condition.Status == metav1.ConditionFalse {
+			return fmt.Sprintf("%s is False: %s", condition.Type, condition.Message)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Explaining queued jobs", func() {
+	orgGroup := func(name string, priority int, labels ...string) giteav1alpha1.RunnerGroup {
+		return giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:    giteav1alpha1.RunnerGroupScopeOrg,
+				Org:      "myorg",
+				GiteaURL: "https://gitea.example.com",
+				Labels:   labels,
+				Priority: priority,
+			},
+		}
+	}
+	job := gitea.ActionWorkflowJob{ID: 7, Labels: []string{"gpu"}, URL: "https://gitea.example.com/api/v1/repos/myorg/app/actions/jobs/7"}
+
+	It("should list the groups serving the job first, by priority, with why the others don't", func() {
+		linux := orgGroup("linux", 0, "linux")
+		gpu := orgGroup("gpu", 0, "gpu")
+		urgent := orgGroup("gpu-urgent", 10, "gpu")
+		otherOrg := orgGroup("other-org", 20, "gpu")
+		otherOrg.Spec.Org = "otherorg"
+		otherInstance := orgGroup("other-instance", 0, "gpu")
+		otherInstance.Spec.GiteaURL = "https://git.example.org"
+
+		matches := ExplainJob("https://gitea.example.com/",
+			[]giteav1alpha1.RunnerGroup{linux, gpu, urgent, otherOrg, otherInstance}, job)
+		Expect(matches).To(HaveLen(4))
+		Expect(matches[0].RunnerGroup.Name).To(Equal("gpu-urgent"))
+		Expect(matches[0].Matches).To(BeTrue())
+		Expect(matches[0].Reason).To(BeEmpty())
+		Expect(matches[1].RunnerGroup.Name).To(Equal("gpu"))
+		Expect(matches[1].Matches).To(BeTrue())
+		Expect(matches[2].RunnerGroup.Name).To(Equal("other-org"))
+		Expect(matches[2].Reason).To(ContainSubstring("scope doesn't cover repository myorg/app"))
+		Expect(matches[3].RunnerGroup.Name).To(Equal("linux"))
+		Expect(matches[3].Reason).To(ContainSubstring("don't satisfy the job's labels [gpu]"))
+	})
+
+	It("should report what keeps a matching group from spawning", func() {
+		gpu := orgGroup("gpu", 0, "gpu")
+		meta.SetStatusCondition(&gpu.Status.Conditions, metav1.Condition{
+			Type:    giteav1alpha1.ConditionTokenValid,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Unauthorized",
+			Message: "Gitea rejected the auth token",
+		})
+
+		matches := ExplainJob("https://gitea.example.com", []giteav1alpha1.RunnerGroup{gpu}, job)
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Matches).To(BeTrue())
+		Expect(matches[0].Reason).To(Equal("TokenValid is False: Gitea rejected the auth token"))
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// defaultMaxRunnerFailures is how many runners may fail within the window by default
+	defaultMaxRunnerFailures = 5
+	// defaultFailureWindow is how far back runner failures are counted by default
+	defaultFailureWindow = 10 * time.Minute
+	// defaultFailureBackoff is how long spawning pauses after the latest failure by default
+	defaultFailureBackoff = 5 * time.Minute
+	// degradedRunnerFailures is the Degraded reason while the group's runners keep failing
+	degradedRunnerFailures = "RunnerFailures"
+)
+
+// runnerStartFailures are the waiting reasons of runner containers that won't start without a fix
+var runnerStartFailures = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"CrashLoopBackOff":           true,
+}
+
+// runnerFailure is a runner Job that failed, or whose pod can't start
+type runnerFailure struct {
+	job     string
+	time    time.Time
+	reason  string
+	message string
+}
+
+// failureBudget returns the group's failure budget, filling in the defaults
+func failureBudget(runnerGroup *giteav1alpha1.RunnerGroup) (int, time.Duration, time.Duration) {
+	maxFailures, window, backoff := defaultMaxRunnerFailures, defaultFailureWindow, defaultFailureBackoff
+	if budget := runnerGroup.Spec.FailureBudget; budget != nil {
+		if budget.MaxFailures > 0 {
+			maxFailures = budget.MaxFailures
+		}
+		if budget.Window.Duration > 0 {
+			window = budget.Window.Duration
+		}
+		if budget.Backoff.Duration > 0 {
+			backoff = budget.Backoff.Duration
+		}
+	}
+	return maxFailures, window, backoff
+}
+
+// runnerFailures returns the failures of the runners since the latest runner succeeded, oldest
+// first. A Job counts once, whether it failed or its pod can't start.
+func runnerFailures(jobs []batchv1.Job, pods []corev1.Pod) []runnerFailure {
+	var lastSuccess time.Time
+	failed := make(map[string]runnerFailure)
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime != nil && job.Status.CompletionTime.After(lastSuccess) {
+			lastSuccess = job.Status.CompletionTime.Time
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				failed[job.Name] = runnerFailure{job: job.Name, time: condition.LastTransitionTime.Time,
+					reason: condition.Reason, message: condition.Message}
+			}
+		}
+	}
+	for i := range pods {
+		pod := &pods[i]
+		jobName := pod.Labels[batchv1.JobNameLabel]
+		if _, ok := failed[jobName]; ok || jobName == "" {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting != nil && runnerStartFailures[status.State.Waiting.Reason] {
+				failed[jobName] = runnerFailure{job: jobName, time: pod.CreationTimestamp.Time,
+					reason: status.State.Waiting.Reason, message: status.State.Waiting.Message}
+				break
+			}
+		}
+	}
+
+	var failures []runnerFailure
+	for _, failure := range failed {
+		if failure.time.After(lastSuccess) {
+			failures = append(failures, failure)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].time.Before(failures[j].time) })
+	return failures
+}
+
+// backingOffFromFailures reports whether the RunnerGroup's status records that its runners
+// exhausted the failure budget, so it holds back spawning
+func backingOffFromFailures(runnerGroup *giteav1alpha1.RunnerGroup) bool {
+	degraded := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)
+	return degraded != nil && degraded.Status == metav1.ConditionTrue && degraded.Reason == degradedRunnerFailures
+}
+
+// checkFailureBudget sets the Degraded condition while more of the group's runners failed within
+// the budget's window than it allows, and returns how long spawning has to pause: until the
+// backoff after the latest failure is over. Once it is, spawning is tried again.
+func (r *RunnerGroupReconciler) checkFailureBudget(runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job, pods []corev1.Pod, now time.Time) time.Duration {
+	maxFailures, window, backoff := failureBudget(runnerGroup)
+	var recent []runnerFailure
+	for _, failure := range runnerFailures(jobs, pods) {
+		if now.Sub(failure.time) <= window {
+			recent = append(recent, failure)
+		}
+	}
+
+	degraded := backingOffFromFailures(runnerGroup)
+	if len(recent) < maxFailures {
+		if degraded {
+			meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+				Type:               giteav1alpha1.ConditionDegraded,
+				Status:             metav1.ConditionFalse,
+				Reason:             "RunnersRecovered",
+				Message:            "Runners no longer keep failing",
+				ObservedGeneration: runnerGroup.Generation,
+			})
+		}
+		return 0
+	}
+
+	last := recent[len(recent)-1]
+	message := fmt.Sprintf("%d runners failed within %s, the latest %s with %s", len(recent), window, last.job, last.reason)
+	if last.message != "" {
+		message += ": " + last.message
+	}
+	if !degraded && r.Recorder != nil {
+		r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, degradedRunnerFailures, message+"; backing off from spawning runners")
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             degradedRunnerFailures,
+		Message:            message,
+		ObservedGeneration: runnerGroup.Generation,
+	})
+	return max(last.time.Add(backoff).Sub(now), 0)
+}
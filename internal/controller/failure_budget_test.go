@@ -0,0 +1,123 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Runner failure budget", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	failedAt := func(name string, failed time.Time) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+				Type:               batchv1.JobFailed,
+				Status:             corev1.ConditionTrue,
+				Reason:             "BackoffLimitExceeded",
+				Message:            "Job has reached the specified backoff limit",
+				LastTransitionTime: metav1.NewTime(failed),
+			}}},
+		}
+	}
+
+	It("should count failed Jobs and pods that can't start since the latest success", func() {
+		succeeded := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "succeeded"}}
+		succeeded.Status.CompletionTime = &metav1.Time{Time: now.Add(-20 * time.Minute)}
+		jobs := []batchv1.Job{
+			failedAt("before-success", now.Add(-30*time.Minute)),
+			succeeded,
+			failedAt("failed", now.Add(-10*time.Minute)),
+		}
+		pullingPod := func(name, jobName string, created time.Time) corev1.Pod {
+			return corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{batchv1.JobNameLabel: jobName},
+					CreationTimestamp: metav1.NewTime(created)},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+				}}},
+			}
+		}
+		pods := []corev1.Pod{
+			pullingPod("typo-abc", "typo", now.Add(-5*time.Minute)),
+			pullingPod("failed-abc", "failed", now.Add(-11*time.Minute)),
+			{ObjectMeta: metav1.ObjectMeta{Name: "healthy-abc", Labels: map[string]string{batchv1.JobNameLabel: "healthy"}}},
+		}
+
+		failures := runnerFailures(jobs, pods)
+		Expect(failures).To(HaveLen(2))
+		Expect(failures[0].job).To(Equal("failed"))
+		Expect(failures[0].reason).To(Equal("BackoffLimitExceeded"))
+		Expect(failures[1].job).To(Equal("typo"))
+		Expect(failures[1].reason).To(Equal("ImagePullBackOff"))
+	})
+
+	It("should back off and set Degraded once the budget is spent", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "failing", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				FailureBudget: &giteav1alpha1.FailureBudgetSpec{MaxFailures: 2, Window: metav1.Duration{Duration: 10 * time.Minute},
+					Backoff: metav1.Duration{Duration: 5 * time.Minute}},
+			},
+		}
+		reconciler := &RunnerGroupReconciler{}
+
+		By("tolerating failures within the budget")
+		jobs := []batchv1.Job{failedAt("first", now.Add(-3*time.Minute)), failedAt("old", now.Add(-time.Hour))}
+		pause := reconciler.checkFailureBudget(runnerGroup, jobs, nil, now)
+		Expect(pause).To(BeZero())
+		Expect(meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)).To(BeNil())
+
+		By("pausing until the backoff after the latest failure is over")
+		jobs = append(jobs, failedAt("second", now.Add(-time.Minute)))
+		pause = reconciler.checkFailureBudget(runnerGroup, jobs, nil, now)
+		Expect(pause).To(Equal(4 * time.Minute))
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(degradedRunnerFailures))
+		Expect(condition.Message).To(ContainSubstring("second with BackoffLimitExceeded"))
+
+		By("trying again after the backoff, still degraded")
+		pause = reconciler.checkFailureBudget(runnerGroup, jobs, nil, now.Add(5*time.Minute))
+		Expect(pause).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)).To(BeTrue())
+
+		By("recovering once a runner succeeds")
+		succeeded := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "succeeded"}}
+		succeeded.Status.CompletionTime = &metav1.Time{Time: now.Add(6 * time.Minute)}
+		pause = reconciler.checkFailureBudget(runnerGroup, append(jobs, succeeded), nil, now.Add(7*time.Minute))
+		Expect(pause).To(BeZero())
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDegraded)).To(BeTrue())
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// probeGitea checks that the group's Gitea instance answers its version endpoint and records the
+// outcome, with the compatibility of the detected version, in the GiteaReachable condition and
+// status.gitea. Like status.lastCheckTime, the probe time and latency alone are only written
+// every lastCheckTimeRefresh, so an idle poll leaves the status unchanged.
+func (r *RunnerGroupReconciler) probeGitea(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup) {
+	logger := log.FromContext(ctx)
+
+	// The version endpoint only needs the token on instances that require signing in; a token
+	// that can't be read is reported by the poll
+	authToken, _ := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+
+	start := time.Now()
+	version, err := r.GiteaClient.GetVersion(ctx, runnerGroup.Spec.GiteaURL, authToken)
+	latency := time.Since(start)
+
+	if runnerGroup.Status.Gitea == nil {
+		runnerGroup.Status.Gitea = &giteav1alpha1.GiteaStatus{}
+	}
+	before := *runnerGroup.Status.Gitea
+	reachableBefore := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)
+	defer func() {
+		// Refresh the probe time when anything else about the check changed, or once it is stale
+		reachable := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)
+		if before.LastProbeTime.IsZero() || start.Sub(before.LastProbeTime.Time) >= lastCheckTimeRefresh ||
+			before.Version != runnerGroup.Status.Gitea.Version || before.Compatibility != runnerGroup.Status.Gitea.Compatibility ||
+			reachableBefore == nil || reachableBefore.Status != reachable.Status {
+			runnerGroup.Status.Gitea.LastProbeTime = metav1.NewTime(start)
+			if err == nil {
+				runnerGroup.Status.Gitea.Latency = metav1.Duration{Duration: latency}
+			}
+		}
+	}()
+
+	// A rejected token is a problem of the group, not of the instance, and a throttling instance
+	// is up; the poll reports both
+	if _, throttled := rateLimitRequeue(err, 0); err != nil && !authTokenRejected(err) && !throttled {
+		logger.Error(err, "Gitea version check failed", "url", runnerGroup.Spec.GiteaURL)
+		message := fmt.Sprintf("Gitea at %s did not answer the version check: %v", runnerGroup.Spec.GiteaURL, err)
+		if !meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable) && r.Recorder != nil {
+			r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "GiteaUnreachable", message)
+		}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionGiteaReachable,
+			Status:             metav1.ConditionFalse,
+			Reason:             "VersionCheckFailed",
+			Message:            message,
+			ObservedGeneration: runnerGroup.Generation,
+		})
+		return
+	}
+
+	message := fmt.Sprintf("Gitea at %s answered the version check", runnerGroup.Spec.GiteaURL)
+	if err == nil {
+		caps := gitea.CapabilitiesFor(version)
+		message = fmt.Sprintf("Gitea %s at %s answered the version check", version, runnerGroup.Spec.GiteaURL)
+		if missing := strings.Join(caps.Missing(), " and "); missing != "" {
+			message += fmt.Sprintf("; it lacks %s", missing)
+			if runnerGroup.Status.Gitea.Compatibility != gitea.CompatibilityLimited && r.Recorder != nil {
+				r.Recorder.Eventf(runnerGroup, corev1.EventTypeWarning, "GiteaCompatibilityLimited",
+					"Gitea %s lacks %s; the operator works around it", version, missing)
+			}
+		}
+		runnerGroup.Status.Gitea.Version = version
+		runnerGroup.Status.Gitea.Compatibility = caps.Compatibility()
+	}
+	if meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable) && r.Recorder != nil {
+		r.Recorder.Event(runnerGroup, corev1.EventTypeNormal, "GiteaReachable", message)
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionGiteaReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "VersionCheckSucceeded",
+		Message:            message,
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
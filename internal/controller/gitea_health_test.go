@@ -0,0 +1,140 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// versionGiteaClient fails the version check with err, or reports version if set
+type versionGiteaClient struct {
+	fakeGiteaClient
+	err     error
+	version string
+}
+
+func (c *versionGiteaClient) GetVersion(ctx context.Context, giteaURL, authToken string) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if c.version != "" {
+		return c.version, nil
+	}
+	return c.fakeGiteaClient.GetVersion(ctx, giteaURL, authToken)
+}
+
+var _ = Describe("Gitea connectivity check", func() {
+	ctx := context.Background()
+
+	var (
+		giteaClient *versionGiteaClient
+		recorder    *record.FakeRecorder
+		reconciler  *RunnerGroupReconciler
+		runnerGroup *giteav1alpha1.RunnerGroup
+	)
+
+	BeforeEach(func() {
+		giteaClient = &versionGiteaClient{}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &RunnerGroupReconciler{Client: k8sClient, GiteaClient: giteaClient, Recorder: recorder}
+		runnerGroup = &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default", Generation: 1},
+			Spec:       giteav1alpha1.RunnerGroupSpec{GiteaURL: "https://gitea.example.com"},
+		}
+	})
+
+	It("should record the version of a reachable Gitea", func() {
+		reconciler.probeGitea(ctx, runnerGroup)
+
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)).To(BeTrue())
+		Expect(runnerGroup.Status.Gitea.Version).To(Equal("1.25.0"))
+		Expect(runnerGroup.Status.Gitea.Compatibility).To(Equal(gitea.CompatibilityFull))
+		Expect(runnerGroup.Status.Gitea.LastProbeTime.IsZero()).To(BeFalse())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should leave the status of an idle Gitea unchanged until the probe time is stale", func() {
+		reconciler.probeGitea(ctx, runnerGroup)
+		statusBefore := runnerGroup.Status.DeepCopy()
+		reconciler.probeGitea(ctx, runnerGroup)
+		Expect(equality.Semantic.DeepEqual(statusBefore, &runnerGroup.Status)).To(BeTrue())
+
+		stale := metav1.NewTime(runnerGroup.Status.Gitea.LastProbeTime.Add(-lastCheckTimeRefresh))
+		runnerGroup.Status.Gitea.LastProbeTime = stale
+		reconciler.probeGitea(ctx, runnerGroup)
+		Expect(runnerGroup.Status.Gitea.LastProbeTime.After(stale.Time)).To(BeTrue())
+	})
+
+	It("should report the features an older Gitea lacks once", func() {
+		giteaClient.version = "1.22.3"
+		reconciler.probeGitea(ctx, runnerGroup)
+		reconciler.probeGitea(ctx, runnerGroup)
+
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("lacks Actions jobs endpoints and ephemeral runners"))
+		Expect(runnerGroup.Status.Gitea.Compatibility).To(Equal(gitea.CompatibilityLimited))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("GiteaCompatibilityLimited"))
+	})
+
+	It("should report an unreachable Gitea once and keep the last known version", func() {
+		reconciler.probeGitea(ctx, runnerGroup)
+		giteaClient.err = fmt.Errorf("dial tcp: connection refused")
+		reconciler.probeGitea(ctx, runnerGroup)
+		reconciler.probeGitea(ctx, runnerGroup)
+
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("VersionCheckFailed"))
+		Expect(condition.Message).To(ContainSubstring("connection refused"))
+		Expect(runnerGroup.Status.Gitea.Version).To(Equal("1.25.0"))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("GiteaUnreachable"))
+
+		giteaClient.err = nil
+		reconciler.probeGitea(ctx, runnerGroup)
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)).To(BeTrue())
+		Expect(<-recorder.Events).To(ContainSubstring("GiteaReachable"))
+	})
+
+	It("should not blame Gitea for a rejected token", func() {
+		giteaClient.err = fmt.Errorf("fetch version: %w", gitea.ErrUnauthorized)
+		reconciler.probeGitea(ctx, runnerGroup)
+
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable)).To(BeTrue())
+		Expect(runnerGroup.Status.Gitea.Version).To(BeEmpty())
+	})
+})
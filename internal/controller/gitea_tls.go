@@ -0,0 +1,81 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// insecureSkipVerify returns whether the operator skips verifying the instance's TLS certificate
+func insecureSkipVerify(instance *giteav1alpha1.GiteaInstance) bool {
+	return instance != nil && instance.Spec.TLS != nil && instance.Spec.TLS.InsecureSkipVerify
+}
+
+// withGiteaInstance returns a context whose Gitea requests use the TLS settings and request
+// limits of the GiteaInstance configured for giteaURL, if any
+func withGiteaInstance(ctx context.Context, c client.Reader, giteaURL string) (context.Context, error) {
+	giteaInstanceList := &giteav1alpha1.GiteaInstanceList{}
+	if err := c.List(ctx, giteaInstanceList); err != nil {
+		return ctx, fmt.Errorf("failed to list GiteaInstances: %w", err)
+	}
+	instance := giteaInstanceForURL(giteaInstanceList.Items, giteaURL)
+	ctx = gitea.WithHostLimits(ctx, requestLimits(instance))
+	return gitea.WithInsecureSkipVerify(ctx, insecureSkipVerify(instance)), nil
+}
+
+// updateInsecureTLS sets the InsecureTLS condition while the group's GiteaInstance skips TLS
+// verification, warning once each time it is turned on
+func (r *RunnerGroupReconciler) updateInsecureTLS(runnerGroup *giteav1alpha1.RunnerGroup, instance *giteav1alpha1.GiteaInstance) {
+	if insecureSkipVerify(instance) {
+		message := fmt.Sprintf("GiteaInstance %s skips TLS certificate verification; auth tokens are sent over unverified connections", instance.Name)
+		if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionInsecureTLS) && r.Recorder != nil {
+			r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "InsecureTLS", message)
+		}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionInsecureTLS,
+			Status:             metav1.ConditionTrue,
+			Reason:             "InsecureSkipVerify",
+			Message:            message,
+			ObservedGeneration: runnerGroup.Generation,
+		})
+		return
+	}
+	if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionInsecureTLS) {
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionInsecureTLS,
+			Status:             metav1.ConditionFalse,
+			Reason:             "CertificateVerified",
+			Message:            "The Gitea instance's TLS certificate is verified",
+			ObservedGeneration: runnerGroup.Generation,
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Insecure TLS", func() {
+	var (
+		reconciler  *RunnerGroupReconciler
+		recorder    *record.FakeRecorder
+		runnerGroup *giteav1alpha1.RunnerGroup
+		instance    *giteav1alpha1.GiteaInstance
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &RunnerGroupReconciler{Recorder: recorder}
+		runnerGroup = &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"}}
+		instance = &giteav1alpha1.GiteaInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitea"},
+			Spec: giteav1alpha1.GiteaInstanceSpec{
+				URL: "https://gitea.example.com",
+				TLS: &giteav1alpha1.GiteaInstanceTLS{InsecureSkipVerify: true},
+			},
+		}
+	})
+
+	It("should not report anything for instances verifying certificates", func() {
+		reconciler.updateInsecureTLS(runnerGroup, nil)
+		instance.Spec.TLS = nil
+		reconciler.updateInsecureTLS(runnerGroup, instance)
+		Expect(meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionInsecureTLS)).To(BeNil())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should warn once while verification is skipped", func() {
+		reconciler.updateInsecureTLS(runnerGroup, instance)
+		reconciler.updateInsecureTLS(runnerGroup, instance)
+
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionInsecureTLS)).To(BeTrue())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("InsecureTLS"))
+	})
+
+	It("should clear the condition once verification is back on", func() {
+		reconciler.updateInsecureTLS(runnerGroup, instance)
+		instance.Spec.TLS.InsecureSkipVerify = false
+		reconciler.updateInsecureTLS(runnerGroup, instance)
+
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionInsecureTLS)).To(BeTrue())
+	})
+})
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// handoffSignatureHeader carries the hex HMAC-SHA256 of a handoff request body
+const handoffSignatureHeader = "X-Runner-Operator-Signature"
+
+// handoffTimeout bounds a request to an external provisioner when no HTTP client is configured
+const handoffTimeout = 10 * time.Second
+
+// handoffRequest is the body POSTed to an external provisioner for every job handed off
+type handoffRequest struct {
+	GiteaURL          string             `json:"giteaURL"`
+	RunnerGroup       handoffRunnerGroup `json:"runnerGroup"`
+	Job               handoffJob         `json:"job"`
+	RegistrationToken string             `json:"registrationToken"`
+}
+
+type handoffRunnerGroup struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type handoffJob struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+	Repo   string   `json:"repo,omitempty"`
+	RunID  int64    `json:"runID"`
+}
+
+// externalProvisionerForJob returns the first provisioner handling any of the job's labels, or nil
+func externalProvisionerForJob(provisioners []giteav1alpha1.ExternalProvisioner, job gitea.ActionWorkflowJob) *giteav1alpha1.ExternalProvisioner {
+	for i := range provisioners {
+		for _, label := range job.Labels {
+			if slices.Contains(provisioners[i].Labels, gitea.NormalizeLabel(label)) {
+				return &provisioners[i]
+			}
+		}
+	}
+	return nil
+}
+
+// delegateJob hands a queued job to an external provisioner instead of spawning a runner pod
+// and records the delegation in status. The job is claimed first so no other RunnerGroup
+// provisions it; if the provisioner rejects it the claim is released to retry on the next poll.
+func (r *RunnerGroupReconciler) delegateJob(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup,
+	provisioner *giteav1alpha1.ExternalProvisioner, job gitea.ActionWorkflowJob) error {
+	logger := log.FromContext(ctx).WithValues("giteaJobID", job.ID, "provisioner", provisioner.Name)
+
+	if value, loaded := r.SpawnedJobsCache.Load(job.ID); loaded && time.Since(value.(time.Time)) < spawnRetryTimeout {
+		return nil
+	}
+
+	claimed, err := r.claimJob(ctx, runnerGroup, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to claim job: %w", err)
+	}
+	if !claimed {
+		logger.V(1).Info("Job already claimed, skipping")
+		return nil
+	}
+
+	if err := r.postHandoff(ctx, runnerGroup, provisioner, job); err != nil {
+		claim := &giteav1alpha1.JobClaim{ObjectMeta: metav1.ObjectMeta{Name: jobClaimName(runnerGroup.Spec.GiteaURL, job.ID)}}
+		if deleteErr := r.Delete(ctx, claim); deleteErr != nil && !errors.IsNotFound(deleteErr) {
+			logger.Error(deleteErr, "Failed to release JobClaim")
+		}
+		return err
+	}
+
+	logger.Info("Handed job off to external provisioner")
+	r.SpawnedJobsCache.Store(job.ID, time.Now())
+	runnerGroup.Status.DelegatedJobs = slices.DeleteFunc(runnerGroup.Status.DelegatedJobs, func(d giteav1alpha1.DelegatedJob) bool {
+		return d.GiteaJobID == job.ID
+	})
+	runnerGroup.Status.DelegatedJobs = append(runnerGroup.Status.DelegatedJobs, giteav1alpha1.DelegatedJob{
+		GiteaJobID:  job.ID,
+		Provisioner: provisioner.Name,
+		DelegatedAt: metav1.Now(),
+	})
+	return nil
+}
+
+// postHandoff sends the job and a registration token to the provisioner, signing the body if
+// the provisioner has a secret
+func (r *RunnerGroupReconciler) postHandoff(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup,
+	provisioner *giteav1alpha1.ExternalProvisioner, job gitea.ActionWorkflowJob) error {
+	registrationToken, err := resolveRegistrationToken(ctx, r.Client, r.GiteaClient, r.RegistrationTokens, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(handoffRequest{
+		GiteaURL:    runnerGroup.Spec.GiteaURL,
+		RunnerGroup: handoffRunnerGroup{Namespace: runnerGroup.Namespace, Name: runnerGroup.Name},
+		Job: handoffJob{
+			ID:     job.ID,
+			Name:   job.Name,
+			Labels: job.Labels,
+			Repo:   job.RepoFullName(),
+			RunID:  job.RunID,
+		},
+		RegistrationToken: registrationToken,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode handoff request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provisioner.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create handoff request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if provisioner.SecretRef != nil {
+		secret, err := r.getSecretValue(ctx, runnerGroup.Namespace, *provisioner.SecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get provisioner secret: %w", err)
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		req.Header.Set(handoffSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: handoffTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach provisioner: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("provisioner rejected job with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// pruneDelegatedJobs drops delegations for jobs that are no longer queued
+func pruneDelegatedJobs(delegated []giteav1alpha1.DelegatedJob, queuedIDs map[int64]bool) []giteav1alpha1.DelegatedJob {
+	return slices.DeleteFunc(delegated, func(d giteav1alpha1.DelegatedJob) bool {
+		return !queuedIDs[d.GiteaJobID]
+	})
+}
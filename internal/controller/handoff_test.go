@@ -0,0 +1,156 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("External provisioner handoff", func() {
+	ctx := context.Background()
+
+	provisioners := []giteav1alpha1.ExternalProvisioner{
+		{Name: "windows-vms", Labels: []string{"windows", "windows-2022"}},
+		{Name: "macs", Labels: []string{"macos"}},
+	}
+
+	It("should pick the provisioner by job label", func() {
+		Expect(externalProvisionerForJob(provisioners, gitea.ActionWorkflowJob{Labels: []string{"windows-2022:host"}}).Name).
+			To(Equal("windows-vms"))
+		Expect(externalProvisionerForJob(provisioners, gitea.ActionWorkflowJob{Labels: []string{"macos"}}).Name).
+			To(Equal("macs"))
+		Expect(externalProvisionerForJob(provisioners, gitea.ActionWorkflowJob{Labels: []string{"ubuntu-latest"}})).To(BeNil())
+		Expect(externalProvisionerForJob(nil, gitea.ActionWorkflowJob{Labels: []string{"windows"}})).To(BeNil())
+	})
+
+	It("should drop delegations for jobs no longer queued", func() {
+		delegated := []giteav1alpha1.DelegatedJob{{GiteaJobID: 1}, {GiteaJobID: 2}}
+		Expect(pruneDelegatedJobs(delegated, map[int64]bool{2: true})).To(Equal([]giteav1alpha1.DelegatedJob{{GiteaJobID: 2}}))
+	})
+
+	Context("when delegating a job", func() {
+		var (
+			reconciler *RunnerGroupReconciler
+			group      *giteav1alpha1.RunnerGroup
+			status     int
+			received   []handoffRequest
+		)
+
+		BeforeEach(func() {
+			status = http.StatusAccepted
+			received = nil
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var req handoffRequest
+				Expect(json.Unmarshal(body, &req)).To(Succeed())
+				received = append(received, req)
+				mac := hmac.New(sha256.New, []byte("hook-secret"))
+				mac.Write(body)
+				Expect(r.Header.Get(handoffSignatureHeader)).To(Equal(hex.EncodeToString(mac.Sum(nil))))
+				w.WriteHeader(status)
+			}))
+			DeferCleanup(server.Close)
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "handoff-secrets", Namespace: "default"},
+				StringData: map[string]string{"token": "reg-token", "hmac": "hook-secret"},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+				Expect(k8sClient.DeleteAllOf(ctx, &giteav1alpha1.JobClaim{})).To(Succeed())
+			})
+
+			reconciler = &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), HTTPClient: server.Client()}
+			group = &giteav1alpha1.RunnerGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "handoff", Namespace: "default"},
+				Spec: giteav1alpha1.RunnerGroupSpec{
+					GiteaURL: "https://gitea.example.com",
+					RegistrationTokenRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "handoff-secrets"},
+						Key:                  "token",
+					},
+					ExternalProvisioners: []giteav1alpha1.ExternalProvisioner{{
+						Name:   "windows-vms",
+						Labels: []string{"windows"},
+						URL:    server.URL,
+						SecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "handoff-secrets"},
+							Key:                  "hmac",
+						},
+					}},
+				},
+			}
+		})
+
+		It("should send the job with a registration token and record it in status", func() {
+			job := gitea.ActionWorkflowJob{
+				ID:     70,
+				Name:   "build",
+				Labels: []string{"windows"},
+				RunID:  7,
+				URL:    "https://gitea.example.com/api/v1/repos/org/app/actions/jobs/70",
+			}
+			Expect(reconciler.delegateJob(ctx, group, &group.Spec.ExternalProvisioners[0], job)).To(Succeed())
+
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].RegistrationToken).To(Equal("reg-token"))
+			Expect(received[0].RunnerGroup).To(Equal(handoffRunnerGroup{Namespace: "default", Name: "handoff"}))
+			Expect(received[0].Job).To(Equal(handoffJob{ID: 70, Name: "build", Labels: []string{"windows"}, Repo: "org/app", RunID: 7}))
+			Expect(group.Status.DelegatedJobs).To(HaveLen(1))
+			Expect(group.Status.DelegatedJobs[0].GiteaJobID).To(Equal(int64(70)))
+			Expect(group.Status.DelegatedJobs[0].Provisioner).To(Equal("windows-vms"))
+
+			By("not handing the job off again while it waits for the provisioner")
+			Expect(reconciler.delegateJob(ctx, group, &group.Spec.ExternalProvisioners[0], job)).To(Succeed())
+			Expect(received).To(HaveLen(1))
+		})
+
+		It("should release the claim when the provisioner rejects the job", func() {
+			status = http.StatusServiceUnavailable
+			job := gitea.ActionWorkflowJob{ID: 71, Labels: []string{"windows"}}
+			Expect(reconciler.delegateJob(ctx, group, &group.Spec.ExternalProvisioners[0], job)).NotTo(Succeed())
+			Expect(group.Status.DelegatedJobs).To(BeEmpty())
+
+			claimed, err := reconciler.claimJob(ctx, group, 71)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// idleRunner is a runner Job whose runner is online in Gitea but never picked up a job
+type idleRunner struct {
+	job      *batchv1.Job
+	giteaID  int64
+	idleTime time.Duration
+}
+
+// idleRunners returns the unfinished runner Jobs whose runner has been online and not busy in
+// Gitea for longer than timeout. idleSince holds when the runners were first seen idle, by Job
+// name; pulling the image and registering don't count. The runners seen idle now are returned
+// with it for the next check. Runners are ephemeral and exit after their one job, so a runner
+// idle that long never got a job.
+func idleRunners(jobs []batchv1.Job, registered []gitea.ActionRunner, idleSince map[string]time.Time, timeout time.Duration,
+	now time.Time) ([]idleRunner, map[string]time.Time) {
+	idle := make(map[string]int64)
+	for _, runner := range registered {
+		if runner.Online() && !runner.Busy {
+			idle[runner.Name] = runner.ID
+		}
+	}
+	var found []idleRunner
+	seen := make(map[string]time.Time)
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime != nil || job.DeletionTimestamp != nil {
+			continue
+		}
+		id, ok := idle[job.Name]
+		if !ok {
+			continue
+		}
+		since, known := idleSince[job.Name]
+		if !known {
+			since = now
+		}
+		seen[job.Name] = since
+		if idleTime := now.Sub(since); idleTime > timeout {
+			found = append(found, idleRunner{job: job, giteaID: id, idleTime: idleTime})
+		}
+	}
+	return found, seen
+}
+
+// reapIdleRunners deregisters the runners that stayed online and idle for longer than the group's
+// startup claim timeout from Gitea and deletes their Jobs, so they stop taking a slot. It returns the
+// names of the Jobs it deleted. Runners that can't be deregistered are kept, since they could
+// still pick up a job.
+func (r *RunnerGroupReconciler) reapIdleRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job,
+	registered []gitea.ActionRunner, now time.Time) (map[string]bool, error) {
+	logger := log.FromContext(ctx)
+	timeout := runnerGroup.Spec.StartupClaimTimeout.Duration
+
+	key := client.ObjectKeyFromObject(runnerGroup)
+	var idleSince map[string]time.Time
+	if value, ok := r.idleSince.Load(key); ok {
+		idleSince = value.(map[string]time.Time)
+	}
+	idle, idleSince := idleRunners(jobs, registered, idleSince, timeout, now)
+	r.idleSince.Store(key, idleSince)
+	if len(idle) == 0 {
+		return nil, nil
+	}
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return nil, err
+	}
+
+	reaped := make(map[string]bool)
+	for _, runner := range idle {
+		job := runner.job
+		if err := r.GiteaClient.DeleteRunner(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+			runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo, runner.giteaID); err != nil {
+			logger.Error(err, "Failed to deregister idle runner from Gitea", "runner", job.Name)
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return reaped, fmt.Errorf("failed to delete runner Job %s: %w", job.Name, err)
+		}
+		reaped[job.Name] = true
+		idleTime := runner.idleTime.Round(time.Second)
+		logger.Info("Reaped runner that never picked up a job", "job", job.Name, "idle", idleTime, "startupClaimTimeout", timeout)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "RunnerIdle",
+				"Deleted runner %s that picked up no job within %s", job.Name, timeout)
+		}
+	}
+	return reaped, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Idle runners", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	startedAt := func(name string, started time.Time) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: started}},
+		}
+	}
+
+	It("should only report runners online and not busy for longer than the timeout", func() {
+		finished := startedAt("finished", now.Add(-time.Hour))
+		finished.Status.CompletionTime = &metav1.Time{Time: now}
+		jobs := []batchv1.Job{
+			startedAt("idle", now.Add(-time.Hour)),
+			startedAt("fresh", now.Add(-time.Hour)),
+			startedAt("busy", now.Add(-time.Hour)),
+			startedAt("offline", now.Add(-time.Hour)),
+			startedAt("unregistered", now.Add(-time.Hour)),
+			finished,
+		}
+		registered := []gitea.ActionRunner{
+			{ID: 1, Name: "idle", Status: "idle"},
+			{ID: 2, Name: "fresh", Status: "idle"},
+			{ID: 3, Name: "busy", Status: "active", Busy: true},
+			{ID: 4, Name: "offline", Status: "offline"},
+			{ID: 5, Name: "finished", Status: "idle"},
+		}
+		idleSince := map[string]time.Time{
+			"idle":    now.Add(-time.Hour),
+			"busy":    now.Add(-time.Hour),
+			"offline": now.Add(-time.Hour),
+		}
+
+		idle, idleSince := idleRunners(jobs, registered, idleSince, 10*time.Minute, now)
+		Expect(idle).To(HaveLen(1))
+		Expect(idle[0].job.Name).To(Equal("idle"))
+		Expect(idle[0].giteaID).To(Equal(int64(1)))
+		Expect(idle[0].idleTime).To(Equal(time.Hour))
+		// A Job started long ago only counts as idle from when its runner was first seen idle
+		Expect(idleSince).To(Equal(map[string]time.Time{"idle": now.Add(-time.Hour), "fresh": now}))
+
+		idle, _ = idleRunners(jobs, registered, idleSince, 10*time.Minute, now.Add(11*time.Minute))
+		Expect(idle).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,252 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// instancePollers runs a poller for every GiteaInstance, so Gitea is crawled on a schedule of
+// its own rather than inside RunnerGroup reconciles
+type instancePollers struct {
+	reconciler *RunnerGroupReconciler
+}
+
+// Start implements manager.Runnable. It starts and stops pollers as GiteaInstances come and go.
+func (p *instancePollers) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("instance-poller")
+	r := p.reconciler
+	running := make(map[string]context.CancelFunc)
+	var wg sync.WaitGroup
+	defer func() {
+		for _, cancel := range running {
+			cancel()
+		}
+		wg.Wait()
+	}()
+
+	for {
+		giteaInstanceList := &giteav1alpha1.GiteaInstanceList{}
+		if err := r.List(ctx, giteaInstanceList); err != nil {
+			logger.Error(err, "Failed to list GiteaInstances")
+		} else {
+			seen := make(map[string]bool, len(giteaInstanceList.Items))
+			for _, instance := range giteaInstanceList.Items {
+				seen[instance.Name] = true
+				if running[instance.Name] != nil {
+					continue
+				}
+				logger.Info("Starting poller", "giteaInstance", instance.Name)
+				pollerCtx, cancel := context.WithCancel(ctx)
+				running[instance.Name] = cancel
+				poller := &instancePoller{reconciler: r, name: instance.Name, snapshots: make(map[queuedJobsKey][]int64)}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					poller.run(log.IntoContext(pollerCtx, logger.WithValues("giteaInstance", poller.name)))
+				}()
+			}
+			for name, cancel := range running {
+				if !seen[name] {
+					logger.Info("Stopping poller of deleted GiteaInstance", "giteaInstance", name)
+					cancel()
+					delete(running, name)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.currentPollInterval()):
+		}
+	}
+}
+
+// instancePoller crawls the queued jobs of every scope the RunnerGroups of one GiteaInstance
+// poll, and enqueues the groups of a scope whose queue changed. The groups' reconciles then
+// find the jobs in the shared queued jobs cache instead of waiting for Gitea.
+type instancePoller struct {
+	reconciler *RunnerGroupReconciler
+	name       string
+	// snapshots holds the IDs of the queued jobs each scope had at the last poll
+	snapshots map[queuedJobsKey][]int64
+}
+
+// run polls until ctx is done
+func (p *instancePoller) run(ctx context.Context) {
+	for {
+		delay := p.poll(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// poll crawls every scope of the instance once and returns when to poll next
+func (p *instancePoller) poll(ctx context.Context, now time.Time) time.Duration {
+	logger := log.FromContext(ctx)
+	r := p.reconciler
+
+	instance := &giteav1alpha1.GiteaInstance{}
+	if err := r.Get(ctx, types.NamespacedName{Name: p.name}, instance); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get GiteaInstance")
+		}
+		return r.currentPollInterval()
+	}
+	interval := r.groupPollInterval(instance)
+	if window := activeMaintenanceWindow(instance, now); window != nil {
+		logger.V(1).Info("Not polling during maintenance window", "until", window.End.Time)
+		return min(window.End.Sub(now), interval)
+	}
+	if stop, err := r.emergencyStop(ctx); err != nil || stop != nil {
+		return interval
+	}
+
+	scopes, err := p.scopes(ctx, instance)
+	if err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return interval
+	}
+
+	delay := interval
+	for key, scope := range scopes {
+		jobs, _, err := r.queuedJobs.get(scope.ctx, key, now, func() ([]gitea.ActionWorkflowJob, error) {
+			stats, err := r.GiteaClient.GetRunnerStats(scope.ctx, key.giteaURL, scope.authToken,
+				key.scope, key.org, key.user, key.repo, nil)
+			if err != nil {
+				return nil, err
+			}
+			return stats.QueuedJobs, nil
+		})
+		if err != nil {
+			// The groups' own polls report the failure
+			logger.Info("Failed to poll queued jobs", "scope", key.scope, "org", key.org, "user", key.user, "repo", key.repo, "error", err.Error())
+			if wait, throttled := rateLimitRequeue(err, interval); throttled {
+				delay = max(delay, wait)
+			} else if wait, open := circuitOpenRequeue(err, interval); open {
+				delay = max(delay, wait)
+			}
+			continue
+		}
+
+		snapshot := queuedJobIDs(jobs)
+		previous, polled := p.snapshots[key]
+		p.snapshots[key] = snapshot
+		if slices.Equal(snapshot, previous) || (!polled && len(snapshot) == 0) {
+			continue
+		}
+		logger.V(1).Info("Queued jobs changed, enqueueing RunnerGroups", "queuedJobs", len(snapshot), "runnerGroups", scope.runnerGroups)
+		for _, runnerGroup := range scope.runnerGroups {
+			if !r.EnqueueRunnerGroup(runnerGroup) {
+				logger.V(1).Info("Enqueue dropped, controller busy", "runnerGroup", runnerGroup)
+			}
+		}
+	}
+	for key := range p.snapshots {
+		if _, ok := scopes[key]; !ok {
+			delete(p.snapshots, key)
+		}
+	}
+	return delay
+}
+
+// polledScope is a scope of the instance and the RunnerGroups polling it, with the context and
+// auth token of the first of them to crawl it with
+type polledScope struct {
+	ctx          context.Context
+	authToken    string
+	runnerGroups []types.NamespacedName
+}
+
+// scopes returns the scopes the instance's RunnerGroups poll. Paused and draining groups don't
+// poll at all, and groups whose auth token can't be read or Gitea rejected, or whose scope it
+// rejected, are left to their own, slower polls.
+func (p *instancePoller) scopes(ctx context.Context, instance *giteav1alpha1.GiteaInstance) (map[queuedJobsKey]*polledScope, error) {
+	r := p.reconciler
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		return nil, err
+	}
+
+	instanceCtx := gitea.WithHostLimits(ctx, requestLimits(instance))
+	instanceCtx = gitea.WithInsecureSkipVerify(instanceCtx, insecureSkipVerify(instance))
+
+	scopes := make(map[queuedJobsKey]*polledScope)
+	for i := range runnerGroupList.Items {
+		runnerGroup := &runnerGroupList.Items[i]
+		if !sameGiteaURL(runnerGroup.Spec.GiteaURL, instance.Spec.URL) || !runnerGroup.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if _, paused := pauseReason(runnerGroup); paused || runnerGroup.Spec.Drain {
+			continue
+		}
+		if meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionTokenValid) ||
+			meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified) {
+			continue
+		}
+		groupCtx := withGroupProxy(instanceCtx, runnerGroup)
+		if runnerGroup.Spec.Sudo != "" {
+			groupCtx = gitea.WithSudo(groupCtx, runnerGroup.Spec.Sudo)
+		}
+		authToken, err := readAuthToken(groupCtx, r.Client, runnerGroup, r.AuthTokenSources)
+		if err != nil {
+			log.FromContext(ctx).V(1).Info("Skipping RunnerGroup without a readable auth token",
+				"runnerGroup", client.ObjectKeyFromObject(runnerGroup), "error", err.Error())
+			continue
+		}
+		key := newQueuedJobsKey(groupCtx, runnerGroup, authToken)
+		scope := scopes[key]
+		if scope == nil {
+			scope = &polledScope{ctx: groupCtx, authToken: authToken}
+			scopes[key] = scope
+		}
+		scope.runnerGroups = append(scope.runnerGroups, client.ObjectKeyFromObject(runnerGroup))
+	}
+	return scopes, nil
+}
+
+// queuedJobIDs returns the sorted IDs of the jobs
+func queuedJobIDs(jobs []gitea.ActionWorkflowJob) []int64 {
+	ids := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
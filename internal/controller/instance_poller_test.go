@@ -0,0 +1,208 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// queueGiteaClient returns the queued jobs set for each org and counts the crawls
+type queueGiteaClient struct {
+	fakeGiteaClient
+	mu     sync.Mutex
+	queues map[string][]gitea.ActionWorkflowJob
+	crawls map[string]int
+}
+
+func (c *queueGiteaClient) GetRunnerStats(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string, labels []string) (*gitea.RunnerStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.crawls[org]++
+	return &gitea.RunnerStats{QueuedJobs: c.queues[org]}, nil
+}
+
+var _ = Describe("Instance poller", func() {
+	ctx := context.Background()
+
+	It("should crawl each scope once and enqueue the RunnerGroups whose queue changed", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "poller-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "admin-token"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+
+		instance := &giteav1alpha1.GiteaInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "polled"},
+			Spec:       giteav1alpha1.GiteaInstanceSpec{URL: "https://polled.example.com"},
+		}
+		Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, instance)).To(Succeed()) })
+
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "token"}
+		for name, org := range map[string]string{"poller-linux": "myorg", "poller-arm": "myorg", "poller-other": "otherorg"} {
+			runnerGroup := &giteav1alpha1.RunnerGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: giteav1alpha1.RunnerGroupSpec{
+					GiteaURL:             "https://polled.example.com/",
+					Scope:                giteav1alpha1.RunnerGroupScopeOrg,
+					Org:                  org,
+					MaxActiveRunners:     1,
+					RegistrationTokenRef: &secretRef,
+					AuthTokenRef:         secretRef,
+				},
+			}
+			Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+		}
+
+		giteaClient := &queueGiteaClient{
+			queues: map[string][]gitea.ActionWorkflowJob{"myorg": {{ID: 1}}},
+			crawls: map[string]int{},
+		}
+		reconciler := &RunnerGroupReconciler{
+			Client:       k8sClient,
+			Scheme:       k8sClient.Scheme(),
+			GiteaClient:  giteaClient,
+			pollTriggers: make(chan event.GenericEvent, 10),
+		}
+		poller := &instancePoller{reconciler: reconciler, name: instance.Name, snapshots: make(map[queuedJobsKey][]int64)}
+		enqueued := func() []string {
+			var names []string
+			for len(reconciler.pollTriggers) > 0 {
+				names = append(names, (<-reconciler.pollTriggers).Object.GetName())
+			}
+			return names
+		}
+
+		Expect(poller.poll(ctx, time.Now())).To(Equal(pollInterval))
+		Expect(giteaClient.crawls).To(Equal(map[string]int{"myorg": 1, "otherorg": 1}))
+		Expect(enqueued()).To(ConsistOf("poller-linux", "poller-arm"))
+
+		// The groups' own polls reuse the crawl
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "poller-linux", Namespace: "default"}}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(runnerGroup), runnerGroup)).To(Succeed())
+		jobs, shared, err := reconciler.queuedJobs.get(ctx, newQueuedJobsKey(ctx, runnerGroup, "admin-token"), time.Now().Add(-pollInterval),
+			func() ([]gitea.ActionWorkflowJob, error) { return nil, nil })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shared).To(BeTrue())
+		Expect(jobs).To(HaveLen(1))
+
+		// Unchanged queues don't enqueue anyone
+		poller.poll(ctx, time.Now())
+		Expect(enqueued()).To(BeEmpty())
+
+		giteaClient.mu.Lock()
+		giteaClient.queues["otherorg"] = []gitea.ActionWorkflowJob{{ID: 2}}
+		giteaClient.mu.Unlock()
+		poller.poll(ctx, time.Now())
+		Expect(enqueued()).To(ConsistOf("poller-other"))
+		Expect(giteaClient.crawls).To(Equal(map[string]int{"myorg": 3, "otherorg": 3}))
+	})
+
+	It("should leave out groups that don't poll or whose token Gitea rejected", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "scoped-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "admin-token"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+		instance := &giteav1alpha1.GiteaInstance{Spec: giteav1alpha1.GiteaInstanceSpec{URL: "https://scoped.example.com"}}
+
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "token"}
+		newRunnerGroup := func(name string) *giteav1alpha1.RunnerGroup {
+			return &giteav1alpha1.RunnerGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: giteav1alpha1.RunnerGroupSpec{
+					GiteaURL:             instance.Spec.URL,
+					Scope:                giteav1alpha1.RunnerGroupScopeOrg,
+					Org:                  "myorg",
+					MaxActiveRunners:     1,
+					RegistrationTokenRef: &secretRef,
+					AuthTokenRef:         secretRef,
+				},
+			}
+		}
+		active := newRunnerGroup("scoped-active")
+		paused := newRunnerGroup("scoped-paused")
+		paused.Spec.Paused = true
+		annotated := newRunnerGroup("scoped-annotated")
+		annotated.Annotations = map[string]string{pausedAnnotation: "true"}
+		draining := newRunnerGroup("scoped-draining")
+		draining.Spec.Drain = true
+		rejected := newRunnerGroup("scoped-rejected")
+		for _, runnerGroup := range []*giteav1alpha1.RunnerGroup{active, paused, annotated, draining, rejected} {
+			Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+		}
+		meta.SetStatusCondition(&rejected.Status.Conditions, metav1.Condition{
+			Type: giteav1alpha1.ConditionTokenValid, Status: metav1.ConditionFalse, Reason: "Unauthorized"})
+		Expect(k8sClient.Status().Update(ctx, rejected)).To(Succeed())
+
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: &queueGiteaClient{}}
+		poller := &instancePoller{reconciler: reconciler, name: "scoped", snapshots: make(map[queuedJobsKey][]int64)}
+		scopes, err := poller.scopes(ctx, instance)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scopes).To(HaveLen(1))
+		for _, scope := range scopes {
+			Expect(scope.runnerGroups).To(ConsistOf(client.ObjectKeyFromObject(active)))
+		}
+	})
+
+	It("should not poll during maintenance windows", func() {
+		now := time.Now()
+		instance := &giteav1alpha1.GiteaInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "maintained"},
+			Spec: giteav1alpha1.GiteaInstanceSpec{
+				URL: "https://maintained.example.com",
+				MaintenanceWindows: []giteav1alpha1.MaintenanceWindow{{
+					Start: metav1.NewTime(now.Add(-time.Minute)),
+					End:   metav1.NewTime(now.Add(time.Second)),
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, instance)).To(Succeed()) })
+
+		giteaClient := &queueGiteaClient{crawls: map[string]int{}}
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: giteaClient}
+		poller := &instancePoller{reconciler: reconciler, name: instance.Name, snapshots: make(map[queuedJobsKey][]int64)}
+
+		Expect(poller.poll(ctx, now)).To(BeNumerically("<=", time.Second))
+		Expect(giteaClient.crawls).To(BeEmpty())
+	})
+})
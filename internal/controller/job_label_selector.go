@@ -0,0 +1,92 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"path"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// jobMatchesRunnerGroup reports whether a RunnerGroup serves a queued job: by its job label selector
+// if it has one, otherwise by whether its effective labels satisfy the job's labels under its
+// label matching policy
+func jobMatchesRunnerGroup(runnerGroup *giteav1alpha1.RunnerGroup, effectiveLabels []string, job gitea.ActionWorkflowJob) bool {
+	if runnerGroup.Spec.JobLabelSelector != nil {
+		return matchesJobLabelSelector(runnerGroup.Spec.JobLabelSelector, job.Labels)
+	}
+	if runnerGroup.Spec.LabelMatching != nil {
+		return jobLabelsMatchWithPolicy(runnerGroup.Spec.LabelMatching, job.Labels, effectiveLabels)
+	}
+	return gitea.JobMatchesLabels(job.Labels, effectiveLabels)
+}
+
+// filterJobsForRunnerGroup keeps the queued jobs the RunnerGroup serves
+func filterJobsForRunnerGroup(runnerGroup *giteav1alpha1.RunnerGroup, effectiveLabels []string, jobs []gitea.ActionWorkflowJob) []gitea.ActionWorkflowJob {
+	var matched []gitea.ActionWorkflowJob
+	for _, job := range jobs {
+		if jobMatchesRunnerGroup(runnerGroup, effectiveLabels, job) {
+			matched = append(matched, job)
+		}
+	}
+	return matched
+}
+
+// matchesJobLabelSelector reports whether a job's labels satisfy every requirement of the selector
+func matchesJobLabelSelector(selector *giteav1alpha1.JobLabelSelector, jobLabels []string) bool {
+	for _, requirement := range selector.MatchExpressions {
+		switch requirement.Operator {
+		case giteav1alpha1.JobLabelSelectorOpIn:
+			if !anyLabelMatches(jobLabels, requirement.Values) {
+				return false
+			}
+		case giteav1alpha1.JobLabelSelectorOpNotIn:
+			if anyLabelMatches(jobLabels, requirement.Values) {
+				return false
+			}
+		case giteav1alpha1.JobLabelSelectorOpExists:
+			for _, pattern := range requirement.Values {
+				if !anyLabelMatches(jobLabels, []string{pattern}) {
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// anyLabelMatches reports whether any label, ignoring its ":schema" suffix, matches any of the glob patterns
+func anyLabelMatches(labels, patterns []string) bool {
+	for _, label := range labels {
+		name := gitea.NormalizeLabel(label)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("matchesJobLabelSelector", func() {
+	selector := &giteav1alpha1.JobLabelSelector{MatchExpressions: []giteav1alpha1.JobLabelSelectorRequirement{
+		{Operator: giteav1alpha1.JobLabelSelectorOpIn, Values: []string{"ubuntu-*"}},
+		{Operator: giteav1alpha1.JobLabelSelectorOpNotIn, Values: []string{"gpu"}},
+	}}
+
+	DescribeTable("matching job labels",
+		func(jobLabels []string, expected bool) {
+			Expect(matchesJobLabelSelector(selector, jobLabels)).To(Equal(expected))
+		},
+		Entry("glob match", []string{"ubuntu-22.04"}, true),
+		Entry("glob match ignoring schema", []string{"ubuntu-latest:docker://node:16"}, true),
+		Entry("excluded label", []string{"ubuntu-22.04", "gpu"}, false),
+		Entry("no matching label", []string{"windows"}, false),
+	)
+
+	It("should require every Exists value", func() {
+		exists := &giteav1alpha1.JobLabelSelector{MatchExpressions: []giteav1alpha1.JobLabelSelectorRequirement{
+			{Operator: giteav1alpha1.JobLabelSelectorOpExists, Values: []string{"linux", "arm*"}},
+		}}
+		Expect(matchesJobLabelSelector(exists, []string{"linux", "arm64"})).To(BeTrue())
+		Expect(matchesJobLabelSelector(exists, []string{"linux", "x64"})).To(BeFalse())
+	})
+})
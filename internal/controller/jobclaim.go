@@ -0,0 +1,130 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// spawnRetryTimeout is how long a spawned runner gets to pick up its job before another
+// runner may be spawned for it
+const spawnRetryTimeout = 5 * time.Minute
+
+// runnerGroupNamespaceLabel records the namespace of the RunnerGroup holding a JobClaim
+const runnerGroupNamespaceLabel = "gitea.bpg.pw/runnergroup-namespace"
+
+// jobClaimName returns the name of the JobClaim for a job, unique per Gitea instance and job ID
+func jobClaimName(giteaURL string, jobID int64) string {
+	sum := sha256.Sum256([]byte(strings.TrimSuffix(giteaURL, "/")))
+	return "job-" + hex.EncodeToString(sum[:])[:12] + "-" + strconv.FormatInt(jobID, 10)
+}
+
+// claimJob takes the JobClaim for a queued job so no other RunnerGroup or operator replica
+// provisions a runner for it. It returns false if the job is already claimed and the claim
+// hasn't expired yet. Expired claims are taken over; concurrent takeovers are resolved by
+// the API server rejecting all but one update.
+func (r *RunnerGroupReconciler) claimJob(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobID int64) (bool, error) {
+	claim := &giteav1alpha1.JobClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: jobClaimName(runnerGroup.Spec.GiteaURL, jobID),
+			Labels: map[string]string{
+				runnerGroupNameLabel:      runnerGroup.Name,
+				runnerGroupNamespaceLabel: runnerGroup.Namespace,
+				managedByLabel:            "gitea-runner-operator",
+			},
+		},
+		Spec: giteav1alpha1.JobClaimSpec{
+			GiteaURL:             runnerGroup.Spec.GiteaURL,
+			JobID:                jobID,
+			RunnerGroupNamespace: runnerGroup.Namespace,
+			RunnerGroupName:      runnerGroup.Name,
+			ClaimedAt:            metav1.Now(),
+		},
+	}
+
+	err := r.Create(ctx, claim)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return false, fmt.Errorf("failed to create JobClaim: %w", err)
+	}
+
+	existing := &giteav1alpha1.JobClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: claim.Name}, existing); err != nil {
+		if errors.IsNotFound(err) {
+			// Released in the meantime; leave the job for the next poll
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get JobClaim: %w", err)
+	}
+	if time.Since(existing.Spec.ClaimedAt.Time) < spawnRetryTimeout {
+		return false, nil
+	}
+
+	existing.Labels = claim.Labels
+	existing.Spec = claim.Spec
+	if err := r.Update(ctx, existing); err != nil {
+		if errors.IsConflict(err) || errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to take over JobClaim: %w", err)
+	}
+	return true, nil
+}
+
+// releaseJobClaims deletes the RunnerGroup's JobClaims for jobs that are no longer queued.
+// With keep set to nil, all of the group's claims are released.
+func (r *RunnerGroupReconciler) releaseJobClaims(ctx context.Context, namespacedName types.NamespacedName, keep map[int64]bool) error {
+	claimList := &giteav1alpha1.JobClaimList{}
+	if err := r.List(ctx, claimList, client.MatchingLabels{
+		runnerGroupNameLabel:      namespacedName.Name,
+		runnerGroupNamespaceLabel: namespacedName.Namespace,
+	}); err != nil {
+		return fmt.Errorf("failed to list JobClaims: %w", err)
+	}
+
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+		if keep[claim.Spec.JobID] {
+			continue
+		}
+		if err := r.Delete(ctx, claim); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete JobClaim: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("JobClaim", func() {
+	ctx := context.Background()
+	reconciler := &RunnerGroupReconciler{}
+
+	newGroup := func(name string) *giteav1alpha1.RunnerGroup {
+		return &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       giteav1alpha1.RunnerGroupSpec{GiteaURL: "https://gitea.example.com"},
+		}
+	}
+
+	BeforeEach(func() {
+		reconciler.Client = k8sClient
+		reconciler.Scheme = k8sClient.Scheme()
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &giteav1alpha1.JobClaim{})).To(Succeed())
+	})
+
+	It("should let only one RunnerGroup claim a job", func() {
+		claimed, err := reconciler.claimJob(ctx, newGroup("first"), 42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeTrue())
+
+		claimed, err = reconciler.claimJob(ctx, newGroup("second"), 42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeFalse())
+	})
+
+	It("should take over an expired claim", func() {
+		claimed, err := reconciler.claimJob(ctx, newGroup("first"), 43)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeTrue())
+
+		claim := &giteav1alpha1.JobClaim{}
+		name := types.NamespacedName{Name: jobClaimName("https://gitea.example.com/", 43)}
+		Expect(k8sClient.Get(ctx, name, claim)).To(Succeed())
+		claim.Spec.ClaimedAt = metav1.NewTime(time.Now().Add(-2 * spawnRetryTimeout))
+		Expect(k8sClient.Update(ctx, claim)).To(Succeed())
+
+		claimed, err = reconciler.claimJob(ctx, newGroup("second"), 43)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeTrue())
+		Expect(k8sClient.Get(ctx, name, claim)).To(Succeed())
+		Expect(claim.Spec.RunnerGroupName).To(Equal("second"))
+	})
+
+	It("should release claims for jobs that are no longer queued", func() {
+		group := newGroup("first")
+		for _, jobID := range []int64{44, 45} {
+			claimed, err := reconciler.claimJob(ctx, group, jobID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+		}
+
+		groupName := types.NamespacedName{Name: group.Name, Namespace: group.Namespace}
+		Expect(reconciler.releaseJobClaims(ctx, groupName, map[int64]bool{44: true})).To(Succeed())
+
+		claims := &giteav1alpha1.JobClaimList{}
+		Expect(k8sClient.List(ctx, claims)).To(Succeed())
+		Expect(claims.Items).To(HaveLen(1))
+		Expect(claims.Items[0].Spec.JobID).To(Equal(int64(44)))
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// canonicalLabel reduces a label to the name it is compared by under the matching policy:
+// the label name without its ":schema" suffix, lowercased if case-insensitive, and resolved
+// to the runner label it is an alias of. Aliases are looked up in the order of their runner
+// labels, so a label listed under several resolves to the same one every time.
+func canonicalLabel(policy *giteav1alpha1.LabelMatchingSpec, label string) string {
+	name := gitea.NormalizeLabel(label)
+	if policy.CaseInsensitive {
+		name = strings.ToLower(name)
+	}
+	for _, canonical := range slices.Sorted(maps.Keys(policy.Aliases)) {
+		aliases := policy.Aliases[canonical]
+		key := canonical
+		if policy.CaseInsensitive {
+			key = strings.ToLower(key)
+		}
+		if name == key {
+			return key
+		}
+		for _, alias := range aliases {
+			if policy.CaseInsensitive {
+				alias = strings.ToLower(alias)
+			}
+			if name == alias {
+				return key
+			}
+		}
+	}
+	return name
+}
+
+// jobLabelsMatchWithPolicy reports whether the runner labels satisfy every job label under the matching policy
+func jobLabelsMatchWithPolicy(policy *giteav1alpha1.LabelMatchingSpec, jobLabels, runnerLabels []string) bool {
+	for _, jobLabel := range jobLabels {
+		if matchingRunnerLabel(policy, jobLabel, runnerLabels) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// matchingRunnerLabel returns the runner label a job label resolves to, or "" if none does
+func matchingRunnerLabel(policy *giteav1alpha1.LabelMatchingSpec, jobLabel string, runnerLabels []string) string {
+	want := canonicalLabel(policy, jobLabel)
+	for _, runnerLabel := range runnerLabels {
+		if canonicalLabel(policy, runnerLabel) == want {
+			return runnerLabel
+		}
+	}
+	return ""
+}
+
+// runnerLabelsForJob returns the labels a runner spawned for a job registers with. Gitea assigns jobs
+// by exact label name, so every job label only matched through the policy is added in the job's own
+// spelling, keeping the schema of the runner label it resolved to.
+func runnerLabelsForJob(policy *giteav1alpha1.LabelMatchingSpec, runnerLabels, jobLabels []string) []string {
+	labels := append([]string(nil), runnerLabels...)
+	for _, jobLabel := range jobLabels {
+		runnerLabel := matchingRunnerLabel(policy, jobLabel, runnerLabels)
+		if runnerLabel == "" || gitea.JobMatchesLabels([]string{jobLabel}, labels) {
+			continue
+		}
+		name := gitea.NormalizeLabel(jobLabel)
+		if _, schema, ok := strings.Cut(strings.TrimSpace(runnerLabel), ":"); ok {
+			name += ":" + schema
+		}
+		labels = append(labels, name)
+	}
+	return labels
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Label matching policy", func() {
+	policy := &giteav1alpha1.LabelMatchingSpec{
+		CaseInsensitive: true,
+		Aliases:         map[string][]string{"ubuntu-latest": {"ubuntu-22.04"}},
+	}
+	runnerLabels := []string{"ubuntu-latest:docker://node:20-bookworm", "linux"}
+
+	DescribeTable("matching job labels",
+		func(jobLabels []string, expected bool) {
+			Expect(jobLabelsMatchWithPolicy(policy, jobLabels, runnerLabels)).To(Equal(expected))
+		},
+		Entry("exact label", []string{"ubuntu-latest"}, true),
+		Entry("different case", []string{"Ubuntu-Latest", "LINUX"}, true),
+		Entry("alias", []string{"ubuntu-22.04"}, true),
+		Entry("alias in different case", []string{"Ubuntu-22.04:docker://node:16"}, true),
+		Entry("unknown label", []string{"ubuntu-22.04", "gpu"}, false),
+	)
+
+	It("should compare case-sensitively unless enabled", func() {
+		strict := &giteav1alpha1.LabelMatchingSpec{Aliases: policy.Aliases}
+		Expect(jobLabelsMatchWithPolicy(strict, []string{"ubuntu-22.04"}, runnerLabels)).To(BeTrue())
+		Expect(jobLabelsMatchWithPolicy(strict, []string{"Ubuntu-22.04"}, runnerLabels)).To(BeFalse())
+	})
+
+	It("should register the job's spelling with the matched label's schema", func() {
+		labels := runnerLabelsForJob(policy, runnerLabels, []string{"ubuntu-22.04", "Linux", "linux"})
+		Expect(labels).To(Equal([]string{
+			"ubuntu-latest:docker://node:20-bookworm",
+			"linux",
+			"ubuntu-22.04:docker://node:20-bookworm",
+			"Linux",
+		}))
+	})
+
+	It("should leave the runner labels alone for exactly matching jobs", func() {
+		Expect(runnerLabelsForJob(policy, runnerLabels, []string{"ubuntu-latest"})).To(Equal(runnerLabels))
+	})
+
+	It("should resolve a label aliased to several runner labels the same way every time", func() {
+		ambiguous := &giteav1alpha1.LabelMatchingSpec{Aliases: map[string][]string{
+			"ubuntu-latest": {"ubuntu"},
+			"debian":        {"ubuntu"},
+			"linux":         {"ubuntu"},
+		}}
+		for range 20 {
+			Expect(canonicalLabel(ambiguous, "ubuntu")).To(Equal("debian"))
+		}
+	})
+})
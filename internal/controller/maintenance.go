@@ -0,0 +1,79 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// giteaInstanceForURL returns the GiteaInstance configured for a Gitea base URL, if any
+func giteaInstanceForURL(instances []giteav1alpha1.GiteaInstance, giteaURL string) *giteav1alpha1.GiteaInstance {
+	for i := range instances {
+		if sameGiteaURL(instances[i].Spec.URL, giteaURL) {
+			return &instances[i]
+		}
+	}
+	return nil
+}
+
+// activeMaintenanceWindow returns the instance's maintenance window covering now, if any
+func activeMaintenanceWindow(instance *giteav1alpha1.GiteaInstance, now time.Time) *giteav1alpha1.MaintenanceWindow {
+	if instance == nil {
+		return nil
+	}
+	for i, window := range instance.Spec.MaintenanceWindows {
+		if !now.Before(window.Start.Time) && now.Before(window.End.Time) {
+			return &instance.Spec.MaintenanceWindows[i]
+		}
+	}
+	return nil
+}
+
+// runnerGroupsForGiteaInstance maps a GiteaInstance to the RunnerGroups using it
+func (r *RunnerGroupReconciler) runnerGroupsForGiteaInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	instance, ok := obj.(*giteav1alpha1.GiteaInstance)
+	if !ok {
+		return nil
+	}
+
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list RunnerGroups for GiteaInstance", "giteaInstance", instance.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, runnerGroup := range runnerGroupList.Items {
+		if sameGiteaURL(runnerGroup.Spec.GiteaURL, instance.Spec.URL) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&runnerGroup)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("activeMaintenanceWindow", func() {
+	now := time.Now()
+	instances := []giteav1alpha1.GiteaInstance{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       giteav1alpha1.GiteaInstanceSpec{URL: "https://git.example.org"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "main"},
+			Spec: giteav1alpha1.GiteaInstanceSpec{
+				URL: "https://gitea.example.com/",
+				MaintenanceWindows: []giteav1alpha1.MaintenanceWindow{
+					{Start: metav1.NewTime(now.Add(-time.Hour)), End: metav1.NewTime(now.Add(-30 * time.Minute))},
+					{Start: metav1.NewTime(now.Add(-time.Minute)), End: metav1.NewTime(now.Add(time.Hour)), Reason: "upgrade"},
+				},
+			},
+		},
+	}
+
+	It("should find the instance by URL, ignoring a trailing slash", func() {
+		instance := giteaInstanceForURL(instances, "https://gitea.example.com")
+		Expect(instance).NotTo(BeNil())
+		Expect(instance.Name).To(Equal("main"))
+		Expect(giteaInstanceForURL(instances, "https://unknown.example.com")).To(BeNil())
+	})
+
+	It("should return the window covering now", func() {
+		window := activeMaintenanceWindow(&instances[1], now)
+		Expect(window).NotTo(BeNil())
+		Expect(window.Reason).To(Equal("upgrade"))
+		Expect(activeMaintenanceWindow(&instances[1], now.Add(2*time.Hour))).To(BeNil())
+		Expect(activeMaintenanceWindow(&instances[0], now)).To(BeNil())
+		Expect(activeMaintenanceWindow(nil, now)).To(BeNil())
+	})
+})
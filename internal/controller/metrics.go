@@ -0,0 +1,343 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var (
+	// labelCapacity is the supply side of capacity planning: how many concurrent runners
+	// advertising a label each RunnerGroup can run
+	labelCapacity = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_label_capacity",
+		Help: "Maximum concurrent runners of a RunnerGroup that advertise the label.",
+	}, []string{"namespace", "runnergroup", "label"})
+
+	// labelQueuedJobs is the demand side: how many matching queued jobs request a label
+	labelQueuedJobs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_label_queued_jobs",
+		Help: "Queued jobs requesting the label that matched a RunnerGroup in its last poll.",
+	}, []string{"namespace", "runnergroup", "label"})
+
+	// oldestQueuedJobAge is how long the longest-waiting matching queued job has waited
+	oldestQueuedJobAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_oldest_queued_job_seconds",
+		Help: "Age of the oldest queued job matching a RunnerGroup in its last poll.",
+	}, []string{"namespace", "runnergroup"})
+
+	// groupRunners is how many of a RunnerGroup's runner Jobs are in each phase
+	groupRunners = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_runners",
+		Help: "Unfinished runner Jobs of a RunnerGroup by phase (pending, running, failed).",
+	}, []string{"namespace", "runnergroup", "phase"})
+
+	// giteaUnreachable is how long polling Gitea has been failing for a RunnerGroup
+	giteaUnreachable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_gitea_unreachable_seconds",
+		Help: "How long polling Gitea has been failing for a RunnerGroup, 0 while it succeeds.",
+	}, []string{"namespace", "runnergroup"})
+
+	// pollStuckGroups flags RunnerGroups whose last Gitea poll is overdue by several intervals
+	pollStuckGroups = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_poll_stuck",
+		Help: "1 while a RunnerGroup has not polled Gitea for several poll intervals, 0 otherwise.",
+	}, []string{"namespace", "runnergroup"})
+
+	// pollWatchdogRequeues counts the requeues of stuck RunnerGroups by the poll watchdog
+	pollWatchdogRequeues = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_poll_watchdog_requeues_total",
+		Help: "Times the poll watchdog requeued a RunnerGroup whose polls had stopped.",
+	}, []string{"namespace", "runnergroup"})
+
+	// groupActiveRunners is how many runners of a RunnerGroup count against its maxActiveRunners
+	groupActiveRunners = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_active_runners",
+		Help: "Active runners of a RunnerGroup, counted against its maxActiveRunners.",
+	}, []string{"namespace", "runnergroup"})
+
+	// groupQueuedJobs is how many queued jobs matched a RunnerGroup in its last poll
+	groupQueuedJobs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_queued_jobs",
+		Help: "Queued jobs matching a RunnerGroup in its last poll.",
+	}, []string{"namespace", "runnergroup"})
+
+	// groupAvailableSlots is how many more runners a RunnerGroup could start before reaching maxActiveRunners
+	groupAvailableSlots = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_available_slots",
+		Help: "Runners a RunnerGroup can still start before reaching its maxActiveRunners.",
+	}, []string{"namespace", "runnergroup"})
+
+	// groupSaturation is the share of a RunnerGroup's capacity its active runners take
+	groupSaturation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_saturation",
+		Help: "Active runners of a RunnerGroup divided by its maxActiveRunners, 1 when it has no capacity.",
+	}, []string{"namespace", "runnergroup"})
+
+	// oldestWaitingJobAge is how long the longest-waiting matching queued job without a runner has waited
+	oldestWaitingJobAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_oldest_unmatched_queued_job_seconds",
+		Help: "Age of the oldest queued job matching a RunnerGroup that had no runner after its last poll.",
+	}, []string{"namespace", "runnergroup"})
+
+	// runnersSpawned counts the runners a RunnerGroup created for queued jobs
+	runnersSpawned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_runners_spawned_total",
+		Help: "Runners a RunnerGroup spawned for queued jobs.",
+	}, []string{"namespace", "runnergroup"})
+
+	// runnerSeconds counts how long a RunnerGroup's runners ran, to attribute their cost
+	runnerSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_runner_seconds_total",
+		Help: "Run time of a RunnerGroup's finished runner Jobs, from their start until they completed, failed or were deleted.",
+	}, []string{"namespace", "runnergroup"})
+
+	// runnersPreempted counts the runners replaced because their spot node was reclaimed
+	runnersPreempted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_runners_preempted_total",
+		Help: "Runners of a RunnerGroup replaced because their spot node was reclaimed.",
+	}, []string{"namespace", "runnergroup"})
+
+	// scaleUps counts the polls after which a RunnerGroup spawned at least one runner
+	scaleUps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_scale_ups_total",
+		Help: "Scale-ups of a RunnerGroup, i.e. polls after which it spawned at least one runner.",
+	}, []string{"namespace", "runnergroup"})
+
+	// reconcileErrors counts the reconciles of a RunnerGroup that returned an error
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_reconcile_errors_total",
+		Help: "Reconciles of a RunnerGroup that failed and were retried.",
+	}, []string{"namespace", "runnergroup"})
+
+	// timeToRunner is how long queued jobs waited in Gitea before their runner was spawned
+	timeToRunner = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gitea_runner_group_time_to_runner_seconds",
+		Help:    "Time from a job being queued in Gitea to a RunnerGroup spawning its runner.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"namespace", "runnergroup"})
+
+	// backlogExceeded flags RunnerGroups whose queue has outnumbered their capacity for too long
+	backlogExceeded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitea_runner_group_backlog_exceeded",
+		Help: "1 while a RunnerGroup's matching queued jobs have outnumbered maxActiveRunners for longer than maxBacklogDuration, 0 otherwise.",
+	}, []string{"namespace", "runnergroup"})
+
+	// sharedPolls counts polls answered by a crawl of the same scope another RunnerGroup started
+	sharedPolls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_shared_polls_total",
+		Help: "Polls of a RunnerGroup that reused the queued jobs another RunnerGroup of the same Gitea scope fetched.",
+	}, []string{"namespace", "runnergroup"})
+
+	// staleRunnersDeleted counts runners deleted from Gitea after staying offline too long
+	staleRunnersDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "gitea_runner_stale_runners_deleted_total",
+		Help: "Runners the operator registered that were deleted from Gitea after staying offline for staleRunnerAge.",
+	})
+
+	// leader is 1 on the replica that is the leader
+	leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gitea_runner_operator_leader",
+		Help: "1 on the operator replica that is the leader and runs the controllers, 0 on standby replicas.",
+	})
+
+	// orphanedJobs counts runner Jobs found without their Runner or RunnerGroup, by what was done with them
+	orphanedJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_orphaned_jobs_total",
+		Help: "Runner Jobs found without their Runner or RunnerGroup, by action (adopted, deleted or kept).",
+	}, []string{"action"})
+
+	// webhookMissedJobs counts queued jobs found by fallback polls instead of webhook deliveries
+	webhookMissedJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_runner_group_webhook_missed_jobs_total",
+		Help: "Queued jobs a RunnerGroup spawned runners for after a fallback poll, because no webhook delivery announced them.",
+	}, []string{"namespace", "runnergroup"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(labelCapacity, labelQueuedJobs, oldestQueuedJobAge, groupRunners, giteaUnreachable,
+		pollStuckGroups, pollWatchdogRequeues, webhookMissedJobs, groupActiveRunners, groupQueuedJobs, groupAvailableSlots,
+		groupSaturation, oldestWaitingJobAge, runnersSpawned, runnerSeconds, runnersPreempted, scaleUps,
+		reconcileErrors, timeToRunner, backlogExceeded, sharedPolls, staleRunnersDeleted,
+		orphanedJobs, leader)
+}
+
+// recordRunnerGroupMetrics publishes the runner phases and Gitea reachability of a RunnerGroup
+func recordRunnerGroupMetrics(namespacedName types.NamespacedName, status *giteav1alpha1.RunnerGroupStatus, now time.Time) {
+	groupRunners.WithLabelValues(namespacedName.Namespace, namespacedName.Name, "pending").Set(float64(status.PendingRunners))
+	groupRunners.WithLabelValues(namespacedName.Namespace, namespacedName.Name, "running").Set(float64(status.RunningRunners))
+	groupRunners.WithLabelValues(namespacedName.Namespace, namespacedName.Name, "failed").Set(float64(status.FailedRunners))
+
+	var unreachable time.Duration
+	if status.GiteaUnreachableSince != nil {
+		unreachable = now.Sub(status.GiteaUnreachableSince.Time)
+	}
+	giteaUnreachable.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(unreachable.Seconds())
+	groupActiveRunners.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(float64(status.ActiveRunners))
+}
+
+// recordCapacityMetrics publishes how much of the RunnerGroup's capacity is free and taken
+func recordCapacityMetrics(namespacedName types.NamespacedName, activeRunners, maxActiveRunners int) {
+	groupAvailableSlots.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(float64(max(maxActiveRunners-activeRunners, 0)))
+	groupSaturation.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(saturation(activeRunners, maxActiveRunners))
+}
+
+// recordWaitingJobs publishes how long the oldest matching queued job still without a runner has waited
+func recordWaitingJobs(namespacedName types.NamespacedName, jobs []gitea.ActionWorkflowJob, deferredJobs map[int64]bool, spawnedJobs *sync.Map, now time.Time) {
+	var waiting []gitea.ActionWorkflowJob
+	for _, job := range jobs {
+		if jobWaiting(job, deferredJobs, spawnedJobs, now) {
+			waiting = append(waiting, job)
+		}
+	}
+	oldestWaitingJobAge.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(oldestQueuedJobWait(waiting, now).Seconds())
+}
+
+// recordRunnerSpawned counts a runner spawned for the job and how long the job waited for it
+func recordRunnerSpawned(namespacedName types.NamespacedName, job gitea.ActionWorkflowJob, now time.Time) {
+	runnersSpawned.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Inc()
+	if !job.CreatedAt.IsZero() {
+		timeToRunner.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Observe(now.Sub(job.CreatedAt).Seconds())
+	}
+}
+
+// recordPollStuck publishes whether the RunnerGroup's polls have stopped
+func recordPollStuck(namespacedName types.NamespacedName, stuck bool) {
+	value := 0.0
+	if stuck {
+		value = 1
+	}
+	pollStuckGroups.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(value)
+}
+
+// recordBacklogExceeded publishes whether the RunnerGroup's backlog has lasted too long
+func recordBacklogExceeded(namespacedName types.NamespacedName, exceeded bool) {
+	value := 0.0
+	if exceeded {
+		value = 1
+	}
+	backlogExceeded.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(value)
+}
+
+// recordLabelCapacity publishes the RunnerGroup's capacity for each of its runner labels
+func recordLabelCapacity(namespacedName types.NamespacedName, runnerLabels []string, capacity int) {
+	labelCapacity.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	for _, label := range labelNames(runnerLabels) {
+		labelCapacity.WithLabelValues(namespacedName.Namespace, namespacedName.Name, label).Set(float64(capacity))
+	}
+}
+
+// recordQueueMetrics publishes how many of the RunnerGroup's queued jobs request each label
+// and how long the oldest of them has waited
+func recordQueueMetrics(namespacedName types.NamespacedName, jobs []gitea.ActionWorkflowJob, now time.Time) {
+	labelQueuedJobs.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	for label, count := range queuedJobsByLabel(jobs) {
+		labelQueuedJobs.WithLabelValues(namespacedName.Namespace, namespacedName.Name, label).Set(float64(count))
+	}
+	oldestQueuedJobAge.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(oldestQueuedJobWait(jobs, now).Seconds())
+	groupQueuedJobs.WithLabelValues(namespacedName.Namespace, namespacedName.Name).Set(float64(len(jobs)))
+}
+
+// forgetRunnerGroupMetrics drops the series of a deleted RunnerGroup
+func forgetRunnerGroupMetrics(namespacedName types.NamespacedName) {
+	labelCapacity.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	labelQueuedJobs.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	oldestQueuedJobAge.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	groupRunners.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	giteaUnreachable.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	pollStuckGroups.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	pollWatchdogRequeues.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	webhookMissedJobs.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	groupActiveRunners.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	groupQueuedJobs.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	groupAvailableSlots.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	groupSaturation.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	oldestWaitingJobAge.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	runnersSpawned.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	runnerSeconds.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	runnersPreempted.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	scaleUps.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	reconcileErrors.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	timeToRunner.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	backlogExceeded.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+	sharedPolls.DeletePartialMatch(runnerGroupMetricLabels(namespacedName))
+}
+
+func runnerGroupMetricLabels(namespacedName types.NamespacedName) prometheus.Labels {
+	return prometheus.Labels{"namespace": namespacedName.Namespace, "runnergroup": namespacedName.Name}
+}
+
+// labelNames returns the distinct label names, without their ":schema" suffix
+func labelNames(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	var names []string
+	for _, label := range labels {
+		name := gitea.NormalizeLabel(label)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// queuedJobsByLabel counts the queued jobs requesting each label name; a job counts once per label
+func queuedJobsByLabel(jobs []gitea.ActionWorkflowJob) map[string]int {
+	counts := make(map[string]int)
+	for _, job := range jobs {
+		for _, label := range labelNames(job.Labels) {
+			counts[label]++
+		}
+	}
+	return counts
+}
+
+// oldestQueuedJobWait is how long the longest-waiting job has been queued, or 0 without jobs
+func oldestQueuedJobWait(jobs []gitea.ActionWorkflowJob, now time.Time) time.Duration {
+	var oldest time.Duration
+	for _, job := range jobs {
+		if job.CreatedAt.IsZero() {
+			continue
+		}
+		oldest = max(oldest, now.Sub(job.CreatedAt))
+	}
+	return oldest
+}
+
+// saturation is the share of the capacity the active runners take; a group without capacity
+// counts as saturated
+func saturation(activeRunners, maxActiveRunners int) float64 {
+	if maxActiveRunners <= 0 {
+		return 1
+	}
+	return float64(activeRunners) / float64(maxActiveRunners)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Label metrics", func() {
+	It("should report each label name once", func() {
+		Expect(labelNames([]string{"ubuntu-latest:docker://node:20", "linux", " linux ", "ubuntu-latest"})).
+			To(Equal([]string{"ubuntu-latest", "linux"}))
+	})
+
+	It("should count queued jobs per requested label", func() {
+		jobs := []gitea.ActionWorkflowJob{
+			{ID: 1, Labels: []string{"ubuntu-latest", "linux"}},
+			{ID: 2, Labels: []string{"ubuntu-latest:docker://node:20"}},
+			{ID: 3, Labels: []string{"windows", "windows"}},
+		}
+		Expect(queuedJobsByLabel(jobs)).To(Equal(map[string]int{"ubuntu-latest": 2, "linux": 1, "windows": 1}))
+	})
+})
+
+var _ = Describe("RunnerGroup metrics", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "metrics-group"}
+
+	AfterEach(func() {
+		forgetRunnerGroupMetrics(key)
+	})
+
+	It("should count spawned runners and how long their jobs waited", func() {
+		now := time.Now()
+		recordRunnerSpawned(key, gitea.ActionWorkflowJob{ID: 1, CreatedAt: now.Add(-30 * time.Second)}, now)
+		recordRunnerSpawned(key, gitea.ActionWorkflowJob{ID: 2}, now)
+
+		Expect(testutil.ToFloat64(runnersSpawned.WithLabelValues(key.Namespace, key.Name))).To(Equal(2.0))
+		// A job without a creation time has no known wait
+		Expect(testutil.CollectAndCount(timeToRunner, "gitea_runner_group_time_to_runner_seconds")).To(Equal(1))
+	})
+
+	It("should publish the active runners and queued jobs", func() {
+		recordRunnerGroupMetrics(key, &giteav1alpha1.RunnerGroupStatus{ActiveRunners: 3}, time.Now())
+		recordQueueMetrics(key, []gitea.ActionWorkflowJob{{ID: 1}, {ID: 2}}, time.Now())
+
+		Expect(testutil.ToFloat64(groupActiveRunners.WithLabelValues(key.Namespace, key.Name))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(groupQueuedJobs.WithLabelValues(key.Namespace, key.Name))).To(Equal(2.0))
+	})
+
+	It("should publish the free slots and saturation of the group", func() {
+		recordCapacityMetrics(key, 3, 4)
+
+		Expect(testutil.ToFloat64(groupAvailableSlots.WithLabelValues(key.Namespace, key.Name))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(groupSaturation.WithLabelValues(key.Namespace, key.Name))).To(Equal(0.75))
+
+		// Runners left over from a lowered maxActiveRunners don't make the free slots negative
+		recordCapacityMetrics(key, 5, 4)
+		Expect(testutil.ToFloat64(groupAvailableSlots.WithLabelValues(key.Namespace, key.Name))).To(Equal(0.0))
+
+		// A group scheduled down to no capacity is saturated
+		recordCapacityMetrics(key, 0, 0)
+		Expect(testutil.ToFloat64(groupSaturation.WithLabelValues(key.Namespace, key.Name))).To(Equal(1.0))
+	})
+
+	It("should only age the queued jobs still without a runner", func() {
+		now := time.Now()
+		var spawnedJobs sync.Map
+		spawnedJobs.Store(int64(1), now)
+		jobs := []gitea.ActionWorkflowJob{
+			{ID: 1, CreatedAt: now.Add(-10 * time.Minute)},
+			{ID: 2, CreatedAt: now.Add(-5 * time.Minute)},
+			{ID: 3, CreatedAt: now.Add(-time.Minute)},
+		}
+		recordWaitingJobs(key, jobs, map[int64]bool{2: true}, &spawnedJobs, now)
+
+		Expect(testutil.ToFloat64(oldestWaitingJobAge.WithLabelValues(key.Namespace, key.Name))).To(Equal(60.0))
+	})
+
+	It("should drop the series of a deleted RunnerGroup", func() {
+		recordRunnerSpawned(key, gitea.ActionWorkflowJob{ID: 1, CreatedAt: time.Now()}, time.Now())
+		forgetRunnerGroupMetrics(key)
+
+		Expect(testutil.CollectAndCount(runnersSpawned, "gitea_runner_group_runners_spawned_total")).To(Equal(0))
+		Expect(testutil.CollectAndCount(timeToRunner, "gitea_runner_group_time_to_runner_seconds")).To(Equal(0))
+	})
+})
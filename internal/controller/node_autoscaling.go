@@ -0,0 +1,133 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// unschedulableGracePeriod is how long a runner pod may be unschedulable before the group reports
+// it, leaving the scheduler time to preempt or place it
+const unschedulableGracePeriod = 30 * time.Second
+
+// nodeAutoscalerAnnotations keep each autoscaler from removing the node of a running runner
+var nodeAutoscalerAnnotations = map[giteav1alpha1.NodeAutoscaler]map[string]string{
+	giteav1alpha1.NodeAutoscalerClusterAutoscaler: {"cluster-autoscaler.kubernetes.io/safe-to-evict": "false"},
+	giteav1alpha1.NodeAutoscalerKarpenter:         {"karpenter.sh/do-not-disrupt": "true"},
+}
+
+// applyNodeAutoscaling stamps the runner pods with the autoscaler's annotations and the group's pod
+// annotations and labels, and sets the runner container's resources
+func applyNodeAutoscaling(job *batchv1.Job, nodeAutoscaling *giteav1alpha1.NodeAutoscalingSpec) {
+	if nodeAutoscaling == nil {
+		return
+	}
+	template := &job.Spec.Template
+	for _, annotations := range []map[string]string{nodeAutoscalerAnnotations[nodeAutoscaling.Autoscaler], nodeAutoscaling.PodAnnotations} {
+		for key, value := range annotations {
+			metav1.SetMetaDataAnnotation(&template.ObjectMeta, key, value)
+		}
+	}
+	for key, value := range nodeAutoscaling.PodLabels {
+		if _, set := template.Labels[key]; !set {
+			template.Labels[key] = value
+		}
+	}
+	if nodeAutoscaling.Resources == nil {
+		return
+	}
+	for i := range template.Spec.Containers {
+		if template.Spec.Containers[i].Name == runnerContainerName {
+			template.Spec.Containers[i].Resources = *nodeAutoscaling.Resources.DeepCopy()
+		}
+	}
+}
+
+// unschedulableRunners counts the runner pods the scheduler has found no node for since longer
+// than the grace period, and returns the scheduler's message for the longest-waiting one
+func unschedulableRunners(pods []corev1.Pod, now time.Time) (int, string) {
+	count := 0
+	var message string
+	var since time.Time
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodPending || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionFalse ||
+				condition.Reason != corev1.PodReasonUnschedulable {
+				continue
+			}
+			if now.Sub(condition.LastTransitionTime.Time) < unschedulableGracePeriod {
+				continue
+			}
+			count++
+			if message == "" || condition.LastTransitionTime.Time.Before(since) {
+				message, since = condition.Message, condition.LastTransitionTime.Time
+			}
+		}
+	}
+	return count, message
+}
+
+// updateRunnersUnschedulable sets the RunnersUnschedulable condition while runner pods wait for
+// nodes, so it's clear jobs are held up by cluster capacity rather than the operator
+func (r *RunnerGroupReconciler) updateRunnersUnschedulable(runnerGroup *giteav1alpha1.RunnerGroup, pods []corev1.Pod, now time.Time) {
+	count, schedulerMessage := unschedulableRunners(pods, now)
+	if count == 0 {
+		if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionRunnersUnschedulable) {
+			meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+				Type:               giteav1alpha1.ConditionRunnersUnschedulable,
+				Status:             metav1.ConditionFalse,
+				Reason:             "RunnersScheduled",
+				Message:            "All runner pods found a node",
+				ObservedGeneration: runnerGroup.Generation,
+			})
+		}
+		return
+	}
+
+	message := fmt.Sprintf("%d runner pods are waiting for a node", count)
+	if schedulerMessage != "" {
+		message += ": " + schedulerMessage
+	}
+	if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionRunnersUnschedulable) && r.Recorder != nil {
+		r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "RunnersUnschedulable", message)
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionRunnersUnschedulable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "WaitingForNodes",
+		Message:            message,
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
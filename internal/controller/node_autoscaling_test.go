@@ -0,0 +1,116 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Node autoscaling", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	unschedulableFor := func(name string, waited time.Duration, message string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionFalse,
+					Reason:             corev1.PodReasonUnschedulable,
+					Message:            message,
+					LastTransitionTime: metav1.Time{Time: now.Add(-waited)},
+				}},
+			},
+		}
+	}
+
+	It("should stamp runner pods with autoscaler hints and resources", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				NodeAutoscaling: &giteav1alpha1.NodeAutoscalingSpec{
+					Autoscaler:     giteav1alpha1.NodeAutoscalerKarpenter,
+					PodAnnotations: map[string]string{"example.com/pool": "ci"},
+					PodLabels:      map[string]string{"workload": "ci", managedByLabel: "someone-else"},
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					},
+				},
+			},
+		}
+		runner := &giteav1alpha1.Runner{ObjectMeta: metav1.ObjectMeta{Name: "team-a-abc12", Namespace: "default"}}
+
+		template := constructRunnerJob(runnerGroup, runner, "token").Spec.Template
+		Expect(template.Annotations).To(HaveKeyWithValue("karpenter.sh/do-not-disrupt", "true"))
+		Expect(template.Annotations).To(HaveKeyWithValue("example.com/pool", "ci"))
+		Expect(template.Labels).To(HaveKeyWithValue("workload", "ci"))
+		Expect(template.Labels).To(HaveKeyWithValue(managedByLabel, "gitea-runner-operator"))
+		Expect(template.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("2"))
+	})
+
+	It("should only count pods unschedulable past the grace period", func() {
+		pods := []corev1.Pod{
+			unschedulableFor("fresh", 5*time.Second, "0/3 nodes are available"),
+			unschedulableFor("newer", time.Minute, "0/3 nodes are available: 3 Insufficient memory"),
+			unschedulableFor("oldest", 5*time.Minute, "0/3 nodes are available: 3 Insufficient cpu"),
+		}
+
+		count, message := unschedulableRunners(pods, now)
+		Expect(count).To(Equal(2))
+		Expect(message).To(Equal("0/3 nodes are available: 3 Insufficient cpu"))
+	})
+
+	It("should report runners waiting for nodes until they are scheduled", func() {
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &RunnerGroupReconciler{Recorder: recorder}
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+
+		reconciler.updateRunnersUnschedulable(runnerGroup, nil, now)
+		Expect(runnerGroup.Status.Conditions).To(BeEmpty())
+
+		pods := []corev1.Pod{unschedulableFor("pod", time.Minute, "0/3 nodes are available: 3 Insufficient cpu")}
+		reconciler.updateRunnersUnschedulable(runnerGroup, pods, now)
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionRunnersUnschedulable)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("Insufficient cpu"))
+		Expect(recorder.Events).To(HaveLen(1))
+
+		reconciler.updateRunnersUnschedulable(runnerGroup, pods, now)
+		Expect(recorder.Events).To(HaveLen(1))
+
+		reconciler.updateRunnersUnschedulable(runnerGroup, nil, now)
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionRunnersUnschedulable)).To(BeTrue())
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// nodeFailedFor returns how long a node has not been Ready, or zero if it is Ready
+func nodeFailedFor(node *corev1.Node, now time.Time) time.Duration {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			return 0
+		}
+		return now.Sub(condition.LastTransitionTime.Time)
+	}
+	// A node that never reported readiness is treated as failed since it was created
+	return now.Sub(node.CreationTimestamp.Time)
+}
+
+// recoverRunnersOnFailedNodes removes runners whose node has been NotReady (or gone) for longer than
+// the grace period: the pod is force-deleted, its Job deleted, the runner deregistered from Gitea and
+// the job's claim released, so the next poll spawns a replacement if the job is queued again.
+// It returns the names of the runner Jobs that were removed.
+func (r *RunnerGroupReconciler) recoverRunnersOnFailedNodes(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job) (map[string]bool, error) {
+	logger := log.FromContext(ctx)
+	gracePeriod := runnerGroup.Spec.NodeFailureRecovery.GracePeriod.Duration
+	now := time.Now()
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(runnerGroup.Namespace), client.MatchingLabels{
+		runnerGroupNameLabel: runnerGroup.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list runner pods: %w", err)
+	}
+
+	jobsByName := make(map[string]*batchv1.Job, len(jobs))
+	for i := range jobs {
+		jobsByName[jobs[i].Name] = &jobs[i]
+	}
+
+	removed := make(map[string]bool)
+	nodes := make(map[string]*corev1.Node)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || owner.Kind != "Job" {
+			continue
+		}
+		job, ok := jobsByName[owner.Name]
+		if !ok || job.Status.CompletionTime != nil || removed[job.Name] {
+			continue
+		}
+
+		node, ok := nodes[pod.Spec.NodeName]
+		if !ok {
+			node = &corev1.Node{}
+			if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+				if !errors.IsNotFound(err) {
+					return removed, fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+				}
+				node = nil
+			}
+			nodes[pod.Spec.NodeName] = node
+		}
+
+		// Runners on deleted nodes are recovered right away
+		if node != nil {
+			if failedFor := nodeFailedFor(node, now); failedFor == 0 || failedFor < gracePeriod {
+				continue
+			}
+		}
+
+		logger.Info("Recovering runner from failed node", "jobName", job.Name, "pod", pod.Name, "node", pod.Spec.NodeName)
+		if err := r.removeRunner(ctx, runnerGroup, job, pod); err != nil {
+			return removed, err
+		}
+		removed[job.Name] = true
+	}
+
+	return removed, nil
+}
+
+// removeRunner deletes a runner Job and force-deletes its pod, deregisters the runner from Gitea and
+// releases the claim on its Gitea job
+func (r *RunnerGroupReconciler) removeRunner(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, job *batchv1.Job, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete runner Job %s: %w", job.Name, err)
+	}
+	// The kubelet on a failed node can't confirm termination, so don't wait for it
+	if err := r.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete runner pod %s: %w", pod.Name, err)
+	}
+
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err == nil {
+		err = r.GiteaClient.DeregisterRunner(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+			runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo, job.Name)
+	}
+	if err != nil {
+		// Ephemeral runners are cleaned up by Gitea eventually, so this doesn't block recovery
+		logger.Error(err, "Failed to deregister runner from Gitea", "runner", job.Name)
+	} else if r.Recorder != nil {
+		if sudo := gitea.SudoFromContext(ctx); sudo != "" {
+			r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "RunnerDeregistered",
+				"Deregistered runner %s from Gitea acting as %s", job.Name, sudo)
+		} else {
+			r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "RunnerDeregistered",
+				"Deregistered runner %s from Gitea", job.Name)
+		}
+	}
+
+	if id, err := strconv.ParseInt(job.Annotations[giteaJobIDAnnotation], 10, 64); err == nil {
+		r.SpawnedJobsCache.Delete(id)
+		claim := &giteav1alpha1.JobClaim{ObjectMeta: metav1.ObjectMeta{Name: jobClaimName(runnerGroup.Spec.GiteaURL, id)}}
+		if err := r.Delete(ctx, claim); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to release JobClaim: %w", err)
+		}
+	}
+	return nil
+}
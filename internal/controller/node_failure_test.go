@@ -0,0 +1,117 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("nodeFailedFor", func() {
+	now := time.Now()
+	nodeWithReady := func(status corev1.ConditionStatus, since time.Time) *corev1.Node {
+		return &corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: status, LastTransitionTime: metav1.NewTime(since)},
+		}}}
+	}
+
+	It("should return zero for a Ready node", func() {
+		Expect(nodeFailedFor(nodeWithReady(corev1.ConditionTrue, now.Add(-time.Hour)), now)).To(BeZero())
+	})
+
+	It("should return how long the node has not been Ready", func() {
+		Expect(nodeFailedFor(nodeWithReady(corev1.ConditionUnknown, now.Add(-5*time.Minute)), now)).To(Equal(5 * time.Minute))
+	})
+})
+
+var _ = Describe("recoverRunnersOnFailedNodes", func() {
+	ctx := context.Background()
+
+	It("should remove runners on nodes that are NotReady past the grace period", func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "failed-node"}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		node.Status.Conditions = []corev1.NodeCondition{{
+			Type:               corev1.NodeReady,
+			Status:             corev1.ConditionUnknown,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		}}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, node)).To(Succeed()) })
+
+		podSpec := corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+			Containers:    []corev1.Container{{Name: "runner", Image: "gitea/act_runner"}},
+		}
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "recover-runner", Namespace: "default"},
+			Spec:       batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: podSpec}},
+		}
+		Expect(k8sClient.Create(ctx, job)).To(Succeed())
+
+		podSpec.NodeName = node.Name
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "recover-runner-pod",
+				Namespace: "default",
+				Labels:    map[string]string{runnerGroupNameLabel: "recover"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "batch/v1",
+					Kind:       "Job",
+					Name:       job.Name,
+					UID:        job.UID,
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: podSpec,
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "recover", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL:            "https://gitea.example.com",
+				NodeFailureRecovery: &giteav1alpha1.NodeFailureRecoverySpec{GracePeriod: metav1.Duration{Duration: time.Minute}},
+			},
+		}
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: &fakeGiteaClient{}}
+
+		removed, err := reconciler.recoverRunnersOnFailedNodes(ctx, runnerGroup, []batchv1.Job{*job})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(HaveKey(job.Name))
+
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &corev1.Pod{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+})
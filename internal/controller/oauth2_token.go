@@ -0,0 +1,191 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// Keys of an OAuth2 credentials Secret
+const (
+	oauth2ClientIDKey     = "clientID"
+	oauth2ClientSecretKey = "clientSecret"
+	oauth2RefreshTokenKey = "refreshToken"
+)
+
+// oauth2ExpiryMargin is how long before it expires an access token is refreshed, so it doesn't
+// expire in the middle of a reconcile
+const oauth2ExpiryMargin = time.Minute
+
+// OAuth2TokenCache holds the OAuth2 access tokens RunnerGroups with oauth2 credentials poll
+// Gitea with, keyed by the Gitea URL and their credentials Secret. It refreshes them with the
+// Secret's refresh token shortly before they expire and writes rotated refresh tokens back to
+// the Secret. A nil cache refuses oauth2 credentials.
+type OAuth2TokenCache struct {
+	Client      client.Client
+	GiteaClient gitea.Client
+
+	mu     sync.Mutex
+	tokens map[oauth2TokenKey]cachedOAuth2Token
+	// refreshes lets one refresh per key run at a time, without holding up the other keys;
+	// callers arriving during a refresh get its result
+	refreshes singleflight.Group
+}
+
+// oauth2TokenKey identifies an access token: the Gitea instance it was issued by and the Secret
+// holding the credentials it was refreshed with
+type oauth2TokenKey struct {
+	giteaURL string
+	secret   types.NamespacedName
+}
+
+// String returns the key of the token's refreshes
+func (k oauth2TokenKey) String() string {
+	return k.giteaURL + "\x00" + k.secret.String()
+}
+
+// cachedOAuth2Token is an access token and whether its refresh token is stored in the Secret yet
+type cachedOAuth2Token struct {
+	gitea.OAuth2Token
+	persisted bool
+}
+
+// valid reports whether the token can be used for a while yet
+func (t cachedOAuth2Token) valid() bool {
+	return time.Until(t.Expiry) > oauth2ExpiryMargin
+}
+
+// accessToken returns a valid access token of the Gitea instance for the credentials in the
+// named Secret
+func (c *OAuth2TokenCache) accessToken(ctx context.Context, giteaURL string, secret types.NamespacedName) (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("oauth2 is set, but the operator doesn't support OAuth2 credentials")
+	}
+	key := oauth2TokenKey{giteaURL: strings.TrimSuffix(giteaURL, "/"), secret: secret}
+
+	c.mu.Lock()
+	cached, ok := c.tokens[key]
+	c.mu.Unlock()
+	if ok && cached.valid() && cached.persisted {
+		return cached.AccessToken, nil
+	}
+
+	accessToken, err, _ := c.refreshes.Do(key.String(), func() (any, error) {
+		return c.refresh(ctx, key)
+	})
+	if err != nil {
+		return "", err
+	}
+	return accessToken.(string), nil
+}
+
+// refresh returns a valid access token for the key, refreshing it if it is about to expire, and
+// stores a refresh token that didn't make it into the Secret yet. Only one refresh of a key runs
+// at a time.
+func (c *OAuth2TokenCache) refresh(ctx context.Context, key oauth2TokenKey) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.tokens[key]
+	c.mu.Unlock()
+	if ok && cached.valid() {
+		if !cached.persisted && c.storeRefreshToken(ctx, key.secret, cached.RefreshToken) == nil {
+			cached.persisted = true
+			c.store(key, cached)
+		}
+		return cached.AccessToken, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Client.Get(ctx, key.secret, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", key.secret.Name, err)
+	}
+	refreshToken := string(secret.Data[oauth2RefreshTokenKey])
+	// A refresh token that didn't make it into the Secret is the only valid one left if Gitea
+	// invalidates used refresh tokens
+	if ok && !cached.persisted {
+		refreshToken = cached.RefreshToken
+	}
+	if refreshToken == "" {
+		return "", fmt.Errorf("key %s not found in secret %s", oauth2RefreshTokenKey, key.secret.Name)
+	}
+
+	token, err := c.GiteaClient.RefreshOAuth2Token(ctx, key.giteaURL,
+		string(secret.Data[oauth2ClientIDKey]), string(secret.Data[oauth2ClientSecretKey]), refreshToken)
+	if err != nil {
+		if errors.Is(err, gitea.ErrUnauthorized) {
+			c.mu.Lock()
+			delete(c.tokens, key)
+			c.mu.Unlock()
+		}
+		return "", fmt.Errorf("failed to refresh OAuth2 token: %w", err)
+	}
+	cached = cachedOAuth2Token{OAuth2Token: *token, persisted: token.RefreshToken == refreshToken}
+	c.store(key, cached)
+	if !cached.persisted {
+		if err := c.storeRefreshToken(ctx, key.secret, token.RefreshToken); err != nil {
+			if apierrors.IsForbidden(err) {
+				return "", fmt.Errorf("failed to store rotated OAuth2 refresh token, grant the operator update on secret %s: %w",
+					key.secret, err)
+			}
+			return "", fmt.Errorf("failed to store rotated OAuth2 refresh token: %w", err)
+		}
+		cached.persisted = true
+		c.store(key, cached)
+	}
+	return cached.AccessToken, nil
+}
+
+// store caches the key's token
+func (c *OAuth2TokenCache) store(key oauth2TokenKey, token cachedOAuth2Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		c.tokens = make(map[oauth2TokenKey]cachedOAuth2Token)
+	}
+	c.tokens[key] = token
+}
+
+// storeRefreshToken writes a rotated refresh token to the credentials Secret
+func (c *OAuth2TokenCache) storeRefreshToken(ctx context.Context, key types.NamespacedName, refreshToken string) error {
+	secret := &corev1.Secret{}
+	if err := c.Client.Get(ctx, key, secret); err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[oauth2RefreshTokenKey] = []byte(refreshToken)
+	return c.Client.Update(ctx, secret)
+}
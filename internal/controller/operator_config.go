@@ -0,0 +1,122 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/operatorconfig"
+)
+
+// currentPollInterval returns the poll interval from the operator config, or pollInterval
+func (r *RunnerGroupReconciler) currentPollInterval() time.Duration {
+	if interval := r.Config.Config().PollInterval.Duration; interval > 0 {
+		return interval
+	}
+	return pollInterval
+}
+
+// staleRunnerAge returns how long runners may stay offline in Gitea before they are deleted
+// there, or 0 if they are kept
+func (r *RunnerGroupReconciler) staleRunnerAge() time.Duration {
+	return r.Config.Config().StaleRunnerAge.Duration
+}
+
+// orphanedJobPolicy returns what happens to runner Jobs whose Runner or RunnerGroup is gone
+func (r *RunnerGroupReconciler) orphanedJobPolicy() operatorconfig.OrphanedJobPolicy {
+	if policy := r.Config.Config().OrphanedJobPolicy; policy != "" {
+		return policy
+	}
+	return operatorconfig.OrphanedJobPolicyAdopt
+}
+
+// fallbackRunnerImage returns the runner image from the operator config, or the built-in default
+func (r *RunnerGroupReconciler) fallbackRunnerImage() string {
+	if image := r.Config.Config().DefaultRunnerImage; image != "" {
+		return image
+	}
+	return defaultRunnerImage
+}
+
+// effectiveConfig summarizes the configuration new runners of the group are created with
+func (r *RunnerGroupReconciler) effectiveConfig(runnerGroup *giteav1alpha1.RunnerGroup, maxActiveRunners int) *giteav1alpha1.EffectiveConfig {
+	labels := getEffectiveLabels(runnerGroup.Spec.Labels)
+	// An architecture without an image is reported by the spawn loop, the summary just leaves the image out
+	image, _, _, _ := resolveRunnerImage(r.RunnerImages, r.fallbackRunnerImage(), r.DefaultRunnerArch, nil)
+	return &giteav1alpha1.EffectiveConfig{
+		Image:             image,
+		ArchImages:        maps.Clone(r.RunnerImages),
+		Labels:            labels,
+		Env:               runnerEnv(runnerGroup.Spec.GiteaURL, registeredLabels(runnerGroup, labels), runnerGroup.Spec.Proxy),
+		PollInterval:      metav1.Duration{Duration: r.currentPollInterval()},
+		MaxActiveRunners:  maxActiveRunners,
+		MaxRunnerLifetime: runnerGroup.Spec.MaxRunnerLifetime,
+	}
+}
+
+// unseenRunnerTimeout is how long a spawned Runner missing from the cache is still counted; one
+// deleted again right away never shows up
+const unseenRunnerTimeout = time.Minute
+
+// countAllActiveRunners counts the unfinished runners of all RunnerGroups, including runners
+// whose Job hasn't been created yet and runners spawned too recently for the cache to have them
+func (r *RunnerGroupReconciler) countAllActiveRunners(ctx context.Context, now time.Time) (int, error) {
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.HasLabels{runnerGroupNameLabel}); err != nil {
+		return 0, fmt.Errorf("failed to list runner Jobs: %w", err)
+	}
+	runnerList := &giteav1alpha1.RunnerList{}
+	if err := r.List(ctx, runnerList); err != nil {
+		return 0, fmt.Errorf("failed to list Runners: %w", err)
+	}
+
+	active := runnersAwaitingJob(runnerList.Items, jobList.Items)
+	for _, job := range jobList.Items {
+		if job.Status.CompletionTime == nil {
+			active++
+		}
+	}
+
+	cached := make(map[types.NamespacedName]bool, len(runnerList.Items))
+	for i := range runnerList.Items {
+		cached[client.ObjectKeyFromObject(&runnerList.Items[i])] = true
+	}
+	r.unseenRunners.Range(func(key, value any) bool {
+		if cached[key.(types.NamespacedName)] || now.Sub(value.(time.Time)) >= unseenRunnerTimeout {
+			r.unseenRunners.Delete(key)
+		} else {
+			active++
+		}
+		return true
+	})
+	return active, nil
+}
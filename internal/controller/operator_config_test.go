@@ -0,0 +1,99 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Effective config", func() {
+	runnerGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{
+		GiteaURL:          "https://gitea.example.com",
+		Labels:            []string{"self-hosted"},
+		MaxRunnerLifetime: &metav1.Duration{Duration: 12 * time.Hour},
+	}}
+
+	It("should report the built-in defaults without an operator config", func() {
+		reconciler := &RunnerGroupReconciler{}
+		config := reconciler.effectiveConfig(runnerGroup, 7)
+
+		Expect(config.Image).To(Equal(defaultRunnerImage))
+		Expect(config.ArchImages).To(BeNil())
+		Expect(config.Labels).To(ContainElements("self-hosted", "ubuntu-latest:docker://node:16-bullseye"))
+		Expect(config.Env).To(ContainElement(corev1.EnvVar{Name: "GITEA_INSTANCE_URL", Value: "https://gitea.example.com"}))
+		Expect(config.PollInterval.Duration).To(Equal(pollInterval))
+		Expect(config.MaxActiveRunners).To(Equal(7))
+		Expect(config.MaxRunnerLifetime.Duration).To(Equal(12 * time.Hour))
+	})
+
+	It("should never report the registration token", func() {
+		config := (&RunnerGroupReconciler{}).effectiveConfig(runnerGroup, 1)
+		for _, envVar := range config.Env {
+			Expect(envVar.Name).NotTo(Equal("GITEA_RUNNER_REGISTRATION_TOKEN"))
+		}
+	})
+
+	It("should report the image of the default architecture", func() {
+		reconciler := &RunnerGroupReconciler{
+			RunnerImages:      map[string]string{"amd64": "act_runner:amd64", "arm64": "act_runner:arm64"},
+			DefaultRunnerArch: "arm64",
+		}
+		config := reconciler.effectiveConfig(runnerGroup, 1)
+		Expect(config.Image).To(Equal("act_runner:arm64"))
+		Expect(config.ArchImages).To(HaveKeyWithValue("amd64", "act_runner:amd64"))
+	})
+})
+
+var _ = Describe("Operator-wide runner count", func() {
+	ctx := context.Background()
+
+	It("should count runners spawned too recently for the cache to have them", func() {
+		runner := &giteav1alpha1.Runner{
+			ObjectMeta: metav1.ObjectMeta{Name: "counted-abcd1234", Namespace: "default"},
+			Spec:       giteav1alpha1.RunnerSpec{RunnerGroupName: "counted", Image: "gitea/act_runner"},
+		}
+		Expect(k8sClient.Create(ctx, runner)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runner)).To(Succeed()) })
+
+		now := time.Now()
+		reconciler := &RunnerGroupReconciler{Client: k8sClient}
+		reconciler.unseenRunners.Store(client.ObjectKeyFromObject(runner), now)
+		reconciler.unseenRunners.Store(types.NamespacedName{Namespace: "default", Name: "counted-uncached"}, now)
+		reconciler.unseenRunners.Store(types.NamespacedName{Namespace: "default", Name: "counted-deleted"}, now.Add(-unseenRunnerTimeout))
+
+		Expect(reconciler.countAllActiveRunners(ctx, now)).To(Equal(2))
+		_, cached := reconciler.unseenRunners.Load(client.ObjectKeyFromObject(runner))
+		Expect(cached).To(BeFalse())
+	})
+})
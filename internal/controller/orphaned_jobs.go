@@ -0,0 +1,244 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/operatorconfig"
+)
+
+const (
+	// orphanedJobInterval is how often runner Jobs are checked for a lost Runner or RunnerGroup
+	orphanedJobInterval = 5 * time.Minute
+	// orphanedJobMinAge keeps Jobs from being taken for orphans while the caches catch up with
+	// the Runner they were created for
+	orphanedJobMinAge = time.Minute
+)
+
+// orphanedJobCollector looks for runner Jobs on startup and periodically that lost their Runner,
+// e.g. because their owner reference was removed, or whose RunnerGroup doesn't exist in their
+// namespace anymore, and adopts or deletes them according to the operator config's
+// orphanedJobPolicy
+type orphanedJobCollector struct {
+	reconciler *RunnerGroupReconciler
+}
+
+// Start implements manager.Runnable
+func (c *orphanedJobCollector) Start(ctx context.Context) error {
+	c.collect(ctx, time.Now())
+	ticker := time.NewTicker(orphanedJobInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			c.collect(ctx, now)
+		}
+	}
+}
+
+// collect handles the orphaned runner Jobs
+func (c *orphanedJobCollector) collect(ctx context.Context, now time.Time) {
+	logger := log.FromContext(ctx).WithName("orphaned-jobs")
+	r := c.reconciler
+
+	// Jobs are listed first, so the Runners of all of them are in the Runner list
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.HasLabels{managedByLabel, runnerGroupNameLabel}); err != nil {
+		logger.Error(err, "Failed to list runner Jobs")
+		return
+	}
+	runnerList := &giteav1alpha1.RunnerList{}
+	if err := r.List(ctx, runnerList); err != nil {
+		logger.Error(err, "Failed to list Runners")
+		return
+	}
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return
+	}
+	runners := make(map[types.NamespacedName]*giteav1alpha1.Runner, len(runnerList.Items))
+	for i := range runnerList.Items {
+		runners[client.ObjectKeyFromObject(&runnerList.Items[i])] = &runnerList.Items[i]
+	}
+	runnerGroups := make(map[types.NamespacedName]*giteav1alpha1.RunnerGroup, len(runnerGroupList.Items))
+	for i := range runnerGroupList.Items {
+		runnerGroups[client.ObjectKeyFromObject(&runnerGroupList.Items[i])] = &runnerGroupList.Items[i]
+	}
+
+	policy := r.orphanedJobPolicy()
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if job.DeletionTimestamp != nil || now.Sub(job.CreationTimestamp.Time) < orphanedJobMinAge {
+			continue
+		}
+		runnerGroup := runnerGroups[types.NamespacedName{Namespace: job.Namespace, Name: job.Labels[runnerGroupNameLabel]}]
+		if runnerGroup != nil && ownedByRunner(job, runnerGroup, runners) {
+			continue
+		}
+
+		jobLogger := logger.WithValues("job", client.ObjectKeyFromObject(job), "runnerGroup", job.Labels[runnerGroupNameLabel])
+		action := "kept"
+		var err error
+		switch {
+		case policy == operatorconfig.OrphanedJobPolicyKeep:
+			jobLogger.Info("Found orphaned runner Job, keeping it")
+		case policy == operatorconfig.OrphanedJobPolicyAdopt && runnerGroup != nil && runnerGroup.DeletionTimestamp == nil:
+			action = "adopted"
+			err = r.adoptOrphanedJob(ctx, runnerGroup, job, runners)
+		default:
+			action = "deleted"
+			err = r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if errors.IsNotFound(err) {
+				err = nil
+			}
+			// The group's next status write adds the run time of its orphans to its usage
+			if err == nil && runnerGroup != nil {
+				r.deletedRunTimes.add(client.ObjectKeyFromObject(runnerGroup), deletedRunnerRunTime(job, now))
+			}
+		}
+		if err != nil {
+			jobLogger.Error(err, "Failed to handle orphaned runner Job", "action", action)
+			continue
+		}
+		if action != "kept" {
+			jobLogger.Info("Handled orphaned runner Job", "action", action)
+		}
+		orphanedJobs.WithLabelValues(action).Inc()
+	}
+}
+
+// ownedByRunner reports whether the Job is controlled by an existing Runner or, for Jobs spawned
+// before the Runner resource, still by its existing RunnerGroup
+func ownedByRunner(job *batchv1.Job, runnerGroup *giteav1alpha1.RunnerGroup, runners map[types.NamespacedName]*giteav1alpha1.Runner) bool {
+	owner := metav1.GetControllerOf(job)
+	if owner != nil && owner.Kind == "RunnerGroup" {
+		return owner.UID == runnerGroup.UID
+	}
+	if owner == nil || owner.Kind != "Runner" {
+		return false
+	}
+	runner := runners[types.NamespacedName{Namespace: job.Namespace, Name: owner.Name}]
+	return runner != nil && runner.UID == owner.UID
+}
+
+// adoptOrphanedJob makes the Runner the Job is named after its controller, creating the Runner
+// from the Job if it is gone
+func (r *RunnerGroupReconciler) adoptOrphanedJob(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, job *batchv1.Job, runners map[types.NamespacedName]*giteav1alpha1.Runner) error {
+	runner := runners[client.ObjectKeyFromObject(job)]
+	if runner != nil && runner.Spec.RunnerGroupName != runnerGroup.Name {
+		return fmt.Errorf("runner %s belongs to RunnerGroup %s", runner.Name, runner.Spec.RunnerGroupName)
+	}
+	if runner == nil {
+		var err error
+		if runner, err = r.runnerForOrphanedJob(runnerGroup, job); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, runner); err != nil {
+			return fmt.Errorf("failed to create Runner: %w", err)
+		}
+	}
+
+	// Drop the owner reference to a Runner that is gone, so the Runner can become the controller
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.UID != runner.UID {
+		job.OwnerReferences = removeOwnerReference(job.OwnerReferences, owner.UID)
+	}
+	if err := ctrl.SetControllerReference(runner, job, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Update(ctx, job); err != nil {
+		return fmt.Errorf("failed to set the owner of the Job: %w", err)
+	}
+	return nil
+}
+
+// runnerForOrphanedJob reconstructs the Runner of the RunnerGroup an orphaned Job ran for
+func (r *RunnerGroupReconciler) runnerForOrphanedJob(runnerGroup *giteav1alpha1.RunnerGroup, job *batchv1.Job) (*giteav1alpha1.Runner, error) {
+	runner := &giteav1alpha1.Runner{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name,
+			Namespace: job.Namespace,
+			Labels: map[string]string{
+				runnerGroupNameLabel: runnerGroup.Name,
+				managedByLabel:       "gitea-runner-operator",
+			},
+		},
+		Spec: giteav1alpha1.RunnerSpec{
+			RunnerGroupName: runnerGroup.Name,
+			Repository:      job.Annotations[repositoryAnnotation],
+			NodeSelector:    job.Spec.Template.Spec.NodeSelector,
+		},
+	}
+	if id, err := strconv.ParseInt(job.Annotations[giteaJobIDAnnotation], 10, 64); err == nil {
+		runner.Spec.GiteaJobID = id
+	}
+	for _, container := range job.Spec.Template.Spec.Containers {
+		if container.Name != "runner" {
+			continue
+		}
+		runner.Spec.Image = container.Image
+		for _, env := range container.Env {
+			if env.Name == "GITEA_RUNNER_LABELS" && env.Value != "" {
+				runner.Spec.Labels = slices.DeleteFunc(strings.Split(env.Value, ","), func(label string) bool {
+					return strings.HasPrefix(label, provisionedRunnerLabelPrefix)
+				})
+			}
+		}
+	}
+	if runner.Spec.Image == "" {
+		return nil, fmt.Errorf("job %s has no runner container", job.Name)
+	}
+
+	if err := ctrl.SetControllerReference(runnerGroup, runner, r.Scheme); err != nil {
+		return nil, err
+	}
+	return runner, nil
+}
+
+// removeOwnerReference returns the owner references without the one of the given owner
+func removeOwnerReference(refs []metav1.OwnerReference, uid types.UID) []metav1.OwnerReference {
+	kept := refs[:0]
+	for _, ref := range refs {
+		if ref.UID != uid {
+			kept = append(kept, ref)
+		}
+	}
+	return kept
+}
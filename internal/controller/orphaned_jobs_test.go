@@ -0,0 +1,127 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Orphaned runner Jobs", func() {
+	ctx := context.Background()
+
+	It("should adopt Jobs of existing RunnerGroups, keep those the RunnerGroup controls and delete the others", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphans", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL:         "https://gitea.example.com",
+				Scope:            giteav1alpha1.RunnerGroupScopeGlobal,
+				MaxActiveRunners: 2,
+				AuthTokenRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "orphans-secret"},
+					Key:                  "token",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+
+		newJob := func(name, runnerGroupName string) *batchv1.Job {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels: map[string]string{
+						runnerGroupNameLabel: runnerGroupName,
+						managedByLabel:       "gitea-runner-operator",
+					},
+					Annotations: map[string]string{
+						giteaJobIDAnnotation: "42",
+						repositoryAnnotation: "myorg/myrepo",
+					},
+				},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					NodeSelector:  map[string]string{"kubernetes.io/arch": "arm64"},
+					Containers: []corev1.Container{{
+						Name:  "runner",
+						Image: "gitea/act_runner:arm64",
+						Env:   []corev1.EnvVar{{Name: "GITEA_RUNNER_LABELS", Value: "ubuntu-latest,arm64," + provisionedRunnerLabelPrefix + "group-uid"}},
+					}},
+				}}},
+			}
+			Expect(k8sClient.Create(ctx, job)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)))).To(Succeed())
+			})
+			return job
+		}
+		adoptable := newJob("orphans-abcd1234", runnerGroup.Name)
+		groupless := newJob("moved-abcd1234", "moved")
+		legacy := newJob("orphans-legacy12", runnerGroup.Name)
+		Expect(ctrl.SetControllerReference(runnerGroup, legacy, k8sClient.Scheme())).To(Succeed())
+		Expect(k8sClient.Update(ctx, legacy)).To(Succeed())
+
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		collector := &orphanedJobCollector{reconciler: reconciler}
+
+		By("leaving Jobs alone while they are new")
+		collector.collect(ctx, time.Now())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(groupless), &batchv1.Job{})).To(Succeed())
+
+		By("adopting and deleting them once the caches had time to catch up")
+		collector.collect(ctx, time.Now().Add(2*orphanedJobMinAge))
+
+		runner := &giteav1alpha1.Runner{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(adoptable), runner)).To(Succeed())
+		DeferCleanup(func() { Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, runner))).To(Succeed()) })
+		Expect(runner.Spec.RunnerGroupName).To(Equal(runnerGroup.Name))
+		Expect(runner.Spec.GiteaJobID).To(Equal(int64(42)))
+		Expect(runner.Spec.Repository).To(Equal("myorg/myrepo"))
+		Expect(runner.Spec.Image).To(Equal("gitea/act_runner:arm64"))
+		Expect(runner.Spec.Labels).To(Equal([]string{"ubuntu-latest", "arm64"}))
+		Expect(runner.Spec.NodeSelector).To(Equal(map[string]string{"kubernetes.io/arch": "arm64"}))
+		Expect(metav1.GetControllerOf(runner).UID).To(Equal(runnerGroup.UID))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(adoptable), adoptable)).To(Succeed())
+		Expect(metav1.GetControllerOf(adoptable).UID).To(Equal(runner.UID))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(groupless), &batchv1.Job{})).To(Satisfy(errors.IsNotFound))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(legacy), legacy)).To(Succeed())
+		Expect(metav1.GetControllerOf(legacy).UID).To(Equal(runnerGroup.UID))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(legacy), &giteav1alpha1.Runner{})).To(Satisfy(errors.IsNotFound))
+	})
+})
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// recoveryRampBudget returns how many more runners the group may spawn while recovering from a
+// Gitea outage, and whether the ramp has finished. The ramp allows RampStep concurrent runners
+// more every interval, the group's effective poll interval, since the recovery started; the
+// group's active runners, including those that kept running through the outage, use up that
+// allowance before any new runner is spawned.
+func recoveryRampBudget(runnerGroup *giteav1alpha1.RunnerGroup, maxActiveRunners, activeRunners, queuedJobs int, interval time.Duration, now time.Time) (int, bool) {
+	recovery := runnerGroup.Spec.OutageRecovery
+	if recovery == nil || runnerGroup.Status.RecoveryStartTime == nil {
+		return max(maxActiveRunners-activeRunners, 0), true
+	}
+
+	rampStep := recovery.RampStep
+	if rampStep < 1 {
+		rampStep = 1
+	}
+
+	if interval <= 0 {
+		interval = pollInterval
+	}
+	intervals := int(now.Sub(runnerGroup.Status.RecoveryStartTime.Time)/interval) + 1
+	limit := intervals * rampStep
+
+	// The ramp is over once it no longer constrains anything: either the limit
+	// reached the configured maximum, or the backlog fits under the current limit.
+	if limit >= maxActiveRunners || activeRunners+queuedJobs <= limit {
+		return max(maxActiveRunners-activeRunners, 0), true
+	}
+
+	return max(limit-activeRunners, 0), false
+}
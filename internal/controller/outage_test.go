@@ -0,0 +1,106 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Outage recovery ramp", func() {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newGroup := func(rampStep int) *giteav1alpha1.RunnerGroup {
+		recoveryStart := metav1.NewTime(start)
+		return &giteav1alpha1.RunnerGroup{
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				MaxActiveRunners: 10,
+				OutageRecovery:   &giteav1alpha1.OutageRecoverySpec{RampStep: rampStep},
+			},
+			Status: giteav1alpha1.RunnerGroupStatus{RecoveryStartTime: &recoveryStart},
+		}
+	}
+
+	It("should grow the budget by RampStep every poll interval", func() {
+		group := newGroup(2)
+
+		budget, done := recoveryRampBudget(group, 10, 0, 20, pollInterval, start)
+		Expect(done).To(BeFalse())
+		Expect(budget).To(Equal(2))
+
+		budget, done = recoveryRampBudget(group, 10, 2, 20, pollInterval, start.Add(2*pollInterval))
+		Expect(done).To(BeFalse())
+		Expect(budget).To(Equal(4))
+	})
+
+	It("should grow the budget by RampStep every effective poll interval", func() {
+		group := newGroup(2)
+		interval := time.Minute
+
+		budget, done := recoveryRampBudget(group, 10, 0, 20, interval, start.Add(59*time.Second))
+		Expect(done).To(BeFalse())
+		Expect(budget).To(Equal(2))
+
+		budget, done = recoveryRampBudget(group, 10, 2, 20, interval, start.Add(2*interval))
+		Expect(done).To(BeFalse())
+		Expect(budget).To(Equal(4))
+	})
+
+	It("should count runners that kept running through the outage against the budget", func() {
+		group := newGroup(2)
+
+		budget, done := recoveryRampBudget(group, 10, 3, 20, pollInterval, start)
+		Expect(done).To(BeFalse())
+		Expect(budget).To(BeZero())
+
+		budget, done = recoveryRampBudget(group, 10, 3, 20, pollInterval, start.Add(pollInterval))
+		Expect(done).To(BeFalse())
+		Expect(budget).To(Equal(1))
+	})
+
+	It("should finish once the limit reaches MaxActiveRunners", func() {
+		budget, done := recoveryRampBudget(newGroup(5), 10, 5, 20, pollInterval, start.Add(pollInterval))
+		Expect(done).To(BeTrue())
+		Expect(budget).To(Equal(5))
+	})
+
+	It("should finish once the backlog fits under the limit", func() {
+		_, done := recoveryRampBudget(newGroup(3), 10, 1, 2, pollInterval, start)
+		Expect(done).To(BeTrue())
+	})
+
+	It("should not limit groups without an outage recovery policy", func() {
+		group := newGroup(1)
+		group.Spec.OutageRecovery = nil
+
+		budget, done := recoveryRampBudget(group, 10, 4, 20, pollInterval, start)
+		Expect(done).To(BeTrue())
+		Expect(budget).To(Equal(6))
+	})
+})
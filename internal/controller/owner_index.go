@@ -0,0 +1,108 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// ownerIndexField indexes Runners by the RunnerGroup controlling them. Unlike the
+// runnergroup-name label, owner references can't be edited into pointing elsewhere.
+const ownerIndexField = ".metadata.controller"
+
+// runnerJobIndexField indexes the Jobs controlled by a Runner or, for Jobs spawned before the
+// Runner resource existed, by a RunnerGroup, so a group's Jobs take one List of its namespace.
+const runnerJobIndexField = ".metadata.controllerGroup"
+
+// indexOwners registers the owner indexes with the manager's cache
+func indexOwners(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &giteav1alpha1.Runner{}, ownerIndexField, controllerName("RunnerGroup")); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &batchv1.Job{}, runnerJobIndexField, runnerJobController)
+}
+
+// controllerName indexes objects by the name of their controller of the given kind
+func controllerName(kind string) client.IndexerFunc {
+	return func(obj client.Object) []string {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.Kind != kind || owner.APIVersion != giteav1alpha1.GroupVersion.String() {
+			return nil
+		}
+		return []string{owner.Name}
+	}
+}
+
+// runnerJobController indexes Jobs controlled by a Runner or RunnerGroup under the API group
+func runnerJobController(obj client.Object) []string {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.APIVersion != giteav1alpha1.GroupVersion.String() ||
+		(owner.Kind != "Runner" && owner.Kind != "RunnerGroup") {
+		return nil
+	}
+	return []string{giteav1alpha1.GroupVersion.Group}
+}
+
+// listRunners returns the Runners the RunnerGroup controls
+func (r *RunnerGroupReconciler) listRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup) ([]giteav1alpha1.Runner, error) {
+	runnerList := &giteav1alpha1.RunnerList{}
+	if err := r.List(ctx, runnerList, client.InNamespace(runnerGroup.Namespace),
+		client.MatchingFields{ownerIndexField: runnerGroup.Name}); err != nil {
+		return nil, err
+	}
+	return runnerList.Items, nil
+}
+
+// listRunnerJobs returns the Jobs of the Runners the RunnerGroup controls, and the Jobs the
+// RunnerGroup still controls directly because they were spawned before the Runner resource
+func (r *RunnerGroupReconciler) listRunnerJobs(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup) ([]batchv1.Job, error) {
+	runners, err := r.listRunners(ctx, runnerGroup)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[types.UID]bool, len(runners)+1)
+	owners[runnerGroup.UID] = true
+	for _, runner := range runners {
+		owners[runner.UID] = true
+	}
+
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.InNamespace(runnerGroup.Namespace),
+		client.MatchingFields{runnerJobIndexField: giteav1alpha1.GroupVersion.Group}); err != nil {
+		return nil, err
+	}
+	var jobs []batchv1.Job
+	for _, job := range jobList.Items {
+		if owner := metav1.GetControllerOf(&job); owner != nil && owners[owner.UID] {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
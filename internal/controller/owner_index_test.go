@@ -0,0 +1,94 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("listRunnerJobs", func() {
+	ctx := context.Background()
+
+	It("should find the Jobs by owner rather than by the runnergroup-name label", func() {
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "gitea-secret"}, Key: "token"}
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "indexed", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:                giteav1alpha1.RunnerGroupScopeGlobal,
+				GiteaURL:             "https://gitea.example.com",
+				MaxActiveRunners:     2,
+				RegistrationTokenRef: &secretRef,
+				AuthTokenRef:         secretRef,
+			},
+		}
+		Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+
+		newJob := func(name, labelValue string, owner client.Object) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{runnerGroupNameLabel: labelValue}},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers:    []corev1.Container{{Name: "runner", Image: "gitea/act_runner"}},
+				}}},
+			}
+			if owner != nil {
+				Expect(ctrl.SetControllerReference(owner, job, k8sClient.Scheme())).To(Succeed())
+			}
+			Expect(k8sClient.Create(ctx, job)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+			})
+		}
+		runner := &giteav1alpha1.Runner{
+			ObjectMeta: metav1.ObjectMeta{Name: "indexed-runner", Namespace: "default"},
+			Spec:       giteav1alpha1.RunnerSpec{RunnerGroupName: runnerGroup.Name, Image: "gitea/act_runner"},
+		}
+		Expect(ctrl.SetControllerReference(runnerGroup, runner, k8sClient.Scheme())).To(Succeed())
+		Expect(k8sClient.Create(ctx, runner)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runner)).To(Succeed()) })
+
+		newJob("indexed-runner", "relabeled", runner)
+		newJob("indexed-unowned", runnerGroup.Name, nil)
+		newJob("indexed-legacy", runnerGroup.Name, runnerGroup)
+
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+		jobs, err := reconciler.listRunnerJobs(ctx, runnerGroup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobs).To(ConsistOf(
+			HaveField("Name", "indexed-runner"),
+			HaveField("Name", "indexed-legacy"),
+		))
+	})
+})
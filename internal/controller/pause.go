@@ -0,0 +1,75 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// pausedAnnotation pauses a RunnerGroup like spec.paused while its value is "true"
+const pausedAnnotation = "gitea.bpg.pw/paused"
+
+// pauseReason returns what pauses the RunnerGroup, or false if it isn't paused
+func pauseReason(runnerGroup *giteav1alpha1.RunnerGroup) (string, bool) {
+	switch {
+	case runnerGroup.Spec.Paused:
+		return "SpecPaused", true
+	case runnerGroup.Annotations[pausedAnnotation] == "true":
+		return "AnnotationPaused", true
+	}
+	return "", false
+}
+
+// setPaused records that the RunnerGroup is paused for the reason
+func setPaused(runnerGroup *giteav1alpha1.RunnerGroup, reason string) {
+	message := "Paused by spec.paused"
+	if reason == "AnnotationPaused" {
+		message = "Paused by the " + pausedAnnotation + " annotation"
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionPaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message + "; Gitea isn't polled and no runners are spawned",
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
+
+// clearPaused records that the RunnerGroup polls and spawns again. It returns false if the group
+// wasn't paused.
+func clearPaused(runnerGroup *giteav1alpha1.RunnerGroup) bool {
+	if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionPaused) {
+		return false
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionPaused,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotPaused",
+		Message:            "Gitea is polled and runners are spawned",
+		ObservedGeneration: runnerGroup.Generation,
+	})
+	return true
+}
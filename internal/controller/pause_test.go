@@ -0,0 +1,68 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Pausing RunnerGroups", func() {
+	It("should pause by spec or annotation", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+		_, paused := pauseReason(runnerGroup)
+		Expect(paused).To(BeFalse())
+
+		runnerGroup.Annotations = map[string]string{pausedAnnotation: "false"}
+		_, paused = pauseReason(runnerGroup)
+		Expect(paused).To(BeFalse())
+
+		runnerGroup.Annotations[pausedAnnotation] = "true"
+		reason, paused := pauseReason(runnerGroup)
+		Expect(paused).To(BeTrue())
+		Expect(reason).To(Equal("AnnotationPaused"))
+
+		runnerGroup.Spec.Paused = true
+		reason, _ = pauseReason(runnerGroup)
+		Expect(reason).To(Equal("SpecPaused"))
+	})
+
+	It("should only report a resume for a paused group", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+		Expect(clearPaused(runnerGroup)).To(BeFalse())
+		Expect(runnerGroup.Status.Conditions).To(BeEmpty())
+
+		setPaused(runnerGroup, "AnnotationPaused")
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionPaused)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring(pausedAnnotation))
+
+		Expect(clearPaused(runnerGroup)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionPaused)).To(BeTrue())
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Reasons runner pods are counted under in status.runnerFailureReasons
+const (
+	podFailureUnschedulable   = "Unschedulable"
+	podFailureEvicted         = "Evicted"
+	podFailureImagePull       = "ImagePull"
+	podFailureContainerConfig = "ContainerConfig"
+	podFailureOOMKilled       = "OOMKilled"
+	podFailureError           = "Error"
+)
+
+// podFailureReason classifies why a runner pod can't run, or returns "" if nothing is wrong
+// with it. The runner container's current state goes before its previous exit, so a container
+// that crashed once but pulls a fixed image again counts as ImagePull.
+func podFailureReason(pod *corev1.Pod) string {
+	if pod.Status.Reason == "Evicted" {
+		return podFailureEvicted
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return podFailureUnschedulable
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != runnerContainerName {
+			continue
+		}
+		if waiting := status.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+				return podFailureImagePull
+			case "CreateContainerConfigError", "CreateContainerError":
+				return podFailureContainerConfig
+			}
+		}
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			if terminated.Reason == "OOMKilled" {
+				return podFailureOOMKilled
+			}
+			return podFailureError
+		}
+	}
+	return ""
+}
+
+// countPodFailureReasons counts the pods that can't run by podFailureReason, or returns nil if
+// all of them are fine
+func countPodFailureReasons(pods []corev1.Pod) map[string]int {
+	var counts map[string]int
+	for i := range pods {
+		reason := podFailureReason(&pods[i])
+		if reason == "" {
+			continue
+		}
+		if counts == nil {
+			counts = make(map[string]int)
+		}
+		counts[reason]++
+	}
+	return counts
+}
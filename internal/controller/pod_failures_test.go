@@ -0,0 +1,79 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Runner pod failure reasons", func() {
+	runnerPod := func(state, last corev1.ContainerState) corev1.Pod {
+		return corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name: runnerContainerName, State: state, LastTerminationState: last,
+		}}}}
+	}
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}
+	}
+	terminated := func(exitCode int32, reason string) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason}}
+	}
+
+	It("should classify pods by why they can't run", func() {
+		unschedulable := corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable,
+		}}}}
+		evicted := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"}}
+		running := runnerPod(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, corev1.ContainerState{})
+
+		Expect(podFailureReason(&unschedulable)).To(Equal(podFailureUnschedulable))
+		Expect(podFailureReason(&evicted)).To(Equal(podFailureEvicted))
+		Expect(podFailureReason(&running)).To(BeEmpty())
+		for _, tc := range []struct {
+			pod    corev1.Pod
+			reason string
+		}{
+			{runnerPod(waiting("ImagePullBackOff"), corev1.ContainerState{}), podFailureImagePull},
+			{runnerPod(waiting("ImagePullBackOff"), terminated(1, "Error")), podFailureImagePull},
+			{runnerPod(waiting("CreateContainerConfigError"), corev1.ContainerState{}), podFailureContainerConfig},
+			{runnerPod(waiting("CrashLoopBackOff"), terminated(137, "OOMKilled")), podFailureOOMKilled},
+			{runnerPod(waiting("CrashLoopBackOff"), terminated(1, "Error")), podFailureError},
+			{runnerPod(terminated(0, "Completed"), corev1.ContainerState{}), ""},
+		} {
+			Expect(podFailureReason(&tc.pod)).To(Equal(tc.reason))
+		}
+	})
+
+	It("should count the reasons", func() {
+		pods := []corev1.Pod{
+			runnerPod(waiting("ErrImagePull"), corev1.ContainerState{}),
+			runnerPod(waiting("ImagePullBackOff"), corev1.ContainerState{}),
+			runnerPod(waiting("CrashLoopBackOff"), terminated(1, "Error")),
+		}
+		Expect(countPodFailureReasons(pods)).To(Equal(map[string]int{podFailureImagePull: 2, podFailureError: 1}))
+		Expect(countPodFailureReasons(nil)).To(BeNil())
+	})
+})
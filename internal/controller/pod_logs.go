@@ -0,0 +1,168 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// runnerContainerName is the container of runner pods that runs act_runner
+	runnerContainerName = "runner"
+	// failureLogLines is how many lines of a failed runner container's log are captured
+	failureLogLines = 20
+	// maxFailureLogBytes caps the captured log, keeping its end, and the event reporting it;
+	// event messages are limited to 1KiB
+	maxFailureLogBytes = 1024
+	// redactedLogValue replaces credentials in captured logs
+	redactedLogValue = "[REDACTED]"
+)
+
+var (
+	// logCredentialFields matches the values of token, secret and password fields in log lines
+	logCredentialFields = regexp.MustCompile(`(?i)([\w.-]*(?:token|secret|password)[\w.-]*["']?\s*[:=]\s*["']?)[^\s"'&,]+`)
+	// logBearerTokens matches bearer tokens in log lines
+	logBearerTokens = regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`)
+	// logURLPasswords matches passwords in URLs in log lines
+	logURLPasswords = regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+@`)
+)
+
+// PodLogReader reads the end of a pod container's log
+type PodLogReader interface {
+	// TailLog returns the last lines of the container's log, of its previous instance if
+	// previous is set
+	TailLog(ctx context.Context, namespace, pod, container string, previous bool, lines int64) (string, error)
+}
+
+// ClientsetPodLogReader reads pod logs through the Kubernetes API
+type ClientsetPodLogReader struct {
+	Clientset kubernetes.Interface
+}
+
+// TailLog implements PodLogReader
+func (r *ClientsetPodLogReader) TailLog(ctx context.Context, namespace, pod, container string, previous bool, lines int64) (string, error) {
+	data, err := r.Clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &lines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log of pod %s: %w", pod, err)
+	}
+	return string(data), nil
+}
+
+// runnerContainerFailure returns the latest exit of the pod's runner container with an error,
+// and whether it was a previous instance of the container that has been restarted since
+func runnerContainerFailure(pod *corev1.Pod) (*corev1.ContainerStateTerminated, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != runnerContainerName {
+			continue
+		}
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return terminated, false
+		}
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return terminated, true
+		}
+	}
+	return nil, false
+}
+
+// tailBytes returns the end of s of at most limit bytes, starting at a full line if possible
+func tailBytes(s string, limit int) string {
+	s = strings.TrimRight(s, "\n")
+	if len(s) <= limit {
+		return s
+	}
+	s = s[len(s)-limit:]
+	if i := strings.IndexByte(s, '\n'); i >= 0 && i < len(s)-1 {
+		return s[i+1:]
+	}
+	// Don't start in the middle of a UTF-8 sequence
+	for len(s) > 0 && !utf8.RuneStart(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
+
+// redactLog replaces the credentials in a captured log: the runner logs its registration
+// token or Gitea's responses when it fails to register
+func redactLog(s string) string {
+	s = logCredentialFields.ReplaceAllString(s, "${1}"+redactedLogValue)
+	s = logBearerTokens.ReplaceAllString(s, "${1}"+redactedLogValue)
+	return logURLPasswords.ReplaceAllString(s, "${1}"+redactedLogValue+"@")
+}
+
+// captureFailureLog records a new exit of the runner container with an error in the Runner's
+// status, with the end of the container's log with credentials redacted, and reports it in an
+// event on the RunnerGroup, so failures like a rejected registration token stay visible after
+// the pod is gone
+func (r *RunnerReconciler) captureFailureLog(ctx context.Context, runner *giteav1alpha1.Runner, pod *corev1.Pod) {
+	terminated, previous := runnerContainerFailure(pod)
+	if terminated == nil {
+		return
+	}
+	if last := runner.Status.LastFailure; last != nil && last.FinishedAt.Equal(&terminated.FinishedAt) {
+		return
+	}
+
+	failure := &giteav1alpha1.RunnerFailure{
+		FinishedAt: terminated.FinishedAt,
+		ExitCode:   terminated.ExitCode,
+		Reason:     terminated.Reason,
+	}
+	if r.PodLogs != nil {
+		logs, err := r.PodLogs.TailLog(ctx, pod.Namespace, pod.Name, runnerContainerName, previous, failureLogLines)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to read log of failed runner container", "pod", pod.Name)
+		}
+		failure.Log = tailBytes(redactLog(logs), maxFailureLogBytes)
+	}
+	runner.Status.LastFailure = failure
+
+	if r.Recorder == nil {
+		return
+	}
+	runnerGroup := &giteav1alpha1.RunnerGroup{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: runner.Namespace, Name: runner.Spec.RunnerGroupName}, runnerGroup); err != nil {
+		return
+	}
+	message := fmt.Sprintf("Runner %s exited with code %d", runner.Name, terminated.ExitCode)
+	if failure.Log != "" {
+		message += ":\n"
+		message += tailBytes(failure.Log, maxFailureLogBytes-len(message))
+	}
+	r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "RunnerFailed", message)
+}
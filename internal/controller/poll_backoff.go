@@ -0,0 +1,74 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxPollErrorBackoff caps how long a RunnerGroup whose polls keep failing waits between polls
+const maxPollErrorBackoff = 5 * time.Minute
+
+// pollBackoff is the state of a RunnerGroup whose polls keep failing
+type pollBackoff struct {
+	// failures counts the consecutive failed polls
+	failures int
+	// notBefore is when the group may poll again
+	notBefore time.Time
+}
+
+// pollErrorRequeue records a failed poll of the RunnerGroup and returns when to poll again. Until
+// then, isPollDue holds back polls that Job or pod events would otherwise trigger.
+func (r *RunnerGroupReconciler) pollErrorRequeue(key types.NamespacedName, interval time.Duration, now time.Time) time.Duration {
+	failures := 0
+	if value, ok := r.pollFailures.Load(key); ok {
+		failures = value.(pollBackoff).failures
+	}
+	delay := pollErrorBackoff(interval, failures)
+	r.pollFailures.Store(key, pollBackoff{failures: failures + 1, notBefore: now.Add(delay)})
+	return delay
+}
+
+// pollBackoffUntil returns when the RunnerGroup may poll again after failed polls, or the zero
+// time if its last poll succeeded
+func (r *RunnerGroupReconciler) pollBackoffUntil(key types.NamespacedName) time.Time {
+	if value, ok := r.pollFailures.Load(key); ok {
+		return value.(pollBackoff).notBefore
+	}
+	return time.Time{}
+}
+
+// pollErrorBackoff returns the poll interval, doubled for every earlier consecutive failure up
+// to maxPollErrorBackoff
+func pollErrorBackoff(interval time.Duration, failures int) time.Duration {
+	delay := interval
+	for range failures {
+		if delay >= maxPollErrorBackoff {
+			break
+		}
+		delay *= 2
+	}
+	return min(delay, maxPollErrorBackoff)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("pollErrorBackoff", func() {
+	It("should double the interval for every consecutive failure up to the cap", func() {
+		Expect(pollErrorBackoff(10*time.Second, 0)).To(Equal(10 * time.Second))
+		Expect(pollErrorBackoff(10*time.Second, 1)).To(Equal(20 * time.Second))
+		Expect(pollErrorBackoff(10*time.Second, 3)).To(Equal(80 * time.Second))
+		Expect(pollErrorBackoff(10*time.Second, 5)).To(Equal(maxPollErrorBackoff))
+		Expect(pollErrorBackoff(10*time.Second, 1000)).To(Equal(maxPollErrorBackoff))
+		Expect(pollErrorBackoff(10*time.Minute, 0)).To(Equal(maxPollErrorBackoff))
+	})
+
+	It("should back off per RunnerGroup", func() {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		reconciler := &RunnerGroupReconciler{}
+		failing := types.NamespacedName{Namespace: "default", Name: "failing"}
+		other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+		Expect(reconciler.pollErrorRequeue(failing, pollInterval, now)).To(Equal(pollInterval))
+		Expect(reconciler.pollErrorRequeue(failing, pollInterval, now)).To(Equal(2 * pollInterval))
+		Expect(reconciler.pollErrorRequeue(other, pollInterval, now)).To(Equal(pollInterval))
+
+		Expect(reconciler.pollBackoffUntil(failing)).To(Equal(now.Add(2 * pollInterval)))
+		Expect(reconciler.pollBackoffUntil(other)).To(Equal(now.Add(pollInterval)))
+
+		reconciler.pollFailures.Delete(failing)
+		Expect(reconciler.pollErrorRequeue(failing, pollInterval, now)).To(Equal(pollInterval))
+	})
+})
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// pollTriggerBuffer is how many triggered RunnerGroups may wait to be enqueued
+	pollTriggerBuffer = 100
+	// defaultFallbackPollInterval is how often RunnerGroups poll while webhooks announce their jobs
+	defaultFallbackPollInterval = time.Minute
+	// pollNowAnnotation makes a RunnerGroup poll right away whenever its value changes
+	pollNowAnnotation = "gitea.bpg.pw/poll-now"
+	// webhookPollPrefix starts the poll-now values of webhook deliveries a standby replica passed
+	// on to the leader, followed by the delivery
+	webhookPollPrefix = "webhook/"
+	// webhookDeliveryTTL is how long the leader remembers the deliveries that made each RunnerGroup
+	// poll, matching the replay window of the webhook receiver
+	webhookDeliveryTTL = time.Hour
+)
+
+// webhookDeliveryKey identifies a delivery that made a RunnerGroup poll
+type webhookDeliveryKey struct {
+	runnerGroup types.NamespacedName
+	delivery    string
+}
+
+// TriggerPoll makes the RunnerGroups of a Gitea instance whose scope covers the repository
+// ("owner/name") poll for queued jobs right away, because the webhook delivery identified by
+// delivery announced a new job. An empty repository triggers every RunnerGroup of the instance.
+// Each replica's receiver only rejects the replays it sees itself, so the leader also ignores
+// deliveries it already acted on, whichever replica received them. Triggers are dropped rather
+// than blocking the caller while the controller is busy; the regular poll picks those groups up.
+func (r *RunnerGroupReconciler) TriggerPoll(ctx context.Context, giteaURL, repository, delivery string) {
+	logger := log.FromContext(ctx)
+
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups to trigger polls", "giteaURL", giteaURL)
+		return
+	}
+
+	for i := range runnerGroupList.Items {
+		runnerGroup := &runnerGroupList.Items[i]
+		if !sameGiteaURL(runnerGroup.Spec.GiteaURL, giteaURL) || (repository != "" && !scopeCoversRepo(runnerGroup, repository)) {
+			continue
+		}
+		if !r.leading() {
+			if err := r.requestPollFromLeader(ctx, runnerGroup, delivery); err != nil {
+				logger.Error(err, "Failed to pass poll trigger on to the leader", "runnerGroup", client.ObjectKeyFromObject(runnerGroup))
+			}
+			continue
+		}
+		if !r.firstWebhookDelivery(client.ObjectKeyFromObject(runnerGroup), delivery, time.Now()) {
+			logger.V(1).Info("Ignored replayed webhook delivery", "runnerGroup", client.ObjectKeyFromObject(runnerGroup))
+			continue
+		}
+		r.webhookTriggered.Store(client.ObjectKeyFromObject(runnerGroup), time.Now())
+		if !r.triggerPoll(runnerGroup) {
+			logger.V(1).Info("Poll trigger dropped, controller busy", "runnerGroup", client.ObjectKeyFromObject(runnerGroup))
+		}
+	}
+}
+
+// leading reports whether this replica is the leader, whose controllers act on poll triggers
+func (r *RunnerGroupReconciler) leading() bool {
+	if r.Elected == nil {
+		return true
+	}
+	select {
+	case <-r.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestPollFromLeader makes the leader poll the RunnerGroup for the webhook delivery by setting
+// its poll-now annotation, since the controllers of a standby replica don't run. The value names
+// the delivery, so the leader counts the poll as webhook-triggered, and a replay of the delivery
+// leaves the annotation as it is.
+func (r *RunnerGroupReconciler) requestPollFromLeader(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, delivery string) error {
+	patch := client.MergeFrom(runnerGroup.DeepCopy())
+	metav1.SetMetaDataAnnotation(&runnerGroup.ObjectMeta, pollNowAnnotation, webhookPollPrefix+delivery)
+	return r.Patch(ctx, runnerGroup, patch)
+}
+
+// firstWebhookDelivery records that the delivery made the RunnerGroup poll, and reports whether
+// it didn't already within webhookDeliveryTTL
+func (r *RunnerGroupReconciler) firstWebhookDelivery(runnerGroup types.NamespacedName, delivery string, now time.Time) bool {
+	r.webhookDeliveries.Range(func(key, value any) bool {
+		if now.Sub(value.(time.Time)) >= webhookDeliveryTTL {
+			r.webhookDeliveries.Delete(key)
+		}
+		return true
+	})
+	_, seen := r.webhookDeliveries.LoadOrStore(webhookDeliveryKey{runnerGroup: runnerGroup, delivery: delivery}, now)
+	return !seen
+}
+
+// EnqueueRunnerGroup reconciles the RunnerGroup right away and makes it poll Gitea, for signals
+// from outside the controller such as a shared poller. It returns false if the signal was dropped
+// because the controller is busy; the RunnerGroup then polls at its next interval.
+func (r *RunnerGroupReconciler) EnqueueRunnerGroup(key types.NamespacedName) bool {
+	return r.triggerPoll(&giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}})
+}
+
+// triggerPoll enqueues the RunnerGroup to poll on its next reconcile. It returns false if the
+// trigger was dropped because the controller is busy.
+func (r *RunnerGroupReconciler) triggerPoll(runnerGroup *giteav1alpha1.RunnerGroup) bool {
+	// Forgetting the polled generation makes the next reconcile poll
+	r.polledGenerations.Delete(client.ObjectKeyFromObject(runnerGroup))
+	if r.pollTriggers == nil {
+		return true
+	}
+	select {
+	case r.pollTriggers <- event.GenericEvent{Object: runnerGroup}:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestedPoll reports whether the RunnerGroup's poll-now annotation changed since it was last
+// seen, i.e. someone asked it to poll, e.g. with
+// kubectl annotate runnergroup my-group gitea.bpg.pw/poll-now="$(date +%s)" --overwrite
+// Webhook deliveries a standby replica passed on count as webhook-triggered polls, unless they
+// are replays of deliveries the group already polled for.
+func (r *RunnerGroupReconciler) requestedPoll(runnerGroup *giteav1alpha1.RunnerGroup) bool {
+	value, ok := runnerGroup.Annotations[pollNowAnnotation]
+	if !ok {
+		return false
+	}
+	key := client.ObjectKeyFromObject(runnerGroup)
+	previous, seen := r.pollRequests.Swap(key, value)
+	if seen && previous.(string) == value {
+		return false
+	}
+	if delivery, forwarded := strings.CutPrefix(value, webhookPollPrefix); forwarded {
+		if !r.firstWebhookDelivery(key, delivery, time.Now()) {
+			return false
+		}
+		r.webhookTriggered.Store(key, time.Now())
+	}
+	return true
+}
+
+// receivesWebhooks reports whether the instance's webhook deliveries reach the operator
+func (r *RunnerGroupReconciler) receivesWebhooks(instance *giteav1alpha1.GiteaInstance) bool {
+	return r.WebhooksEnabled && instance != nil && instance.Spec.WebhookSecretRef != nil
+}
+
+// groupPollInterval returns how often RunnerGroups of the instance poll Gitea: the poll interval,
+// or the longer fallback interval if webhooks announce the instance's jobs
+func (r *RunnerGroupReconciler) groupPollInterval(instance *giteav1alpha1.GiteaInstance) time.Duration {
+	interval := r.currentPollInterval()
+	if !r.receivesWebhooks(instance) {
+		return interval
+	}
+	fallback := defaultFallbackPollInterval
+	if instance.Spec.FallbackPollInterval != nil {
+		fallback = instance.Spec.FallbackPollInterval.Duration
+	}
+	return max(interval, fallback)
+}
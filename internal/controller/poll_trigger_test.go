@@ -0,0 +1,208 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("TriggerPoll", func() {
+	ctx := context.Background()
+
+	It("should make the instance's RunnerGroups poll right away", func() {
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "gitea-secret"}, Key: "token"}
+		newRunnerGroup := func(name, giteaURL string) *giteav1alpha1.RunnerGroup {
+			runnerGroup := &giteav1alpha1.RunnerGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: giteav1alpha1.RunnerGroupSpec{
+					Scope:                giteav1alpha1.RunnerGroupScopeGlobal,
+					GiteaURL:             giteaURL,
+					MaxActiveRunners:     1,
+					RegistrationTokenRef: &secretRef,
+					AuthTokenRef:         secretRef,
+				},
+			}
+			Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+			return runnerGroup
+		}
+		triggered := newRunnerGroup("trigger-hooked", "https://hooked.example.com/")
+		other := newRunnerGroup("trigger-other", "https://other.example.com")
+
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), pollTriggers: make(chan event.GenericEvent, 10)}
+		reconciler.polledGenerations.Store(client.ObjectKeyFromObject(triggered), triggered.Generation)
+		reconciler.polledGenerations.Store(client.ObjectKeyFromObject(other), other.Generation)
+
+		reconciler.TriggerPoll(ctx, "https://hooked.example.com", "", "hooked/1234")
+
+		_, polled := reconciler.polledGenerations.Load(client.ObjectKeyFromObject(triggered))
+		Expect(polled).To(BeFalse())
+		_, polled = reconciler.polledGenerations.Load(client.ObjectKeyFromObject(other))
+		Expect(polled).To(BeTrue())
+		Expect(reconciler.pollTriggers).To(HaveLen(1))
+		Expect((<-reconciler.pollTriggers).Object.GetName()).To(Equal(triggered.Name))
+
+		// A replay another replica's receiver accepted doesn't poll again
+		reconciler.TriggerPoll(ctx, "https://hooked.example.com", "", "hooked/1234")
+		Expect(reconciler.pollTriggers).To(BeEmpty())
+	})
+
+	It("should pass the trigger on to the leader when this replica is on standby", func() {
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "gitea-secret"}, Key: "token"}
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "trigger-standby", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:                giteav1alpha1.RunnerGroupScopeGlobal,
+				GiteaURL:             "https://standby.example.com",
+				MaxActiveRunners:     1,
+				RegistrationTokenRef: &secretRef,
+				AuthTokenRef:         secretRef,
+			},
+		}
+		Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+
+		reconciler := &RunnerGroupReconciler{
+			Client:       k8sClient,
+			Scheme:       k8sClient.Scheme(),
+			pollTriggers: make(chan event.GenericEvent, 10),
+			Elected:      make(chan struct{}),
+		}
+
+		reconciler.TriggerPoll(ctx, "https://standby.example.com", "", "standby/1234")
+
+		Expect(reconciler.pollTriggers).To(BeEmpty())
+		updated := &giteav1alpha1.RunnerGroup{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(runnerGroup), updated)).To(Succeed())
+		Expect(updated.Annotations).To(HaveKeyWithValue(pollNowAnnotation, webhookPollPrefix+"standby/1234"))
+	})
+
+	It("should only wake RunnerGroups whose scope covers the job's repository", func() {
+		orgGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeOrg, Org: "MyOrg"}}
+		userGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeUser, User: "alice"}}
+		repoGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeRepo, Org: "myorg", Repo: "api"}}
+		globalGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeGlobal}}
+
+		Expect(scopeCoversRepo(orgGroup, "myorg/web")).To(BeTrue())
+		Expect(scopeCoversRepo(orgGroup, "alice/web")).To(BeFalse())
+		Expect(scopeCoversRepo(userGroup, "alice/dotfiles")).To(BeTrue())
+		Expect(scopeCoversRepo(repoGroup, "myorg/api")).To(BeTrue())
+		Expect(scopeCoversRepo(repoGroup, "myorg/web")).To(BeFalse())
+		Expect(scopeCoversRepo(globalGroup, "anyone/anything")).To(BeTrue())
+		Expect(scopeCoversRepo(repoGroup, "MyOrg/API")).To(BeTrue())
+		Expect(scopeCoversRepo(repoGroup, "")).To(BeFalse())
+		Expect(scopeCoversRepo(globalGroup, "")).To(BeTrue())
+	})
+})
+
+var _ = Describe("groupPollInterval", func() {
+	hooked := &giteav1alpha1.GiteaInstance{Spec: giteav1alpha1.GiteaInstanceSpec{
+		URL:              "https://gitea.example.com",
+		WebhookSecretRef: &giteav1alpha1.SecretKeyReference{Namespace: "ops", Name: "webhook", Key: "secret"},
+	}}
+	unhooked := &giteav1alpha1.GiteaInstance{Spec: giteav1alpha1.GiteaInstanceSpec{URL: "https://gitea.example.com"}}
+
+	It("should only fall back to slow polling when webhooks reach the operator", func() {
+		Expect((&RunnerGroupReconciler{}).groupPollInterval(hooked)).To(Equal(pollInterval))
+
+		reconciler := &RunnerGroupReconciler{WebhooksEnabled: true}
+		Expect(reconciler.groupPollInterval(hooked)).To(Equal(defaultFallbackPollInterval))
+		Expect(reconciler.groupPollInterval(unhooked)).To(Equal(pollInterval))
+		Expect(reconciler.groupPollInterval(nil)).To(Equal(pollInterval))
+	})
+
+	It("should use the instance's fallback interval, but never poll faster than the poll interval", func() {
+		reconciler := &RunnerGroupReconciler{WebhooksEnabled: true}
+		instance := hooked.DeepCopy()
+		instance.Spec.FallbackPollInterval = &metav1.Duration{Duration: 5 * time.Minute}
+		Expect(reconciler.groupPollInterval(instance)).To(Equal(5 * time.Minute))
+
+		instance.Spec.FallbackPollInterval = &metav1.Duration{Duration: time.Second}
+		Expect(reconciler.groupPollInterval(instance)).To(Equal(pollInterval))
+	})
+})
+
+var _ = Describe("EnqueueRunnerGroup", func() {
+	It("should enqueue the RunnerGroup and make it poll", func() {
+		key := types.NamespacedName{Namespace: "default", Name: "enqueued"}
+		reconciler := &RunnerGroupReconciler{pollTriggers: make(chan event.GenericEvent, 1)}
+		reconciler.polledGenerations.Store(key, int64(1))
+
+		Expect(reconciler.EnqueueRunnerGroup(key)).To(BeTrue())
+		_, polled := reconciler.polledGenerations.Load(key)
+		Expect(polled).To(BeFalse())
+		Expect(client.ObjectKeyFromObject((<-reconciler.pollTriggers).Object)).To(Equal(key))
+
+		// A full channel drops the signal instead of blocking
+		reconciler.pollTriggers <- event.GenericEvent{}
+		Expect(reconciler.EnqueueRunnerGroup(key)).To(BeFalse())
+	})
+
+	It("should poll whenever the poll-now annotation changes", func() {
+		reconciler := &RunnerGroupReconciler{}
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "annotated"}}
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeFalse())
+
+		runnerGroup.Annotations = map[string]string{pollNowAnnotation: "1"}
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeTrue())
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeFalse())
+
+		runnerGroup.Annotations[pollNowAnnotation] = "2"
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeTrue())
+		_, webhookTriggered := reconciler.webhookTriggered.Load(client.ObjectKeyFromObject(runnerGroup))
+		Expect(webhookTriggered).To(BeFalse())
+	})
+
+	It("should count webhook deliveries passed on by a standby as webhook-triggered, once each", func() {
+		reconciler := &RunnerGroupReconciler{}
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "forwarded"}}
+		key := client.ObjectKeyFromObject(runnerGroup)
+
+		runnerGroup.Annotations = map[string]string{pollNowAnnotation: webhookPollPrefix + "gitea/1234"}
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeTrue())
+		_, webhookTriggered := reconciler.webhookTriggered.LoadAndDelete(key)
+		Expect(webhookTriggered).To(BeTrue())
+
+		runnerGroup.Annotations[pollNowAnnotation] = webhookPollPrefix + "gitea/5678"
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeTrue())
+		reconciler.webhookTriggered.Delete(key)
+
+		// A replay of the first delivery through yet another replica
+		runnerGroup.Annotations[pollNowAnnotation] = webhookPollPrefix + "gitea/1234"
+		Expect(reconciler.requestedPoll(runnerGroup)).To(BeFalse())
+		_, webhookTriggered = reconciler.webhookTriggered.Load(key)
+		Expect(webhookTriggered).To(BeFalse())
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// defaultMaxJobWait is the default queue wait that fires the job wait alert
+	defaultMaxJobWait = 15 * time.Minute
+	// defaultMaxFailedRunnersPercent is the default share of failed runners that fires the failure alert
+	defaultMaxFailedRunnersPercent = 20
+	// defaultMaxGiteaUnreachable is the default Gitea outage that fires the unreachable alert
+	defaultMaxGiteaUnreachable = 10 * time.Minute
+	// prometheusRuleRecheckInterval is how long PrometheusRules are skipped once their CRD was
+	// found missing, before it is looked for again
+	prometheusRuleRecheckInterval = 10 * time.Minute
+)
+
+// prometheusRuleGVK is the Prometheus Operator's PrometheusRule, handled unstructured so the
+// operator doesn't depend on the Prometheus Operator API
+var prometheusRuleGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// alertThresholds returns the group's alert thresholds with defaults filled in
+func alertThresholds(runnerGroup *giteav1alpha1.RunnerGroup) (time.Duration, int, time.Duration) {
+	maxJobWait, maxFailedPercent, maxUnreachable := defaultMaxJobWait, defaultMaxFailedRunnersPercent, defaultMaxGiteaUnreachable
+	if alerting := runnerGroup.Spec.Alerting; alerting != nil {
+		if alerting.MaxJobWait.Duration > 0 {
+			maxJobWait = alerting.MaxJobWait.Duration
+		}
+		if alerting.MaxFailedRunnersPercent > 0 {
+			maxFailedPercent = alerting.MaxFailedRunnersPercent
+		}
+		if alerting.MaxGiteaUnreachable.Duration > 0 {
+			maxUnreachable = alerting.MaxGiteaUnreachable.Duration
+		}
+	}
+	return maxJobWait, maxFailedPercent, maxUnreachable
+}
+
+// prometheusRuleGroups builds the alerting rules for a RunnerGroup
+func prometheusRuleGroups(runnerGroup *giteav1alpha1.RunnerGroup) []any {
+	maxJobWait, maxFailedPercent, maxUnreachable := alertThresholds(runnerGroup)
+	selector := fmt.Sprintf(`namespace=%q,runnergroup=%q`, runnerGroup.Namespace, runnerGroup.Name)
+	alertLabels := map[string]any{
+		"severity":    "warning",
+		"runnergroup": runnerGroup.Name,
+	}
+
+	rules := []any{
+		map[string]any{
+			"alert":  "GiteaRunnerJobWaitTooLong",
+			"expr":   fmt.Sprintf("gitea_runner_group_oldest_queued_job_seconds{%s} > %d", selector, int64(maxJobWait.Seconds())),
+			"for":    "5m",
+			"labels": alertLabels,
+			"annotations": map[string]any{
+				"summary": fmt.Sprintf("Jobs of RunnerGroup %s/%s wait longer than %s for a runner", runnerGroup.Namespace, runnerGroup.Name, maxJobWait),
+			},
+		},
+		map[string]any{
+			"alert": "GiteaRunnerFailureRateHigh",
+			"expr": fmt.Sprintf(`100 * sum(gitea_runner_group_runners{%s,phase="failed"}) / clamp_min(sum(gitea_runner_group_runners{%s}), 1) > %d`,
+				selector, selector, maxFailedPercent),
+			"for":    "15m",
+			"labels": alertLabels,
+			"annotations": map[string]any{
+				"summary": fmt.Sprintf("More than %d%% of the runners of RunnerGroup %s/%s failed", maxFailedPercent, runnerGroup.Namespace, runnerGroup.Name),
+			},
+		},
+		map[string]any{
+			"alert":  "GiteaRunnerGiteaUnreachable",
+			"expr":   fmt.Sprintf("gitea_runner_group_gitea_unreachable_seconds{%s} > %d", selector, int64(maxUnreachable.Seconds())),
+			"labels": alertLabels,
+			"annotations": map[string]any{
+				"summary": fmt.Sprintf("RunnerGroup %s/%s can't reach Gitea for more than %s", runnerGroup.Namespace, runnerGroup.Name, maxUnreachable),
+			},
+		},
+		map[string]any{
+			"alert":  "GiteaRunnerBacklogExceeded",
+			"expr":   fmt.Sprintf("gitea_runner_group_backlog_exceeded{%s} == 1", selector),
+			"labels": alertLabels,
+			"annotations": map[string]any{
+				"summary": fmt.Sprintf("Queued jobs of RunnerGroup %s/%s have outnumbered its maxActiveRunners for more than %s; raise its capacity",
+					runnerGroup.Namespace, runnerGroup.Name, maxBacklogDuration(runnerGroup)),
+			},
+		},
+	}
+
+	return []any{map[string]any{"name": "gitea-runner-group", "rules": rules}}
+}
+
+// syncPrometheusRule keeps the RunnerGroup's PrometheusRule up to date. A missing Prometheus
+// Operator mustn't stop scaling nor flood the log: the missing CRD is logged as an error once,
+// then PrometheusRules are skipped, logged at V(1), and only looked for again every
+// prometheusRuleRecheckInterval.
+func (r *RunnerGroupReconciler) syncPrometheusRule(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, now time.Time) {
+	logger := log.FromContext(ctx)
+	if missingSince := r.prometheusRuleMissing.Load(); missingSince != nil && now.Sub(*missingSince) < prometheusRuleRecheckInterval {
+		logger.V(1).Info("Skipping PrometheusRule, the Prometheus Operator's CRD isn't installed")
+		return
+	}
+
+	err := r.reconcilePrometheusRule(ctx, runnerGroup)
+	switch {
+	case meta.IsNoMatchError(err):
+		if previous := r.prometheusRuleMissing.Swap(&now); previous == nil {
+			logger.Error(err, "PrometheusRule CRD not found, skipping alerting rules; install the Prometheus Operator or drop --enable-prometheus-rules")
+		} else {
+			logger.V(1).Info("PrometheusRule CRD still not found", "error", err.Error())
+		}
+	case err != nil:
+		logger.Error(err, "Failed to reconcile PrometheusRule")
+	default:
+		r.prometheusRuleMissing.Store(nil)
+	}
+}
+
+// reconcilePrometheusRule creates or updates the PrometheusRule alerting on the RunnerGroup
+func (r *RunnerGroupReconciler) reconcilePrometheusRule(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup) error {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(prometheusRuleGVK)
+	rule.SetNamespace(runnerGroup.Namespace)
+	rule.SetName(runnerGroup.Name + "-alerts")
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, rule, func() error {
+		labels := rule.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[runnerGroupNameLabel] = runnerGroup.Name
+		labels[managedByLabel] = "gitea-runner-operator"
+		rule.SetLabels(labels)
+		if err := unstructured.SetNestedSlice(rule.Object, prometheusRuleGroups(runnerGroup), "spec", "groups"); err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(runnerGroup, rule, r.Scheme)
+	})
+	return err
+}
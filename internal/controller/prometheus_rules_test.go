@@ -0,0 +1,110 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// noPrometheusRuleClient answers as if the PrometheusRule CRD weren't installed, counting the lookups
+type noPrometheusRuleClient struct {
+	client.Client
+	lookups int
+}
+
+func (c *noPrometheusRuleClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		c.lookups++
+		return &meta.NoKindMatchError{GroupKind: prometheusRuleGVK.GroupKind(), SearchedVersions: []string{prometheusRuleGVK.Version}}
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+var _ = Describe("syncPrometheusRule", func() {
+	It("should look for a missing PrometheusRule CRD again only after a while", func() {
+		ctx := context.Background()
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		noCRD := &noPrometheusRuleClient{Client: k8sClient}
+		reconciler := &RunnerGroupReconciler{Client: noCRD, Scheme: k8sClient.Scheme()}
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "ci"}}
+
+		reconciler.syncPrometheusRule(ctx, runnerGroup, now)
+		Expect(noCRD.lookups).To(Equal(1))
+		Expect(*reconciler.prometheusRuleMissing.Load()).To(Equal(now))
+
+		reconciler.syncPrometheusRule(ctx, runnerGroup, now.Add(time.Minute))
+		Expect(noCRD.lookups).To(Equal(1))
+
+		reconciler.syncPrometheusRule(ctx, runnerGroup, now.Add(prometheusRuleRecheckInterval))
+		Expect(noCRD.lookups).To(Equal(2))
+		Expect(*reconciler.prometheusRuleMissing.Load()).To(Equal(now.Add(prometheusRuleRecheckInterval)))
+	})
+})
+
+var _ = Describe("prometheusRuleGroups", func() {
+	exprs := func(runnerGroup *giteav1alpha1.RunnerGroup) map[string]string {
+		groups := prometheusRuleGroups(runnerGroup)
+		Expect(groups).To(HaveLen(1))
+		result := map[string]string{}
+		for _, rule := range groups[0].(map[string]any)["rules"].([]any) {
+			rule := rule.(map[string]any)
+			result[rule["alert"].(string)] = rule["expr"].(string)
+		}
+		return result
+	}
+
+	It("should use the default thresholds", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "ci"}}
+		Expect(exprs(runnerGroup)).To(Equal(map[string]string{
+			"GiteaRunnerJobWaitTooLong": `gitea_runner_group_oldest_queued_job_seconds{namespace="ci",runnergroup="linux"} > 900`,
+			"GiteaRunnerFailureRateHigh": `100 * sum(gitea_runner_group_runners{namespace="ci",runnergroup="linux",phase="failed"}) / ` +
+				`clamp_min(sum(gitea_runner_group_runners{namespace="ci",runnergroup="linux"}), 1) > 20`,
+			"GiteaRunnerGiteaUnreachable": `gitea_runner_group_gitea_unreachable_seconds{namespace="ci",runnergroup="linux"} > 600`,
+			"GiteaRunnerBacklogExceeded":  `gitea_runner_group_backlog_exceeded{namespace="ci",runnergroup="linux"} == 1`,
+		}))
+	})
+
+	It("should use the group's thresholds", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "ci"},
+			Spec: giteav1alpha1.RunnerGroupSpec{Alerting: &giteav1alpha1.AlertingSpec{
+				MaxJobWait:              metav1.Duration{Duration: 30 * time.Minute},
+				MaxFailedRunnersPercent: 10,
+			}},
+		}
+		Expect(exprs(runnerGroup)["GiteaRunnerJobWaitTooLong"]).To(HaveSuffix("> 1800"))
+		Expect(exprs(runnerGroup)["GiteaRunnerFailureRateHigh"]).To(HaveSuffix("> 10"))
+		Expect(exprs(runnerGroup)["GiteaRunnerGiteaUnreachable"]).To(HaveSuffix("> 600"))
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Group proxy", func() {
+	var runnerGroup *giteav1alpha1.RunnerGroup
+
+	BeforeEach(func() {
+		runnerGroup = &giteav1alpha1.RunnerGroup{
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL: "https://gitea.example.com",
+				Proxy:    &giteav1alpha1.ProxySpec{URL: "http://proxy.corp:3128", NoProxy: ".svc,10.0.0.0/8"},
+			},
+		}
+	})
+
+	It("should send the group's Gitea requests through its proxy", func() {
+		ctx := withGroupProxy(context.Background(), runnerGroup)
+		Expect(gitea.ProxyFromContext(ctx)).To(Equal("http://proxy.corp:3128"))
+
+		runnerGroup.Spec.Proxy = nil
+		Expect(gitea.ProxyFromContext(withGroupProxy(context.Background(), runnerGroup))).To(BeEmpty())
+	})
+
+	It("should only give runner pods the proxy if asked to", func() {
+		env := runnerEnv(runnerGroup.Spec.GiteaURL, nil, runnerGroup.Spec.Proxy)
+		Expect(env).NotTo(ContainElement(HaveField("Name", "HTTPS_PROXY")))
+
+		runnerGroup.Spec.Proxy.InjectIntoRunners = true
+		env = runnerEnv(runnerGroup.Spec.GiteaURL, nil, runnerGroup.Spec.Proxy)
+		Expect(env).To(ContainElements(
+			corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.corp:3128"},
+			corev1.EnvVar{Name: "https_proxy", Value: "http://proxy.corp:3128"},
+			corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,10.0.0.0/8"},
+			corev1.EnvVar{Name: "no_proxy", Value: ".svc,10.0.0.0/8"},
+		))
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// queuedJobsCacheTTL is how long an unused scope's queued jobs are kept
+const queuedJobsCacheTTL = 10 * time.Minute
+
+// queuedJobsKey identifies what a poll asks Gitea for: a scope of a Gitea instance, read with
+// particular credentials, since different tokens or sudo users may see different jobs
+type queuedJobsKey struct {
+	giteaURL    string
+	scope       giteav1alpha1.RunnerGroupScope
+	org         string
+	user        string
+	repo        string
+	credentials string
+}
+
+// newQueuedJobsKey returns the key of the RunnerGroup's poll. Credentials are only kept hashed.
+func newQueuedJobsKey(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, authToken string) queuedJobsKey {
+	credentials := sha256.Sum256([]byte(authToken + "\x00" + gitea.SudoFromContext(ctx)))
+	return queuedJobsKey{
+		giteaURL:    strings.TrimSuffix(runnerGroup.Spec.GiteaURL, "/"),
+		scope:       runnerGroup.Spec.Scope,
+		org:         runnerGroup.Spec.Org,
+		user:        runnerGroup.Spec.User,
+		repo:        runnerGroup.Spec.Repo,
+		credentials: hex.EncodeToString(credentials[:]),
+	}
+}
+
+// queuedJobsFetch is one crawl of a scope's queued jobs. Its result is shared by every
+// RunnerGroup polling the scope and must not be modified.
+type queuedJobsFetch struct {
+	started time.Time
+	done    chan struct{}
+	jobs    []gitea.ActionWorkflowJob
+	err     error
+}
+
+// queuedJobsCache shares the queued jobs of a scope between the RunnerGroups polling it, so N
+// groups on the same organization cost one crawl per interval instead of N. The jobs are cached
+// unfiltered; each group applies its own labels.
+type queuedJobsCache struct {
+	mu      sync.Mutex
+	fetches map[queuedJobsKey]*queuedJobsFetch
+}
+
+// get returns the scope's queued jobs from a crawl started at or after notBefore, joining one
+// in progress or starting one with fetch. shared reports whether the jobs came from a crawl
+// another poll started. Failed crawls aren't cached.
+func (c *queuedJobsCache) get(
+	ctx context.Context,
+	key queuedJobsKey,
+	notBefore time.Time,
+	fetch func() ([]gitea.ActionWorkflowJob, error),
+) (jobs []gitea.ActionWorkflowJob, shared bool, err error) {
+	now := time.Now()
+	c.mu.Lock()
+	if c.fetches == nil {
+		c.fetches = make(map[queuedJobsKey]*queuedJobsFetch)
+	}
+	for k, f := range c.fetches {
+		if now.Sub(f.started) > queuedJobsCacheTTL && isDone(f) {
+			delete(c.fetches, k)
+		}
+	}
+	if f := c.fetches[key]; f != nil && !f.started.Before(notBefore) {
+		c.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.jobs, true, f.err
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+	f := &queuedJobsFetch{started: now, done: make(chan struct{})}
+	c.fetches[key] = f
+	c.mu.Unlock()
+
+	f.jobs, f.err = fetch()
+	close(f.done)
+	if f.err != nil {
+		c.mu.Lock()
+		if c.fetches[key] == f {
+			delete(c.fetches, key)
+		}
+		c.mu.Unlock()
+	}
+	return f.jobs, false, f.err
+}
+
+// isDone reports whether the crawl finished
+func isDone(f *queuedJobsFetch) bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Shared queued jobs", func() {
+	var (
+		cache   *queuedJobsCache
+		key     queuedJobsKey
+		fetches atomic.Int32
+		fetch   func() ([]gitea.ActionWorkflowJob, error)
+	)
+
+	BeforeEach(func() {
+		cache = &queuedJobsCache{}
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL: "https://gitea.example.com/",
+				Scope:    giteav1alpha1.RunnerGroupScopeOrg,
+				Org:      "myorg",
+			},
+		}
+		key = newQueuedJobsKey(context.Background(), runnerGroup, "token")
+		fetches.Store(0)
+		fetch = func() ([]gitea.ActionWorkflowJob, error) {
+			fetches.Add(1)
+			return []gitea.ActionWorkflowJob{{ID: int64(fetches.Load())}}, nil
+		}
+	})
+
+	It("should reuse a crawl of the scope within the interval", func() {
+		jobs, shared, err := cache.get(context.Background(), key, time.Now().Add(-time.Minute), fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shared).To(BeFalse())
+		Expect(jobs).To(HaveLen(1))
+
+		jobs, shared, err = cache.get(context.Background(), key, time.Now().Add(-time.Minute), fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shared).To(BeTrue())
+		Expect(jobs[0].ID).To(Equal(int64(1)))
+		Expect(fetches.Load()).To(Equal(int32(1)))
+	})
+
+	It("should crawl again for polls that need newer jobs", func() {
+		_, _, _ = cache.get(context.Background(), key, time.Now().Add(-time.Minute), fetch)
+		jobs, shared, err := cache.get(context.Background(), key, time.Now(), fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shared).To(BeFalse())
+		Expect(jobs[0].ID).To(Equal(int64(2)))
+	})
+
+	It("should not share crawls between credentials", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{Spec: giteav1alpha1.RunnerGroupSpec{
+			GiteaURL: "https://gitea.example.com",
+			Scope:    giteav1alpha1.RunnerGroupScopeOrg,
+			Org:      "myorg",
+		}}
+		Expect(newQueuedJobsKey(context.Background(), runnerGroup, "token")).To(Equal(key))
+		Expect(newQueuedJobsKey(context.Background(), runnerGroup, "other-token")).NotTo(Equal(key))
+		Expect(newQueuedJobsKey(gitea.WithSudo(context.Background(), "alice"), runnerGroup, "token")).NotTo(Equal(key))
+	})
+
+	It("should let concurrent polls wait for the crawl in progress", func() {
+		release := make(chan struct{})
+		slowFetch := func() ([]gitea.ActionWorkflowJob, error) {
+			<-release
+			return fetch()
+		}
+
+		var wg sync.WaitGroup
+		var sharedPolls atomic.Int32
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				jobs, shared, err := cache.get(context.Background(), key, time.Now().Add(-time.Minute), slowFetch)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jobs).To(HaveLen(1))
+				if shared {
+					sharedPolls.Add(1)
+				}
+			}()
+		}
+		Eventually(func() int {
+			cache.mu.Lock()
+			defer cache.mu.Unlock()
+			return len(cache.fetches)
+		}).Should(Equal(1))
+		close(release)
+		wg.Wait()
+
+		Expect(fetches.Load()).To(Equal(int32(1)))
+		Expect(sharedPolls.Load()).To(Equal(int32(4)))
+	})
+
+	It("should not cache failed crawls", func() {
+		_, _, err := cache.get(context.Background(), key, time.Now().Add(-time.Minute), func() ([]gitea.ActionWorkflowJob, error) {
+			return nil, errors.New("gitea unreachable")
+		})
+		Expect(err).To(HaveOccurred())
+
+		_, shared, err := cache.get(context.Background(), key, time.Now().Add(-time.Minute), fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shared).To(BeFalse())
+	})
+})
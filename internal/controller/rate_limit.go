@@ -0,0 +1,55 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// maxRateLimitBackoff caps how long a RunnerGroup waits because Gitea asked it to
+const maxRateLimitBackoff = time.Hour
+
+// rateLimitRequeue returns whether Gitea throttled a request, and when to poll again: after the
+// delay Gitea asked for, but no sooner than the poll interval and no later than maxRateLimitBackoff
+func rateLimitRequeue(err error, interval time.Duration) (time.Duration, bool) {
+	if !errors.Is(err, gitea.ErrRateLimited) {
+		return 0, false
+	}
+	return min(max(gitea.RetryAfter(err), interval), maxRateLimitBackoff), true
+}
+
+// updateRateLimited records whether Gitea throttled the RunnerGroup's latest poll, and reports
+// whether it just became throttled, so the GiteaRateLimited event isn't repeated on every poll
+func (r *RunnerGroupReconciler) updateRateLimited(key types.NamespacedName, throttled bool) bool {
+	if !throttled {
+		r.rateLimited.Delete(key)
+		return false
+	}
+	_, throttledBefore := r.rateLimited.LoadOrStore(key, true)
+	return !throttledBefore
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("rateLimitRequeue", func() {
+	DescribeTable("requeueing throttled polls",
+		func(err error, expectedDelay time.Duration, expectedThrottled bool) {
+			delay, throttled := rateLimitRequeue(err, 10*time.Second)
+			Expect(throttled).To(Equal(expectedThrottled))
+			Expect(delay).To(Equal(expectedDelay))
+		},
+		Entry("other error", errors.New("connection refused"), time.Duration(0), false),
+		Entry("delay Gitea asked for", fmt.Errorf("poll: %w", &gitea.RateLimitError{RetryAfter: 5 * time.Minute}), 5*time.Minute, true),
+		Entry("no sooner than the poll interval", &gitea.RateLimitError{RetryAfter: time.Second}, 10*time.Second, true),
+		Entry("no delay given", &gitea.RateLimitError{}, 10*time.Second, true),
+		Entry("capped", &gitea.RateLimitError{RetryAfter: 24 * time.Hour}, maxRateLimitBackoff, true),
+	)
+})
+
+var _ = Describe("updateRateLimited", func() {
+	It("should only report a RunnerGroup becoming throttled", func() {
+		reconciler := &RunnerGroupReconciler{}
+		key := types.NamespacedName{Namespace: "default", Name: "throttled"}
+
+		Expect(reconciler.updateRateLimited(key, true)).To(BeTrue())
+		Expect(reconciler.updateRateLimited(key, true)).To(BeFalse())
+		Expect(reconciler.updateRateLimited(key, false)).To(BeFalse())
+		Expect(reconciler.updateRateLimited(key, true)).To(BeTrue())
+	})
+})
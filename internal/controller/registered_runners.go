@@ -0,0 +1,115 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// registeredRunnersPolls is how many poll intervals apart the runners registered in Gitea are
+// listed; listing them pages through every runner of the scope
+const registeredRunnersPolls = 3
+
+// runnerList is when a RunnerGroup's registered runners were last listed, and whether it failed
+type runnerList struct {
+	at     time.Time
+	failed bool
+}
+
+// updateRegisteredRunners counts the group's runners among those registered in its scope in Gitea
+// and records them in status.registeredRunners, every registeredRunnersPolls poll intervals. It
+// returns the group's registered runners, or nil with the count kept when Gitea isn't asked or
+// can't be. The runners listed, and whether they were ever busy, are remembered until their
+// Runner is gone, for recordFinishedRunners.
+func (r *RunnerGroupReconciler) updateRegisteredRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup,
+	runners []giteav1alpha1.Runner, now time.Time) []gitea.ActionRunner {
+	if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionGiteaReachable) {
+		return nil
+	}
+	key := client.ObjectKeyFromObject(runnerGroup)
+	var last runnerList
+	if value, ok := r.runnerLists.Load(key); ok {
+		last = value.(runnerList)
+		if now.Sub(last.at) < registeredRunnersPolls*r.currentPollInterval() {
+			return nil
+		}
+	}
+	// A token that can't be read is reported by the poll
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return nil
+	}
+	registered, err := r.GiteaClient.ListRunners(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo)
+	r.runnerLists.Store(key, runnerList{at: now, failed: err != nil})
+	if err != nil {
+		// Only the first of consecutive failures is an error; Gitea's reachability is in the conditions
+		if last.failed {
+			log.FromContext(ctx).V(1).Info("Failed to list runners registered in Gitea again", "error", err.Error())
+		} else {
+			log.FromContext(ctx).Error(err, "Failed to list runners registered in Gitea")
+		}
+		return nil
+	}
+
+	ours := make(map[string]bool, len(runners))
+	for _, runner := range runners {
+		ours[runner.Name] = true
+	}
+	seen := make(map[string]bool)
+	if value, ok := r.seenRunners.Load(key); ok {
+		for name, busy := range value.(map[string]bool) {
+			if ours[name] {
+				seen[name] = busy
+			}
+		}
+	}
+	counts := &giteav1alpha1.RegisteredRunners{}
+	groupRunners := []gitea.ActionRunner{}
+	for _, runner := range registered {
+		if !ours[runner.Name] {
+			continue
+		}
+		groupRunners = append(groupRunners, runner)
+		seen[runner.Name] = seen[runner.Name] || runner.Busy
+		if !runner.Online() {
+			counts.Offline++
+			continue
+		}
+		counts.Online++
+		if runner.Busy {
+			counts.Busy++
+		}
+	}
+	r.seenRunners.Store(key, seen)
+	runnerGroup.Status.RegisteredRunners = counts
+	return groupRunners
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// runnersGiteaClient lists the runners set in registered
+type runnersGiteaClient struct {
+	fakeGiteaClient
+	registered []gitea.ActionRunner
+	lists      int
+}
+
+func (c *runnersGiteaClient) ListRunners(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) ([]gitea.ActionRunner, error) {
+	c.lists++
+	return c.registered, nil
+}
+
+var _ = Describe("Registered runners", func() {
+	ctx := context.Background()
+
+	It("should count the group's runners registered in Gitea by state", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registered-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "admin-token"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL: "https://gitea.example.com",
+				AuthTokenRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  "token",
+				},
+			},
+		}
+		runners := []giteav1alpha1.Runner{
+			{ObjectMeta: metav1.ObjectMeta{Name: "linux-idle"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "linux-busy"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "linux-gone"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "linux-unregistered"}},
+		}
+		giteaClient := &runnersGiteaClient{registered: []gitea.ActionRunner{
+			{Name: "linux-idle", Status: "idle"},
+			{Name: "linux-busy", Status: "active", Busy: true},
+			{Name: "linux-gone", Status: "offline"},
+			{Name: "someone-elses", Status: "idle"},
+		}}
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, GiteaClient: giteaClient}
+
+		now := time.Now()
+
+		// Without a reachable Gitea, nothing is asked
+		reconciler.updateRegisteredRunners(ctx, runnerGroup, runners, now)
+		Expect(runnerGroup.Status.RegisteredRunners).To(BeNil())
+
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:   giteav1alpha1.ConditionGiteaReachable,
+			Status: metav1.ConditionTrue,
+			Reason: "VersionCheckSucceeded",
+		})
+		Expect(reconciler.updateRegisteredRunners(ctx, runnerGroup, runners, now)).To(HaveLen(3))
+		Expect(runnerGroup.Status.RegisteredRunners).To(Equal(&giteav1alpha1.RegisteredRunners{Online: 2, Offline: 1, Busy: 1}))
+
+		By("listing them again only after a few poll intervals")
+		Expect(reconciler.updateRegisteredRunners(ctx, runnerGroup, runners, now.Add(pollInterval))).To(BeNil())
+		Expect(runnerGroup.Status.RegisteredRunners).To(Equal(&giteav1alpha1.RegisteredRunners{Online: 2, Offline: 1, Busy: 1}))
+		Expect(giteaClient.lists).To(Equal(1))
+		Expect(reconciler.updateRegisteredRunners(ctx, runnerGroup, runners, now.Add(registeredRunnersPolls*pollInterval))).To(HaveLen(3))
+		Expect(giteaClient.lists).To(Equal(2))
+	})
+})
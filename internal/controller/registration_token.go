@@ -0,0 +1,136 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// defaultRegistrationTokenRefreshInterval is how long a registration token fetched from Gitea is
+// reused if the RunnerGroup doesn't set registrationTokenRefreshInterval
+const defaultRegistrationTokenRefreshInterval = time.Hour
+
+// RegistrationTokenCache holds the registration tokens fetched from Gitea for RunnerGroups without
+// a registration token Secret. The controllers creating runners share it, so a batch of runners
+// costs one request to Gitea. A nil cache fetches a token for every runner.
+type RegistrationTokenCache struct {
+	tokens sync.Map
+}
+
+// fetchedRegistrationToken is a registration token and when it was fetched
+type fetchedRegistrationToken struct {
+	token     string
+	fetchedAt time.Time
+}
+
+// get returns the group's token if it was fetched less than maxAge ago
+func (c *RegistrationTokenCache) get(key types.NamespacedName, maxAge time.Duration, now time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	value, ok := c.tokens.Load(key)
+	if !ok {
+		return "", false
+	}
+	fetched := value.(fetchedRegistrationToken)
+	if now.Sub(fetched.fetchedAt) >= maxAge {
+		return "", false
+	}
+	return fetched.token, true
+}
+
+// set stores a token just fetched for the group
+func (c *RegistrationTokenCache) set(key types.NamespacedName, token string, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.tokens.Store(key, fetchedRegistrationToken{token: token, fetchedAt: now})
+}
+
+// forget drops the token of a deleted group
+func (c *RegistrationTokenCache) forget(key types.NamespacedName) {
+	if c == nil {
+		return
+	}
+	c.tokens.Delete(key)
+}
+
+// registrationTokenRefreshInterval returns how long the group reuses a fetched registration token
+func registrationTokenRefreshInterval(runnerGroup *giteav1alpha1.RunnerGroup) time.Duration {
+	if interval := runnerGroup.Spec.RegistrationTokenRefreshInterval; interval != nil && interval.Duration > 0 {
+		return interval.Duration
+	}
+	return defaultRegistrationTokenRefreshInterval
+}
+
+// resolveRegistrationToken returns the token the group's runners register with: the one in the
+// spec.registrationToken Secret, or, if unset, one fetched from Gitea with the group's auth token.
+// A fetched token is reused from the cache until the group's refresh interval has passed.
+func resolveRegistrationToken(ctx context.Context, c client.Reader, giteaClient gitea.Client,
+	cache *RegistrationTokenCache, runnerGroup *giteav1alpha1.RunnerGroup, sources AuthTokenSources) (string, error) {
+	if runnerGroup.Spec.RegistrationTokenRef != nil {
+		token, err := readSecretValue(ctx, c, runnerGroup.Namespace, *runnerGroup.Spec.RegistrationTokenRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to get registration token from secret: %w", err)
+		}
+		return token, nil
+	}
+	if token, ok := cache.get(client.ObjectKeyFromObject(runnerGroup), registrationTokenRefreshInterval(runnerGroup), time.Now()); ok {
+		return token, nil
+	}
+	return refreshRegistrationToken(ctx, c, giteaClient, cache, runnerGroup, sources)
+}
+
+// refreshRegistrationToken fetches a registration token for the group from Gitea and caches it.
+// Gitea hands out the same token until it is reset, so refreshing picks up resets before runners
+// are spawned with a token Gitea no longer accepts.
+func refreshRegistrationToken(ctx context.Context, c client.Reader, giteaClient gitea.Client,
+	cache *RegistrationTokenCache, runnerGroup *giteav1alpha1.RunnerGroup, sources AuthTokenSources) (string, error) {
+	if giteaClient == nil {
+		return "", fmt.Errorf("no registration token secret configured and no Gitea client to fetch one")
+	}
+
+	authToken, err := readAuthToken(ctx, c, runnerGroup, sources)
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth token from secret: %w", err)
+	}
+	if runnerGroup.Spec.Sudo != "" {
+		ctx = gitea.WithSudo(ctx, runnerGroup.Spec.Sudo)
+	}
+	token, err := giteaClient.GetRegistrationToken(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch registration token from Gitea: %w", err)
+	}
+	cache.set(client.ObjectKeyFromObject(runnerGroup), token, time.Now())
+	return token, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// registrationTokenGiteaClient counts the registration tokens fetched
+type registrationTokenGiteaClient struct {
+	fakeGiteaClient
+	fetches int
+}
+
+func (c *registrationTokenGiteaClient) GetRegistrationToken(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) (string, error) {
+	c.fetches++
+	return c.fakeGiteaClient.GetRegistrationToken(ctx, giteaURL, authToken, scope, org, user, repo)
+}
+
+var _ = Describe("resolveRegistrationToken", func() {
+	ctx := context.Background()
+
+	var runnerGroup *giteav1alpha1.RunnerGroup
+
+	BeforeEach(func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registration-token-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "reg-token", "auth": "api-token"},
+		}
+		if err := k8sClient.Create(ctx, secret); err != nil && !errors.IsAlreadyExists(err) {
+			Expect(err).To(Succeed())
+		}
+		runnerGroup = &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:    giteav1alpha1.RunnerGroupScopeOrg,
+				Org:      "myorg",
+				GiteaURL: "https://gitea.example.com",
+				AuthTokenRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "registration-token-secret"},
+					Key:                  "auth",
+				},
+			},
+		}
+	})
+
+	It("should prefer the configured Secret", func() {
+		runnerGroup.Spec.RegistrationTokenRef = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "registration-token-secret"},
+			Key:                  "token",
+		}
+		Expect(resolveRegistrationToken(ctx, k8sClient, &fakeGiteaClient{}, nil, runnerGroup, AuthTokenSources{})).To(Equal("reg-token"))
+	})
+
+	It("should fetch a token from Gitea without a Secret", func() {
+		Expect(resolveRegistrationToken(ctx, k8sClient, &fakeGiteaClient{}, nil, runnerGroup, AuthTokenSources{})).To(Equal("fetched-token"))
+	})
+
+	It("should reuse a fetched token until it is refreshed", func() {
+		giteaClient := &registrationTokenGiteaClient{}
+		cache := &RegistrationTokenCache{}
+
+		Expect(resolveRegistrationToken(ctx, k8sClient, giteaClient, cache, runnerGroup, AuthTokenSources{})).To(Equal("fetched-token"))
+		Expect(resolveRegistrationToken(ctx, k8sClient, giteaClient, cache, runnerGroup, AuthTokenSources{})).To(Equal("fetched-token"))
+		Expect(giteaClient.fetches).To(Equal(1))
+
+		Expect(refreshRegistrationToken(ctx, k8sClient, giteaClient, cache, runnerGroup, AuthTokenSources{})).To(Equal("fetched-token"))
+		Expect(giteaClient.fetches).To(Equal(2))
+	})
+
+	It("should fetch again once the refresh interval passed", func() {
+		runnerGroup.Spec.RegistrationTokenRefreshInterval = &metav1.Duration{Duration: time.Minute}
+		cache := &RegistrationTokenCache{}
+		key := client.ObjectKeyFromObject(runnerGroup)
+		cache.set(key, "old-token", time.Now().Add(-2*time.Minute))
+
+		_, ok := cache.get(key, registrationTokenRefreshInterval(runnerGroup), time.Now())
+		Expect(ok).To(BeFalse())
+		Expect(resolveRegistrationToken(ctx, k8sClient, &fakeGiteaClient{}, cache, runnerGroup, AuthTokenSources{})).To(Equal("fetched-token"))
+	})
+
+	It("should fail without a Secret or a Gitea client", func() {
+		_, err := resolveRegistrationToken(ctx, k8sClient, nil, nil, runnerGroup, AuthTokenSources{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// requestLimits returns the client-side limits of requests to the instance. Without an explicit
+// burst, requestsPerSecond requests may be sent at once.
+func requestLimits(instance *giteav1alpha1.GiteaInstance) gitea.HostLimits {
+	if instance == nil || instance.Spec.RequestLimits == nil {
+		return gitea.HostLimits{}
+	}
+	spec := instance.Spec.RequestLimits
+	limits := gitea.HostLimits{
+		QPS:         float32(spec.RequestsPerSecond),
+		Burst:       spec.Burst,
+		MaxInFlight: spec.MaxInFlight,
+	}
+	if limits.Burst == 0 {
+		limits.Burst = spec.RequestsPerSecond
+	}
+	return limits
+}
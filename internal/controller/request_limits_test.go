@@ -0,0 +1,62 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Request limits", func() {
+	var instance *giteav1alpha1.GiteaInstance
+
+	BeforeEach(func() {
+		instance = &giteav1alpha1.GiteaInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitea"},
+			Spec:       giteav1alpha1.GiteaInstanceSpec{URL: "https://gitea.example.com"},
+		}
+	})
+
+	It("should not limit instances without request limits", func() {
+		Expect(requestLimits(nil)).To(Equal(gitea.HostLimits{}))
+		Expect(requestLimits(instance)).To(Equal(gitea.HostLimits{}))
+	})
+
+	It("should burst up to the request rate by default", func() {
+		instance.Spec.RequestLimits = &giteav1alpha1.RequestLimits{RequestsPerSecond: 5, MaxInFlight: 3}
+		Expect(requestLimits(instance)).To(Equal(gitea.HostLimits{QPS: 5, Burst: 5, MaxInFlight: 3}))
+
+		instance.Spec.RequestLimits.Burst = 20
+		Expect(requestLimits(instance)).To(Equal(gitea.HostLimits{QPS: 5, Burst: 20, MaxInFlight: 3}))
+	})
+
+	It("should only cap in-flight requests when no rate is set", func() {
+		instance.Spec.RequestLimits = &giteav1alpha1.RequestLimits{MaxInFlight: 2}
+		Expect(requestLimits(instance)).To(Equal(gitea.HostLimits{MaxInFlight: 2}))
+	})
+})
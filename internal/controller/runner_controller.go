@@ -0,0 +1,488 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// runnerRegistrationRecheckInterval is how often a running runner is looked up in Gitea until
+// its registration shows up
+const runnerRegistrationRecheckInterval = 15 * time.Second
+
+// RunnerReconciler reconciles a Runner object
+type RunnerReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	GiteaClient gitea.Client
+
+	// RegistrationTokens caches the registration tokens fetched from Gitea, shared with the
+	// RunnerGroupReconciler
+	RegistrationTokens *RegistrationTokenCache
+
+	// AuthTokenSources are where RunnerGroups may read their Gitea API token from besides a
+	// Secret in their namespace
+	AuthTokenSources AuthTokenSources
+
+	// PodLogs reads the logs of failed runner containers; without it failures are recorded
+	// without their log
+	PodLogs PodLogReader
+
+	// Recorder reports runner failures as events on their RunnerGroup
+	Recorder record.EventRecorder
+
+	// Workqueue tunes the controller's parallelism and retry rate
+	Workqueue WorkqueueOptions
+
+	// APIReader reads runner credentials Secrets past the cache, so credentials stored by an
+	// earlier attempt are found before the cache has seen them. Defaults to the Client.
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runners,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runners/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runners/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+
+// Reconcile creates the Job of a Runner and reports the runner's phase, pod and Gitea
+// registration. A Runner whose Job is gone, because it finished and was cleaned up or was
+// deleted by a drain or node failure recovery, is deleted.
+func (r *RunnerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	runner := &giteav1alpha1.Runner{}
+	if err := r.Get(ctx, req.NamespacedName, runner); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get Runner")
+		return ctrl.Result{}, err
+	}
+	if !runner.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, req.NamespacedName, job); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get runner Job")
+			return ctrl.Result{}, err
+		}
+		if runner.Status.JobName != "" {
+			if err := r.deregisterRunner(ctx, runner); err != nil {
+				// Gitea lists the runner as offline until an admin removes it; that doesn't
+				// block cleaning up
+				logger.Error(err, "Failed to deregister runner from Gitea")
+			}
+			logger.Info("Runner Job is gone, deleting Runner")
+			if err := r.Delete(ctx, runner); err != nil && !errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.createJob(ctx, runner)
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(runner.Namespace), client.MatchingLabels{
+		batchv1.JobNameLabel: job.Name,
+	}); err != nil {
+		logger.Error(err, "Failed to list runner pods")
+		return ctrl.Result{}, err
+	}
+	pod := newestPod(podList.Items)
+
+	statusBefore := runner.Status.DeepCopy()
+	runner.Status.JobName = job.Name
+	runner.Status.Phase = runnerPhase(job, pod)
+	runner.Status.StartTime = job.Status.StartTime
+	runner.Status.CompletionTime = job.Status.CompletionTime
+	if pod != nil {
+		runner.Status.PodName = pod.Name
+		runner.Status.NodeName = pod.Spec.NodeName
+		r.captureFailureLog(ctx, runner, pod)
+	}
+
+	var result ctrl.Result
+	if runner.Status.Phase == giteav1alpha1.RunnerPhaseRunning && runner.Status.GiteaRunnerID == 0 && r.GiteaClient != nil {
+		id, err := r.lookupGiteaRunnerID(ctx, runner)
+		if err != nil {
+			logger.Error(err, "Failed to look up runner registration in Gitea")
+		}
+		runner.Status.GiteaRunnerID = id
+		if id == 0 {
+			result.RequeueAfter = runnerRegistrationRecheckInterval
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(statusBefore, &runner.Status) {
+		if err := r.Status().Update(ctx, runner); err != nil {
+			logger.Error(err, "Failed to update Runner status")
+			return ctrl.Result{}, err
+		}
+	}
+	return result, nil
+}
+
+// createJob creates the Job running the runner, registering with the RunnerGroup's registration token
+func (r *RunnerReconciler) createJob(ctx context.Context, runner *giteav1alpha1.Runner) error {
+	logger := log.FromContext(ctx)
+
+	runnerGroup := &giteav1alpha1.RunnerGroup{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: runner.Namespace, Name: runner.Spec.RunnerGroupName}, runnerGroup); err != nil {
+		if errors.IsNotFound(err) {
+			// The Runner is garbage collected with its RunnerGroup
+			return nil
+		}
+		return fmt.Errorf("failed to get RunnerGroup: %w", err)
+	}
+	if !runnerGroup.DeletionTimestamp.IsZero() {
+		// A group being deleted starts no more runners
+		logger.Info("RunnerGroup is being deleted, deleting Runner instead of starting it")
+		return client.IgnoreNotFound(r.Delete(ctx, runner))
+	}
+	if runnerGroup.Spec.Drain {
+		// Nor does a draining group
+		logger.Info("RunnerGroup is draining, deleting Runner instead of starting it")
+		return client.IgnoreNotFound(r.Delete(ctx, runner))
+	}
+	ctx, err := withGiteaInstance(withGroupProxy(ctx, runnerGroup), r.Client, runnerGroup.Spec.GiteaURL)
+	if err != nil {
+		return err
+	}
+
+	registrationToken, err := resolveRegistrationToken(ctx, r.Client, r.GiteaClient, r.RegistrationTokens, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return err
+	}
+
+	// With per-runner credentials the pod gets its own runner token instead of the shared one
+	var giteaRunnerID int64
+	if runnerGroup.Spec.PerRunnerCredentials && r.GiteaClient != nil {
+		if giteaRunnerID, err = r.ensureRunnerCredentials(ctx, runnerGroup, runner, registrationToken); err != nil {
+			return err
+		}
+		registrationToken = ""
+	}
+
+	job := constructRunnerJob(runnerGroup, runner, registrationToken)
+	if giteaRunnerID != 0 {
+		mountRunnerCredentials(job, runner.Name)
+	}
+	if err := ctrl.SetControllerReference(runner, job, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create runner Job: %w", err)
+	}
+	logger.Info("Created runner Job", "jobName", job.Name, "giteaJobID", runner.Spec.GiteaJobID)
+
+	runner.Status.JobName = job.Name
+	runner.Status.Phase = giteav1alpha1.RunnerPhasePending
+	if giteaRunnerID != 0 {
+		runner.Status.GiteaRunnerID = giteaRunnerID
+	}
+	return r.Status().Update(ctx, runner)
+}
+
+// lookupGiteaRunnerID returns the ID the runner registered with in Gitea, or 0 if it isn't
+// registered yet
+func (r *RunnerReconciler) lookupGiteaRunnerID(ctx context.Context, runner *giteav1alpha1.Runner) (int64, error) {
+	runnerGroup := &giteav1alpha1.RunnerGroup{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: runner.Namespace, Name: runner.Spec.RunnerGroupName}, runnerGroup); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	ctx, err := withGiteaInstance(withGroupProxy(ctx, runnerGroup), r.Client, runnerGroup.Spec.GiteaURL)
+	if err != nil {
+		return 0, err
+	}
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return 0, err
+	}
+	if runnerGroup.Spec.Sudo != "" {
+		ctx = gitea.WithSudo(ctx, runnerGroup.Spec.Sudo)
+	}
+	registered, err := r.GiteaClient.GetRunner(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo, runner.Name)
+	if err != nil || registered == nil {
+		return 0, err
+	}
+	return registered.ID, nil
+}
+
+// deregisterRunner removes the runner from Gitea once its Job is gone, so finished runners don't
+// pile up there. Gitea removes ephemeral runners after their job by itself, but not on versions
+// before 1.23, nor runners whose pod died mid-job.
+func (r *RunnerReconciler) deregisterRunner(ctx context.Context, runner *giteav1alpha1.Runner) error {
+	if r.GiteaClient == nil || !mayBeRegistered(runner) {
+		return nil
+	}
+	runnerGroup := &giteav1alpha1.RunnerGroup{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: runner.Namespace, Name: runner.Spec.RunnerGroupName}, runnerGroup); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ctx, err := withGiteaInstance(withGroupProxy(ctx, runnerGroup), r.Client, runnerGroup.Spec.GiteaURL)
+	if err != nil {
+		return err
+	}
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return err
+	}
+	if runnerGroup.Spec.Sudo != "" {
+		ctx = gitea.WithSudo(ctx, runnerGroup.Spec.Sudo)
+	}
+	return deregisterGiteaRunner(ctx, r.GiteaClient, runnerGroup, authToken, runner)
+}
+
+// mayBeRegistered reports whether the runner may have registered in Gitea: it did if its Gitea
+// ID is known, and could have if it ever had a pod
+func mayBeRegistered(runner *giteav1alpha1.Runner) bool {
+	return runner.Status.GiteaRunnerID != 0 || runner.Status.PodName != ""
+}
+
+// deregisterGiteaRunner removes the runner from the group's scope in Gitea by its Gitea ID, or by
+// name if the ID wasn't seen. ctx carries the group's Gitea settings.
+func deregisterGiteaRunner(ctx context.Context, giteaClient gitea.Client, runnerGroup *giteav1alpha1.RunnerGroup,
+	authToken string, runner *giteav1alpha1.Runner) error {
+	if runner.Status.GiteaRunnerID != 0 {
+		return giteaClient.DeleteRunner(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+			runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo, runner.Status.GiteaRunnerID)
+	}
+	return giteaClient.DeregisterRunner(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo, runner.Name)
+}
+
+// runnerPhase derives a runner's phase from its Job and latest pod
+func runnerPhase(job *batchv1.Job, pod *corev1.Pod) giteav1alpha1.RunnerPhase {
+	switch {
+	case job.Status.CompletionTime != nil:
+		return giteav1alpha1.RunnerPhaseSucceeded
+	case jobFailed(job):
+		return giteav1alpha1.RunnerPhaseFailed
+	case pod != nil && pod.Status.Phase == corev1.PodRunning:
+		return giteav1alpha1.RunnerPhaseRunning
+	default:
+		return giteav1alpha1.RunnerPhasePending
+	}
+}
+
+// newestPod returns the most recently created pod, or nil if there are none
+func newestPod(pods []corev1.Pod) *corev1.Pod {
+	var newest *corev1.Pod
+	for i := range pods {
+		if newest == nil || newest.CreationTimestamp.Before(&pods[i].CreationTimestamp) {
+			newest = &pods[i]
+		}
+	}
+	return newest
+}
+
+// runnersAwaitingJob counts the Runners whose Job hasn't been created yet. They already take
+// one of their RunnerGroup's slots.
+func runnersAwaitingJob(runners []giteav1alpha1.Runner, jobs []batchv1.Job) int {
+	jobNames := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		jobNames[job.Name] = true
+	}
+	count := 0
+	for _, runner := range runners {
+		if runner.Status.JobName == "" && runner.DeletionTimestamp.IsZero() && !jobNames[runner.Name] {
+			count++
+		}
+	}
+	return count
+}
+
+// provisionedRunnerLabelPrefix starts the label the operator registers runners with besides
+// their RunnerGroup's labels, followed by the group's UID
+const provisionedRunnerLabelPrefix = "gitea-runner-operator-"
+
+// provisionedRunnerLabel returns the label the runners of the RunnerGroup register with besides
+// the group's labels. No job asks for it; it marks the runners as the group's, so the stale
+// runner collector doesn't take runners registered by others that happen to be named alike.
+func provisionedRunnerLabel(runnerGroup *giteav1alpha1.RunnerGroup) string {
+	return provisionedRunnerLabelPrefix + string(runnerGroup.UID)
+}
+
+// registeredLabels returns the labels a runner of the RunnerGroup registers with: its labels
+// and provisionedRunnerLabel. Without labels runners register with act_runner's defaults, which
+// the marker would replace, so it is left out.
+func registeredLabels(runnerGroup *giteav1alpha1.RunnerGroup, labels []string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	return append(slices.Clone(labels), provisionedRunnerLabel(runnerGroup))
+}
+
+// runnerEnv returns the runner container's environment shared by all runners with the labels
+func runnerEnv(giteaURL string, labels []string, proxy *giteav1alpha1.ProxySpec) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{
+		{Name: "GITEA_INSTANCE_URL", Value: giteaURL},
+		{Name: "GITEA_RUNNER_EPHEMERAL", Value: "true"},
+		{Name: "DOCKER_HOST", Value: "tcp://localhost:2376"},
+		{Name: "DOCKER_CERT_PATH", Value: "/certs/client"},
+		{Name: "DOCKER_TLS_VERIFY", Value: "1"},
+	}
+	if len(labels) > 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: "GITEA_RUNNER_LABELS", Value: strings.Join(labels, ",")})
+	}
+	if proxy != nil && proxy.InjectIntoRunners {
+		// Tools disagree on the case they read these in, so both are set
+		for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY"} {
+			envVars = append(envVars,
+				corev1.EnvVar{Name: name, Value: proxy.URL},
+				corev1.EnvVar{Name: strings.ToLower(name), Value: proxy.URL})
+		}
+		if proxy.NoProxy != "" {
+			envVars = append(envVars,
+				corev1.EnvVar{Name: "NO_PROXY", Value: proxy.NoProxy},
+				corev1.EnvVar{Name: "no_proxy", Value: proxy.NoProxy})
+		}
+	}
+	return envVars
+}
+
+// constructRunnerJob creates the Job running a Runner of the RunnerGroup. The Job, and the
+// runner it registers in Gitea, are named after the Runner. Without a registration token the
+// runner must be given its credentials with mountRunnerCredentials.
+func constructRunnerJob(runnerGroup *giteav1alpha1.RunnerGroup, runner *giteav1alpha1.Runner, registrationToken string) *batchv1.Job {
+	var envVars []corev1.EnvVar
+	if registrationToken != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "GITEA_RUNNER_REGISTRATION_TOKEN", Value: registrationToken})
+	}
+	envVars = append(envVars, corev1.EnvVar{Name: "GITEA_RUNNER_NAME", Value: runner.Name})
+	envVars = append(envVars, runnerEnv(runnerGroup.Spec.GiteaURL, registeredLabels(runnerGroup, runner.Spec.Labels), runnerGroup.Spec.Proxy)...)
+
+	// Construct Job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runner.Name,
+			Namespace: runner.Namespace,
+			Labels: map[string]string{
+				"app":                runnerGroup.Name,
+				runnerGroupNameLabel: runnerGroup.Name,
+				managedByLabel:       "gitea-runner-operator",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: ptr.To(int32(600)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						runnerGroupNameLabel: runnerGroup.Name,
+						managedByLabel:       "gitea-runner-operator",
+					},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					NodeSelector:  runner.Spec.NodeSelector,
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup: ptr.To(int64(1000)),
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "runner",
+							Image:           runner.Spec.Image,
+							ImagePullPolicy: corev1.PullAlways,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: ptr.To(true),
+							},
+							Env: envVars,
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "runner-data", MountPath: "/data"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "runner-data",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if runner.Spec.GiteaJobID != 0 {
+		metav1.SetMetaDataAnnotation(&job.ObjectMeta, giteaJobIDAnnotation, strconv.FormatInt(runner.Spec.GiteaJobID, 10))
+	}
+	if runner.Spec.Repository != "" {
+		metav1.SetMetaDataAnnotation(&job.ObjectMeta, repositoryAnnotation, runner.Spec.Repository)
+	}
+	applyCostCenterLabels(job, runnerGroup.Spec.CostCenterLabels)
+	applySpotProfile(&job.Spec.Template.Spec, runnerGroup.Spec.Spot)
+	applyNodeAutoscaling(job, runnerGroup.Spec.NodeAutoscaling)
+
+	return job
+}
+
+// runnerForPod maps a runner pod to the Runner named after its Job
+func runnerForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[managedByLabel] == "" || labels[batchv1.JobNameLabel] == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: labels[batchv1.JobNameLabel]}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RunnerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&giteav1alpha1.Runner{}).
+		Owns(&batchv1.Job{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(runnerForPod)).
+		Named("runner").
+		WithOptions(r.Workqueue.controllerOptions()).
+		Complete(r)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// deletingGiteaClient lists the given registered runners and records the IDs of the runners
+// deleted from Gitea
+type deletingGiteaClient struct {
+	fakeGiteaClient
+	registered []gitea.ActionRunner
+	deleted    []int64
+}
+
+func (c *deletingGiteaClient) ListRunners(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) ([]gitea.ActionRunner, error) {
+	return c.registered, nil
+}
+
+func (c *deletingGiteaClient) DeleteRunner(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string, id int64) error {
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+// secretConflictClient fails creating Secrets as if another reconcile created them first
+type secretConflictClient struct {
+	client.Client
+}
+
+func (c *secretConflictClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		return errors.NewAlreadyExists(corev1.Resource("secrets"), obj.GetName())
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("Runner Controller", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "runner-group-abc", Namespace: "default"}
+
+	BeforeEach(func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "runner-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "reg-token", "auth": "api-token"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		group := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "runner-group", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:            giteav1alpha1.RunnerGroupScopeGlobal,
+				GiteaURL:         "https://gitea.example.com",
+				MaxActiveRunners: 1,
+				RegistrationTokenRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "runner-secret"},
+					Key:                  "token",
+				},
+				AuthTokenRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "runner-secret"},
+					Key:                  "auth",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, group)).To(Succeed())
+		runner := &giteav1alpha1.Runner{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: giteav1alpha1.RunnerSpec{
+				RunnerGroupName: "runner-group",
+				GiteaJobID:      42,
+				Repository:      "org/app",
+				Labels:          []string{"ubuntu-latest"},
+				NodeSelector:    map[string]string{corev1.LabelArchStable: "arm64"},
+				Image:           "act_runner:arm64",
+			},
+		}
+		Expect(k8sClient.Create(ctx, runner)).To(Succeed())
+
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, runner))).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &batchv1.Job{ObjectMeta: runner.ObjectMeta},
+				client.PropagationPolicy(metav1.DeletePropagationBackground)))).To(Succeed())
+			Expect(k8sClient.Delete(ctx, group)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		})
+	})
+
+	It("should create the runner Job and delete the Runner once the Job is gone", func() {
+		reconciler := &RunnerReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: &fakeGiteaClient{}}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, key, job)).To(Succeed())
+		Expect(job.OwnerReferences).To(HaveLen(1))
+		Expect(job.OwnerReferences[0].Kind).To(Equal("Runner"))
+		Expect(job.Labels).To(HaveKeyWithValue(runnerGroupNameLabel, "runner-group"))
+		Expect(job.Annotations).To(HaveKeyWithValue(giteaJobIDAnnotation, "42"))
+		Expect(job.Annotations).To(HaveKeyWithValue(repositoryAnnotation, "org/app"))
+		Expect(job.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{corev1.LabelArchStable: "arm64"}))
+		container := job.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal("act_runner:arm64"))
+		group := &giteav1alpha1.RunnerGroup{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "runner-group", Namespace: "default"}, group)).To(Succeed())
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: "GITEA_RUNNER_NAME", Value: key.Name},
+			corev1.EnvVar{Name: "GITEA_RUNNER_REGISTRATION_TOKEN", Value: "reg-token"},
+			corev1.EnvVar{Name: "GITEA_RUNNER_LABELS", Value: "ubuntu-latest," + provisionedRunnerLabel(group)},
+		))
+
+		runner := &giteav1alpha1.Runner{}
+		Expect(k8sClient.Get(ctx, key, runner)).To(Succeed())
+		Expect(runner.Status.JobName).To(Equal(key.Name))
+		Expect(runner.Status.Phase).To(Equal(giteav1alpha1.RunnerPhasePending))
+
+		By("deleting the Runner after its Job is deleted")
+		Expect(k8sClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		err = k8sClient.Get(ctx, key, runner)
+		Expect(errors.IsNotFound(err) || !runner.DeletionTimestamp.IsZero()).To(BeTrue())
+	})
+
+	It("should hand the runner its own credentials instead of the registration token", func() {
+		group := &giteav1alpha1.RunnerGroup{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "runner-group", Namespace: "default"}, group)).To(Succeed())
+		group.Spec.PerRunnerCredentials = true
+		Expect(k8sClient.Update(ctx, group)).To(Succeed())
+
+		reconciler := &RunnerReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: &fakeGiteaClient{}}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, key, secret)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+		})
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+		var registration runnerRegistration
+		Expect(json.Unmarshal(secret.Data[runnerStateFile], &registration)).To(Succeed())
+		Expect(registration.ID).To(Equal(int64(7)))
+		Expect(registration.Token).To(Equal("runner-token"))
+		Expect(registration.Address).To(Equal("https://gitea.example.com"))
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, key, job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.InitContainers).To(HaveLen(1))
+		for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+			Expect(env.Name).NotTo(Equal("GITEA_RUNNER_REGISTRATION_TOKEN"))
+		}
+
+		runner := &giteav1alpha1.Runner{}
+		Expect(k8sClient.Get(ctx, key, runner)).To(Succeed())
+		Expect(runner.Status.GiteaRunnerID).To(Equal(int64(7)))
+	})
+
+	It("should deregister the runner from Gitea when its credentials can't be stored", func() {
+		group := &giteav1alpha1.RunnerGroup{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "runner-group", Namespace: "default"}, group)).To(Succeed())
+		group.Spec.PerRunnerCredentials = true
+		Expect(k8sClient.Update(ctx, group)).To(Succeed())
+
+		giteaClient := &deletingGiteaClient{}
+		reconciler := &RunnerReconciler{Client: &secretConflictClient{Client: k8sClient}, Scheme: k8sClient.Scheme(), GiteaClient: giteaClient, APIReader: k8sClient}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).To(HaveOccurred())
+		Expect(giteaClient.deleted).To(Equal([]int64{7}))
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, key, &batchv1.Job{}))).To(BeTrue())
+	})
+
+	It("should deregister the runner from Gitea once its Job is gone", func() {
+		group := &giteav1alpha1.RunnerGroup{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "runner-group", Namespace: "default"}, group)).To(Succeed())
+		group.Spec.PerRunnerCredentials = true
+		Expect(k8sClient.Update(ctx, group)).To(Succeed())
+
+		giteaClient := &deletingGiteaClient{}
+		reconciler := &RunnerReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: giteaClient}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}))).To(Succeed())
+		})
+		Expect(giteaClient.deleted).To(BeEmpty())
+
+		job := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, key, job)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(giteaClient.deleted).To(Equal([]int64{7}))
+	})
+})
+
+var _ = Describe("runnerPhase", func() {
+	It("should derive the phase from the Job and its pod", func() {
+		done := metav1.Now()
+		running := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+		failed := &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+		}}}
+
+		Expect(runnerPhase(&batchv1.Job{}, nil)).To(Equal(giteav1alpha1.RunnerPhasePending))
+		Expect(runnerPhase(&batchv1.Job{}, &corev1.Pod{})).To(Equal(giteav1alpha1.RunnerPhasePending))
+		Expect(runnerPhase(&batchv1.Job{}, running)).To(Equal(giteav1alpha1.RunnerPhaseRunning))
+		Expect(runnerPhase(&batchv1.Job{Status: batchv1.JobStatus{CompletionTime: &done}}, running)).
+			To(Equal(giteav1alpha1.RunnerPhaseSucceeded))
+		Expect(runnerPhase(failed, nil)).To(Equal(giteav1alpha1.RunnerPhaseFailed))
+	})
+})
+
+var _ = Describe("runnersAwaitingJob", func() {
+	It("should count Runners without a Job", func() {
+		runners := []giteav1alpha1.Runner{
+			{ObjectMeta: metav1.ObjectMeta{Name: "new"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "created"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "reported"}, Status: giteav1alpha1.RunnerStatus{JobName: "reported"}},
+		}
+		jobs := []batchv1.Job{{ObjectMeta: metav1.ObjectMeta{Name: "created"}}}
+		Expect(runnersAwaitingJob(runners, jobs)).To(Equal(1))
+	})
+})
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+const (
+	// runnerStateFile is the file act_runner keeps its registration in. A runner whose data
+	// directory already has it connects with the credentials in it instead of registering.
+	runnerStateFile = ".runner"
+	// runnerCredentialsMountPath is where the credentials Secret is mounted in the init container
+	runnerCredentialsMountPath = "/etc/gitea-runner"
+)
+
+// runnerRegistration is act_runner's state file
+type runnerRegistration struct {
+	Warning   string   `json:"WARNING"`
+	ID        int64    `json:"id"`
+	UUID      string   `json:"uuid"`
+	Name      string   `json:"name"`
+	Token     string   `json:"token"`
+	Address   string   `json:"address"`
+	Labels    []string `json:"labels"`
+	Ephemeral bool     `json:"ephemeral"`
+}
+
+// ensureRunnerCredentials registers the runner in Gitea with the registration token and stores
+// the credentials it connects with in a Secret named after and owned by the Runner, so the pod
+// never sees the shared registration token. Credentials of an earlier attempt are reused rather
+// than registering the runner twice. It returns the runner's Gitea ID.
+func (r *RunnerReconciler) ensureRunnerCredentials(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup,
+	runner *giteav1alpha1.Runner, registrationToken string) (int64, error) {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	secret := &corev1.Secret{}
+	err := reader.Get(ctx, client.ObjectKeyFromObject(runner), secret)
+	if err == nil {
+		var registration runnerRegistration
+		if err := json.Unmarshal(secret.Data[runnerStateFile], &registration); err != nil {
+			return 0, fmt.Errorf("failed to decode runner credentials: %w", err)
+		}
+		return registration.ID, nil
+	}
+	if !errors.IsNotFound(err) {
+		return 0, fmt.Errorf("failed to get runner credentials: %w", err)
+	}
+
+	labels := registeredLabels(runnerGroup, runner.Spec.Labels)
+	registered, err := r.GiteaClient.RegisterRunner(ctx, runnerGroup.Spec.GiteaURL, registrationToken, runner.Name, labels, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register runner in Gitea: %w", err)
+	}
+	state, err := json.Marshal(runnerRegistration{
+		Warning:   "This file is generated by gitea-runner-operator; the token in it authenticates the runner",
+		ID:        registered.ID,
+		UUID:      registered.UUID,
+		Name:      registered.Name,
+		Token:     registered.Token,
+		Address:   runnerGroup.Spec.GiteaURL,
+		Labels:    labels,
+		Ephemeral: registered.Ephemeral,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runner.Name,
+			Namespace: runner.Namespace,
+			Labels: map[string]string{
+				runnerGroupNameLabel: runnerGroup.Name,
+				managedByLabel:       "gitea-runner-operator",
+			},
+		},
+		Data: map[string][]byte{runnerStateFile: state},
+	}
+	if err := ctrl.SetControllerReference(runner, secret, r.Scheme); err != nil {
+		return 0, err
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		// The credentials are lost without the Secret; don't leave their runner behind in Gitea.
+		// If another attempt stored its own credentials first, the next reconcile reuses those.
+		if deleteErr := r.deleteRegisteredRunner(ctx, runnerGroup, registered.ID); deleteErr != nil {
+			log.FromContext(ctx).Error(deleteErr, "Failed to deregister runner whose credentials couldn't be stored",
+				"giteaRunnerID", registered.ID)
+		}
+		return 0, fmt.Errorf("failed to create runner credentials Secret: %w", err)
+	}
+	return registered.ID, nil
+}
+
+// deleteRegisteredRunner removes the runner with the Gitea ID from the group's scope in Gitea.
+// ctx carries the group's Gitea settings.
+func (r *RunnerReconciler) deleteRegisteredRunner(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, id int64) error {
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		return err
+	}
+	if runnerGroup.Spec.Sudo != "" {
+		ctx = gitea.WithSudo(ctx, runnerGroup.Spec.Sudo)
+	}
+	return r.GiteaClient.DeleteRunner(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo, id)
+}
+
+// mountRunnerCredentials makes the runner Job start with the credentials in the Secret: an init
+// container copies the state file into the runner's data directory, where act_runner may update it
+func mountRunnerCredentials(job *batchv1.Job, secretName string) {
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "runner-credentials",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+		},
+	})
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:    "credentials",
+		Image:   podSpec.Containers[0].Image,
+		Command: []string{"cp", path.Join(runnerCredentialsMountPath, runnerStateFile), path.Join("/data", runnerStateFile)},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "runner-data", MountPath: "/data"},
+			{Name: "runner-credentials", MountPath: runnerCredentialsMountPath, ReadOnly: true},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultRunnerImage is the runner image used when neither --runner-images nor the operator
+// config set an image
+const defaultRunnerImage = "gitea/act_runner:nightly-dind-rootless"
+
+// ParseRunnerImages parses a comma-separated list of arch=image pairs, e.g.
+// "amd64=gitea/act_runner:nightly-dind-rootless,arm64=registry.example.com/act_runner:arm64"
+func ParseRunnerImages(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	images := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		arch, image, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || arch == "" || image == "" {
+			return nil, fmt.Errorf("invalid runner image %q, expected arch=image", pair)
+		}
+		images[arch] = image
+	}
+	return images, nil
+}
+
+// resolveRunnerImage picks the runner image for the architecture a runner is scheduled to,
+// defaultImage when no per-arch images are configured. A runner whose node selector doesn't pin an architecture is pinned to defaultArch when
+// per-arch images are configured, so its image always matches the node it runs on. The
+// returned node selector is a copy if it was changed. It fails if the pinned architecture
+// has no configured image.
+func resolveRunnerImage(images map[string]string, defaultImage, defaultArch string, nodeSelector map[string]string) (string, string, map[string]string, error) {
+	arch := nodeSelector[corev1.LabelArchStable]
+	if len(images) == 0 {
+		return defaultImage, arch, nodeSelector, nil
+	}
+	if arch == "" {
+		arch = defaultArch
+		nodeSelector = maps.Clone(nodeSelector)
+		if nodeSelector == nil {
+			nodeSelector = make(map[string]string)
+		}
+		nodeSelector[corev1.LabelArchStable] = arch
+	}
+	image, ok := images[arch]
+	if !ok {
+		return "", arch, nil, fmt.Errorf("no runner image configured for architecture %q", arch)
+	}
+	return image, arch, nodeSelector, nil
+}
+
+// archSchedulable reports whether any node runs the architecture, so runners pinned to it
+// aren't created just to stay pending
+func (r *RunnerGroupReconciler) archSchedulable(ctx context.Context, arch string) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels{corev1.LabelArchStable: arch}, client.Limit(1)); err != nil {
+		return false, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	return len(nodes.Items) > 0, nil
+}
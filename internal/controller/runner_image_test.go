@@ -0,0 +1,90 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Runner images per architecture", func() {
+	images := map[string]string{"amd64": "act_runner:amd64", "arm64": "act_runner:arm64"}
+
+	It("should parse arch=image pairs", func() {
+		Expect(ParseRunnerImages("amd64=act_runner:amd64, arm64=act_runner:arm64")).To(Equal(images))
+		Expect(ParseRunnerImages("")).To(BeNil())
+		_, err := ParseRunnerImages("amd64")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should use the default image when no images are configured", func() {
+		image, arch, nodeSelector, err := resolveRunnerImage(nil, defaultRunnerImage, "amd64", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(image).To(Equal(defaultRunnerImage))
+		Expect(arch).To(BeEmpty())
+		Expect(nodeSelector).To(BeNil())
+	})
+
+	It("should pick the image of the architecture the node selector pins", func() {
+		selector := map[string]string{corev1.LabelArchStable: "arm64"}
+		image, arch, nodeSelector, err := resolveRunnerImage(images, defaultRunnerImage, "amd64", selector)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(image).To(Equal("act_runner:arm64"))
+		Expect(arch).To(Equal("arm64"))
+		Expect(nodeSelector).To(Equal(selector))
+	})
+
+	It("should pin unpinned runners to the default architecture", func() {
+		selector := map[string]string{"disk": "ssd"}
+		image, arch, nodeSelector, err := resolveRunnerImage(images, defaultRunnerImage, "amd64", selector)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(image).To(Equal("act_runner:amd64"))
+		Expect(arch).To(Equal("amd64"))
+		Expect(nodeSelector).To(Equal(map[string]string{"disk": "ssd", corev1.LabelArchStable: "amd64"}))
+		Expect(selector).NotTo(HaveKey(corev1.LabelArchStable))
+	})
+
+	It("should reject architectures without an image", func() {
+		_, _, _, err := resolveRunnerImage(images, defaultRunnerImage, "amd64", map[string]string{corev1.LabelArchStable: "s390x"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only report architectures that nodes run", func() {
+		ctx := context.Background()
+		reconciler := &RunnerGroupReconciler{Client: k8sClient}
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "arm-node",
+			Labels: map[string]string{corev1.LabelArchStable: "arm64"},
+		}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, node)).To(Succeed()) })
+
+		Expect(reconciler.archSchedulable(ctx, "arm64")).To(BeTrue())
+		Expect(reconciler.archSchedulable(ctx, "riscv64")).To(BeFalse())
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// expiredRunnerJobs returns the unfinished runner Jobs created longer than lifetime ago
+func expiredRunnerJobs(jobs []batchv1.Job, lifetime time.Duration, now time.Time) []*batchv1.Job {
+	var expired []*batchv1.Job
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime != nil || job.DeletionTimestamp != nil {
+			continue
+		}
+		if now.Sub(job.CreationTimestamp.Time) > lifetime {
+			expired = append(expired, job)
+		}
+	}
+	return expired
+}
+
+// retireExpiredRunners deletes the runner Jobs older than the group's maximum runner lifetime, so
+// their slots go to fresh runners. It returns the names of the Jobs it deleted.
+func (r *RunnerGroupReconciler) retireExpiredRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job, now time.Time) (map[string]bool, error) {
+	logger := log.FromContext(ctx)
+	lifetime := runnerGroup.Spec.MaxRunnerLifetime.Duration
+
+	retired := make(map[string]bool)
+	for _, job := range expiredRunnerJobs(jobs, lifetime, now) {
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return retired, fmt.Errorf("failed to delete runner Job %s: %w", job.Name, err)
+		}
+		retired[job.Name] = true
+		age := now.Sub(job.CreationTimestamp.Time).Round(time.Second)
+		logger.Info("Retired runner past its maximum lifetime", "job", job.Name, "age", age, "maxRunnerLifetime", lifetime)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "RunnerRetired",
+				"Retired runner %s after %s, exceeding the maximum lifetime of %s", job.Name, age, lifetime)
+		}
+	}
+	return retired, nil
+}
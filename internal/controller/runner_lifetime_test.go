@@ -0,0 +1,58 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Maximum runner lifetime", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	jobCreatedAt := func(name string, created time.Time) batchv1.Job {
+		return batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	}
+
+	It("should only expire unfinished runners older than the lifetime", func() {
+		finished := jobCreatedAt("finished", now.Add(-3*time.Hour))
+		finished.Status.CompletionTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+		deleting := jobCreatedAt("deleting", now.Add(-3*time.Hour))
+		deleting.DeletionTimestamp = &metav1.Time{Time: now}
+		jobs := []batchv1.Job{
+			jobCreatedAt("young", now.Add(-30*time.Minute)),
+			jobCreatedAt("old", now.Add(-2*time.Hour)),
+			finished,
+			deleting,
+		}
+
+		expired := expiredRunnerJobs(jobs, time.Hour, now)
+		Expect(expired).To(HaveLen(1))
+		Expect(expired[0].Name).To(Equal("old"))
+	})
+})
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// runnerPhaseCounts is how many of a RunnerGroup's runner Jobs are in each phase
+type runnerPhaseCounts struct {
+	pending int
+	running int
+	failed  int
+}
+
+// countRunnersByPhase sorts unfinished runner Jobs into pending (no ready pod yet),
+// running (a ready pod) and failed
+func countRunnersByPhase(jobs []batchv1.Job) runnerPhaseCounts {
+	var counts runnerPhaseCounts
+	for _, job := range jobs {
+		if job.Status.CompletionTime != nil {
+			continue
+		}
+		switch {
+		case jobFailed(&job):
+			counts.failed++
+		case ptr.Deref(job.Status.Ready, 0) > 0:
+			counts.running++
+		default:
+			counts.pending++
+		}
+	}
+	return counts
+}
+
+// jobFailed reports whether the Job has a Failed condition
+func jobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// desiredRunners is the active runners plus one for every queued job that has no runner yet and
+// isn't left to a higher-priority group, capped at the group's capacity
+func desiredRunners(queuedJobs []gitea.ActionWorkflowJob, deferredJobs map[int64]bool, spawnedJobs *sync.Map, activeRunners, maxActiveRunners int, now time.Time) int {
+	desired := activeRunners + waitingJobs(queuedJobs, deferredJobs, spawnedJobs, now)
+	return min(desired, max(maxActiveRunners, activeRunners))
+}
+
+// polledJobs is what a RunnerGroup's last poll found: its matching queued jobs and the ones it
+// left to higher-priority groups
+type polledJobs struct {
+	jobs     []gitea.ActionWorkflowJob
+	deferred map[int64]bool
+}
+
+// waitingJobs counts the queued jobs that have no runner yet and aren't left to a higher-priority group
+func waitingJobs(queuedJobs []gitea.ActionWorkflowJob, deferredJobs map[int64]bool, spawnedJobs *sync.Map, now time.Time) int {
+	waiting := 0
+	for _, job := range queuedJobs {
+		if jobWaiting(job, deferredJobs, spawnedJobs, now) {
+			waiting++
+		}
+	}
+	return waiting
+}
+
+// jobWaiting reports whether the queued job has no runner yet and isn't left to a higher-priority group
+func jobWaiting(job gitea.ActionWorkflowJob, deferredJobs map[int64]bool, spawnedJobs *sync.Map, now time.Time) bool {
+	if deferredJobs[job.ID] {
+		return false
+	}
+	value, loaded := spawnedJobs.Load(job.ID)
+	return !loaded || now.Sub(value.(time.Time)) >= spawnRetryTimeout
+}
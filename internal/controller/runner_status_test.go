@@ -0,0 +1,66 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Runner status", func() {
+	It("should count runner Jobs by phase", func() {
+		now := metav1.Now()
+		jobs := []batchv1.Job{
+			{},
+			{Status: batchv1.JobStatus{Ready: ptr.To(int32(1))}},
+			{Status: batchv1.JobStatus{Ready: ptr.To(int32(1))}},
+			{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			}}},
+			{Status: batchv1.JobStatus{CompletionTime: &now}},
+		}
+		Expect(countRunnersByPhase(jobs)).To(Equal(runnerPhaseCounts{pending: 1, running: 2, failed: 1}))
+	})
+
+	It("should want a runner for every queued job without one, up to the capacity", func() {
+		now := time.Now()
+		var spawned sync.Map
+		spawned.Store(int64(1), now.Add(-time.Minute))
+		spawned.Store(int64(2), now.Add(-2*spawnRetryTimeout))
+		queued := []gitea.ActionWorkflowJob{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+		Expect(desiredRunners(queued, map[int64]bool{4: true}, &spawned, 1, 10, now)).To(Equal(3))
+		Expect(desiredRunners(queued, nil, &spawned, 1, 2, now)).To(Equal(2))
+		Expect(desiredRunners(nil, nil, &spawned, 3, 2, now)).To(Equal(3))
+	})
+})
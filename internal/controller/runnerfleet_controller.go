@@ -0,0 +1,187 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// topQueuedReposPerGroup is how many repositories each RunnerGroup reports in status.topQueuedRepos
+const topQueuedReposPerGroup = 5
+
+// defaultFleetTopRepos is used when the RunnerFleet doesn't set spec.topRepos
+const defaultFleetTopRepos = 10
+
+// RunnerFleetReconciler maintains the RunnerFleet singleton
+type RunnerFleetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnerfleets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnerfleets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnerfleets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnergroups,verbs=get;list;watch
+
+// Reconcile creates the RunnerFleet singleton if needed and refreshes its status
+// from all RunnerGroups in the cluster.
+func (r *RunnerFleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if req.Name != giteav1alpha1.RunnerFleetName {
+		return ctrl.Result{}, nil
+	}
+
+	fleet := &giteav1alpha1.RunnerFleet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: giteav1alpha1.RunnerFleetName}, fleet); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to get RunnerFleet")
+			return ctrl.Result{}, err
+		}
+		fleet = &giteav1alpha1.RunnerFleet{
+			ObjectMeta: metav1.ObjectMeta{Name: giteav1alpha1.RunnerFleetName},
+			Spec:       giteav1alpha1.RunnerFleetSpec{TopRepos: defaultFleetTopRepos},
+		}
+		logger.Info("Creating RunnerFleet")
+		if err := r.Create(ctx, fleet); err != nil {
+			logger.Error(err, "Failed to create RunnerFleet")
+			return ctrl.Result{}, err
+		}
+	}
+
+	var runnerGroups giteav1alpha1.RunnerGroupList
+	if err := r.List(ctx, &runnerGroups); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return ctrl.Result{}, err
+	}
+
+	status := summarizeRunnerGroups(runnerGroups.Items, fleet.Spec.TopRepos)
+	status.LastUpdateTime = fleet.Status.LastUpdateTime
+	if fleet.Status.LastUpdateTime == nil || !equality.Semantic.DeepEqual(&status, &fleet.Status) {
+		now := metav1.Now()
+		status.LastUpdateTime = &now
+		fleet.Status = status
+		if err := r.Status().Update(ctx, fleet); err != nil {
+			logger.Error(err, "Failed to update RunnerFleet status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+// summarizeRunnerGroups builds the fleet status from the RunnerGroups' own status
+func summarizeRunnerGroups(runnerGroups []giteav1alpha1.RunnerGroup, topRepos int) giteav1alpha1.RunnerFleetStatus {
+	status := giteav1alpha1.RunnerFleetStatus{TotalGroups: len(runnerGroups)}
+	queuedByRepo := make(map[string]int)
+
+	for _, runnerGroup := range runnerGroups {
+		maxActiveRunners := runnerGroup.Spec.MaxActiveRunners + runnerGroup.Status.BurstRunners
+		reachable := runnerGroup.Status.GiteaUnreachableSince == nil
+
+		status.ActiveRunners += runnerGroup.Status.ActiveRunners
+		status.MaxActiveRunners += maxActiveRunners
+		status.QueuedJobs += runnerGroup.Status.LastKnownQueuedJobs
+		if !reachable {
+			status.UnreachableGroups++
+		}
+		for _, repoQueue := range runnerGroup.Status.TopQueuedRepos {
+			queuedByRepo[repoQueue.Repo] += repoQueue.QueuedJobs
+		}
+
+		status.Groups = append(status.Groups, giteav1alpha1.RunnerGroupSummary{
+			Namespace:        runnerGroup.Namespace,
+			Name:             runnerGroup.Name,
+			Scope:            runnerGroup.Spec.Scope,
+			ActiveRunners:    runnerGroup.Status.ActiveRunners,
+			MaxActiveRunners: maxActiveRunners,
+			QueuedJobs:       runnerGroup.Status.LastKnownQueuedJobs,
+			GiteaReachable:   reachable,
+			LastCheckTime:    runnerGroup.Status.LastCheckTime,
+		})
+	}
+
+	slices.SortFunc(status.Groups, func(a, b giteav1alpha1.RunnerGroupSummary) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	status.TopRepos = topRepoQueues(queuedByRepo, topRepos)
+
+	return status
+}
+
+// countQueuedJobsByRepo counts queued jobs per repository, skipping jobs whose repository is unknown
+func countQueuedJobsByRepo(jobs []gitea.ActionWorkflowJob) map[string]int {
+	counts := make(map[string]int)
+	for _, job := range jobs {
+		if repo := job.RepoFullName(); repo != "" {
+			counts[repo]++
+		}
+	}
+	return counts
+}
+
+// topRepoQueues returns up to limit repositories with the most queued jobs, busiest first
+func topRepoQueues(counts map[string]int, limit int) []giteav1alpha1.RepoQueue {
+	if limit <= 0 || len(counts) == 0 {
+		return nil
+	}
+	queues := make([]giteav1alpha1.RepoQueue, 0, len(counts))
+	for repo, queued := range counts {
+		queues = append(queues, giteav1alpha1.RepoQueue{Repo: repo, QueuedJobs: queued})
+	}
+	slices.SortFunc(queues, func(a, b giteav1alpha1.RepoQueue) int {
+		return cmp.Or(cmp.Compare(b.QueuedJobs, a.QueuedJobs), cmp.Compare(a.Repo, b.Repo))
+	})
+	if len(queues) > limit {
+		queues = queues[:limit]
+	}
+	return queues
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RunnerFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&giteav1alpha1.RunnerFleet{}).
+		Watches(&giteav1alpha1.RunnerGroup{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: giteav1alpha1.RunnerFleetName}}}
+			})).
+		Named("runnerfleet").
+		Complete(r)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("RunnerFleet Controller", func() {
+	Context("When reconciling the singleton", func() {
+		ctx := context.Background()
+
+		fleetName := types.NamespacedName{Name: giteav1alpha1.RunnerFleetName}
+
+		AfterEach(func() {
+			fleet := &giteav1alpha1.RunnerFleet{}
+			Expect(k8sClient.Get(ctx, fleetName, fleet)).To(Succeed())
+
+			By("Cleanup the RunnerFleet singleton")
+			Expect(k8sClient.Delete(ctx, fleet)).To(Succeed())
+		})
+
+		It("should create the RunnerFleet if it does not exist", func() {
+			controllerReconciler := &RunnerFleetReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: fleetName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(pollInterval))
+
+			fleet := &giteav1alpha1.RunnerFleet{}
+			Expect(k8sClient.Get(ctx, fleetName, fleet)).To(Succeed())
+			Expect(fleet.Spec.TopRepos).To(Equal(defaultFleetTopRepos))
+			Expect(fleet.Status.LastUpdateTime).NotTo(BeNil())
+		})
+	})
+})
+
+var _ = Describe("summarizeRunnerGroups", func() {
+	It("should aggregate totals, unreachable groups and top repositories", func() {
+		unreachableSince := metav1.Now()
+		runnerGroups := []giteav1alpha1.RunnerGroup{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "linux"},
+				Spec:       giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeOrg, MaxActiveRunners: 5},
+				Status: giteav1alpha1.RunnerGroupStatus{
+					ActiveRunners:       3,
+					BurstRunners:        2,
+					LastKnownQueuedJobs: 4,
+					TopQueuedRepos: []giteav1alpha1.RepoQueue{
+						{Repo: "org/api", QueuedJobs: 3},
+						{Repo: "org/web", QueuedJobs: 1},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "arm"},
+				Spec:       giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeRepo, MaxActiveRunners: 2},
+				Status: giteav1alpha1.RunnerGroupStatus{
+					ActiveRunners:         1,
+					LastKnownQueuedJobs:   2,
+					GiteaUnreachableSince: &unreachableSince,
+					TopQueuedRepos:        []giteav1alpha1.RepoQueue{{Repo: "org/web", QueuedJobs: 2}},
+				},
+			},
+		}
+
+		status := summarizeRunnerGroups(runnerGroups, 1)
+		Expect(status.TotalGroups).To(Equal(2))
+		Expect(status.ActiveRunners).To(Equal(4))
+		Expect(status.MaxActiveRunners).To(Equal(9))
+		Expect(status.QueuedJobs).To(Equal(6))
+		Expect(status.UnreachableGroups).To(Equal(1))
+		Expect(status.Groups).To(HaveLen(2))
+		Expect(status.Groups[0].Name).To(Equal("arm"))
+		Expect(status.Groups[0].GiteaReachable).To(BeFalse())
+		Expect(status.TopRepos).To(Equal([]giteav1alpha1.RepoQueue{{Repo: "org/api", QueuedJobs: 3}}))
+	})
+})
+
+var _ = Describe("countQueuedJobsByRepo", func() {
+	It("should count jobs per repository", func() {
+		jobs := []gitea.ActionWorkflowJob{
+			queuedJob(1, "org/a"),
+			queuedJob(2, "org/b"),
+			queuedJob(3, "org/a"),
+			{ID: 4},
+		}
+		Expect(countQueuedJobsByRepo(jobs)).To(Equal(map[string]int{"org/a": 2, "org/b": 1}))
+	})
+})
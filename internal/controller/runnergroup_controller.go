@@ -26,22 +26,55 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/utils/ptr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
 	"github.com/bapung/gitea-runner-operator/internal/gitea"
+	"github.com/bapung/gitea-runner-operator/internal/operatorconfig"
+)
+
+// pollInterval is how often a RunnerGroup is requeued to poll Gitea, unless the operator
+// config sets another interval
+const pollInterval = 10 * time.Second
+
+// lastCheckTimeRefresh is how often status.lastCheckTime is written when nothing else in the
+// status changed
+const lastCheckTimeRefresh = 5 * time.Minute
+
+const (
+	// runnerGroupNameLabel links a runner Job to its RunnerGroup
+	runnerGroupNameLabel = "gitea.bpg.pw/runnergroup-name"
+	// managedByLabel marks resources created by the operator
+	managedByLabel = "gitea.bpg.pw/managed-by"
+	// giteaJobIDAnnotation records the queued Gitea job a runner Job was spawned for
+	giteaJobIDAnnotation = "gitea.bpg.pw/gitea-job-id"
+	// repositoryAnnotation records the "owner/repo" of the Gitea job a runner Job was spawned for
+	repositoryAnnotation = "gitea.bpg.pw/repository"
 )
 
 // RunnerGroupReconciler reconciles a RunnerGroup object
@@ -50,18 +83,158 @@ type RunnerGroupReconciler struct {
 	Scheme           *runtime.Scheme
 	GiteaClient      gitea.Client
 	SpawnedJobsCache sync.Map
+
+	// Recorder emits audit events for changes made in Gitea
+	Recorder record.EventRecorder
+
+	// RegistrationTokens caches the registration tokens fetched from Gitea, shared with the
+	// RunnerReconciler
+	RegistrationTokens *RegistrationTokenCache
+
+	// AuthTokenSources are where RunnerGroups may read their Gitea API token from besides a
+	// Secret in their namespace
+	AuthTokenSources AuthTokenSources
+
+	// RunnerImages maps node architectures to the runner image spawned on them; when empty every
+	// runner uses the multi-arch default image. Runners not pinned to an architecture by their
+	// node selector are pinned to DefaultRunnerArch.
+	RunnerImages      map[string]string
+	DefaultRunnerArch string
+
+	// Config holds the operator-wide defaults; the built-in defaults apply if nil
+	Config *operatorconfig.Watcher
+
+	// HTTPClient sends jobs to external provisioners; a client with a default timeout is used if nil
+	HTTPClient *http.Client
+
+	// EmergencyStop halts runner creation in every RunnerGroup, in addition to the RunnerFleet's
+	// spec.emergencyStop; EmergencyStopDrain also deletes running runners
+	EmergencyStop      bool
+	EmergencyStopDrain bool
+
+	// WebhooksEnabled is set when the Gitea webhook receiver runs. RunnerGroups of GiteaInstances
+	// with a webhook secret then poll at the instance's fallbackPollInterval only.
+	WebhooksEnabled bool
+
+	// InstancePollers crawls the queued jobs of each GiteaInstance's RunnerGroups in the background
+	// and enqueues the groups whose queue changed; their reconciles read the crawled jobs
+	InstancePollers bool
+
+	// EnablePrometheusRules generates a PrometheusRule with alerts for every RunnerGroup
+	EnablePrometheusRules bool
+
+	// StartupConcurrency is how many RunnerGroups have their runners adopted in parallel on startup
+	StartupConcurrency int
+	// StartupQPS limits the API requests per second made while adopting runners on startup
+	StartupQPS float64
+
+	// Workqueue tunes the controller's parallelism and retry rate
+	Workqueue WorkqueueOptions
+
+	// Elected is closed once this replica is the leader; nil means it always is. Standby
+	// replicas pass poll triggers on to the leader through the poll-now annotation.
+	Elected <-chan struct{}
+
+	// adoptionPending holds off spawning until runners that existed before startup are adopted
+	adoptionPending atomic.Bool
+
+	// prometheusRuleMissing is when the PrometheusRule CRD was last found missing, nil while it
+	// is installed or hasn't been looked for
+	prometheusRuleMissing atomic.Pointer[time.Time]
+
+	// polledGenerations remembers the RunnerGroup generation seen at the last Gitea poll,
+	// so spec changes are picked up without waiting for the next poll interval
+	polledGenerations sync.Map
+
+	// lastServedRepos remembers the repository that got the last runner of each user-scope
+	// RunnerGroup, so the next poll starts serving at the repository after it
+	lastServedRepos sync.Map
+
+	// pollRequests remembers the last seen poll-now annotation of each RunnerGroup
+	pollRequests sync.Map
+
+	// lastChecks remembers when each RunnerGroup last polled Gitea; status.lastCheckTime is only
+	// written along with other status changes, or once it is lastCheckTimeRefresh old
+	lastChecks sync.Map
+
+	// pollFailures remembers the pollBackoff of each RunnerGroup whose Gitea polls keep failing
+	pollFailures sync.Map
+
+	// rateLimited remembers the RunnerGroups whose last poll Gitea throttled, so the
+	// GiteaRateLimited event is only recorded when a group becomes throttled
+	rateLimited sync.Map
+
+	// webhookTriggered remembers when a webhook delivery asked for each RunnerGroup's next poll
+	webhookTriggered sync.Map
+
+	// webhookDeliveries remembers which webhook deliveries made each RunnerGroup poll, by
+	// webhookDeliveryKey, to ignore replays received by another replica
+	webhookDeliveries sync.Map
+
+	// queuedJobs shares the queued jobs found by one poll with RunnerGroups polling the same scope
+	queuedJobs queuedJobsCache
+
+	// pollTriggers enqueues RunnerGroups that should poll before their next interval
+	pollTriggers chan event.GenericEvent
+
+	// spawnMu serializes deciding how many runners a RunnerGroup may spawn, by priority and the
+	// operator-wide cap, with spawning them, so groups reconciled in parallel don't each spawn
+	// into the same headroom. Polling Gitea stays parallel.
+	spawnMu sync.Mutex
+
+	// unseenRunners remembers when each Runner was spawned until the cache has it, so the
+	// operator-wide runner count includes it
+	unseenRunners sync.Map
+
+	// idleSince remembers when the runners of each RunnerGroup were first seen online and idle in
+	// Gitea, by Job name
+	idleSince sync.Map
+
+	// deletedRunTimes holds the run time of the runner Jobs the operator deleted until it is
+	// written to their RunnerGroup's status.usage
+	deletedRunTimes runTimeLedger
+
+	// runnerLists remembers the runnerList of each RunnerGroup, so its registered runners are
+	// listed every few polls only
+	runnerLists sync.Map
+
+	// seenRunners remembers the runners of each RunnerGroup Gitea listed, by Job name, and
+	// whether it ever showed them busy, so finished runners that never ran a job count as idle
+	seenRunners sync.Map
+
+	// lastPolls remembers the polledJobs of each RunnerGroup's last poll, so its waiting jobs
+	// are kept current while it is at capacity and doesn't poll
+	lastPolls sync.Map
+
+	// deletionWaits remembers how many running jobs each deleted RunnerGroup last waited for, so
+	// it records an event when that changes rather than on every recheck
+	deletionWaits sync.Map
 }
 
 // +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnergroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnergroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnergroups/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=burstrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=jobclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runners,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=giteainstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gitea.bpg.pw,resources=runnerfleets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
+	defer func() {
+		if err != nil {
+			reconcileErrors.WithLabelValues(req.Namespace, req.Name).Inc()
+		}
+	}()
 
 	// 1. Fetch RunnerGroup
 	runnerGroup := &giteav1alpha1.RunnerGroup{}
@@ -69,6 +242,25 @@ func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if errors.IsNotFound(err) {
 			// RunnerGroup deleted, nothing to do
 			logger.Info("RunnerGroup not found, ignoring since object must be deleted")
+			r.polledGenerations.Delete(req.NamespacedName)
+			r.lastServedRepos.Delete(req.NamespacedName)
+			r.pollRequests.Delete(req.NamespacedName)
+			r.webhookTriggered.Delete(req.NamespacedName)
+			r.lastChecks.Delete(req.NamespacedName)
+			r.pollFailures.Delete(req.NamespacedName)
+			r.rateLimited.Delete(req.NamespacedName)
+			r.lastPolls.Delete(req.NamespacedName)
+			r.idleSince.Delete(req.NamespacedName)
+			r.seenRunners.Delete(req.NamespacedName)
+			r.runnerLists.Delete(req.NamespacedName)
+			r.deletionWaits.Delete(req.NamespacedName)
+			r.deletedRunTimes.forget(req.NamespacedName)
+			r.RegistrationTokens.forget(req.NamespacedName)
+			forgetRunnerGroupMetrics(req.NamespacedName)
+			if err := r.releaseJobClaims(ctx, req.NamespacedName, nil); err != nil {
+				logger.Error(err, "Failed to release JobClaims of deleted RunnerGroup")
+				return ctrl.Result{}, err
+			}
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get RunnerGroup")
@@ -77,18 +269,133 @@ func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	logger.Info("Reconciling RunnerGroup", "name", runnerGroup.Name, "namespace", runnerGroup.Namespace)
 
-	// 2. List Jobs owned by this RunnerGroup
-	jobList := &batchv1.JobList{}
-	labelSelector := client.MatchingLabels{
-		"gitea.bpg.pw/runnergroup-name": runnerGroup.Name,
+	// Act as the configured Gitea user in all Gitea requests of this reconcile
+	if runnerGroup.Spec.Sudo != "" {
+		logger = logger.WithValues("giteaSudo", runnerGroup.Spec.Sudo)
+		ctx = log.IntoContext(gitea.WithSudo(ctx, runnerGroup.Spec.Sudo), logger)
+	}
+
+	// Apply the settings of the group's Gitea instance, if one is configured
+	giteaInstanceList := &giteav1alpha1.GiteaInstanceList{}
+	if err := r.List(ctx, giteaInstanceList); err != nil {
+		logger.Error(err, "Failed to list GiteaInstances")
+		return ctrl.Result{}, err
+	}
+	giteaInstance := giteaInstanceForURL(giteaInstanceList.Items, runnerGroup.Spec.GiteaURL)
+	if insecureSkipVerify(giteaInstance) {
+		// Logged once when it starts; the InsecureTLS condition reports it from then on
+		logLevel := 1
+		if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionInsecureTLS) {
+			logLevel = 0
+		}
+		logger.V(logLevel).Info("Skipping TLS certificate verification of the Gitea instance", "giteaInstance", giteaInstance.Name)
+		ctx = gitea.WithInsecureSkipVerify(ctx, true)
+	}
+	ctx = gitea.WithHostLimits(ctx, requestLimits(giteaInstance))
+	ctx = withGroupProxy(ctx, runnerGroup)
+
+	// Let running jobs finish and deregister the runners before the group goes away
+	if !runnerGroup.DeletionTimestamp.IsZero() {
+		return r.finalizeRunnerGroup(ctx, runnerGroup, time.Now())
 	}
-	if err := r.List(ctx, jobList, client.InNamespace(runnerGroup.Namespace), labelSelector); err != nil {
+	if controllerutil.AddFinalizer(runnerGroup, runnerGroupFinalizer) {
+		if err := r.Update(ctx, runnerGroup); err != nil {
+			logger.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// 2. List the Jobs of this RunnerGroup's Runners
+	jobs, err := r.listRunnerJobs(ctx, runnerGroup)
+	if err != nil {
 		logger.Error(err, "Failed to list Jobs")
 		return ctrl.Result{}, err
 	}
+	jobList := &batchv1.JobList{Items: jobs}
+
+	// Replace runners stuck on failed nodes
+	if runnerGroup.Spec.NodeFailureRecovery != nil {
+		removed, err := r.recoverRunnersOnFailedNodes(ctx, runnerGroup, jobList.Items)
+		if err != nil {
+			logger.Error(err, "Failed to recover runners from failed nodes")
+			return ctrl.Result{}, err
+		}
+		if len(removed) > 0 {
+			r.deletedRunTimes.add(req.NamespacedName, deletedRunTime(jobList.Items, removed, time.Now()))
+			jobList.Items = slices.DeleteFunc(jobList.Items, func(job batchv1.Job) bool { return removed[job.Name] })
+			// Poll right away so replacements are spawned for jobs that went back to the queue
+			r.polledGenerations.Delete(req.NamespacedName)
+		}
+	}
+
+	// Replace runners whose spot node was reclaimed
+	if runnerGroup.Spec.Spot != nil {
+		preempted, err := r.respawnPreemptedRunners(ctx, runnerGroup, jobList.Items)
+		if err != nil {
+			logger.Error(err, "Failed to replace preempted runners")
+			return ctrl.Result{}, err
+		}
+		if len(preempted) > 0 {
+			r.deletedRunTimes.add(req.NamespacedName, deletedRunTime(jobList.Items, preempted, time.Now()))
+			jobList.Items = slices.DeleteFunc(jobList.Items, func(job batchv1.Job) bool { return preempted[job.Name] })
+			// Poll right away so the still-queued jobs get their runner back
+			r.polledGenerations.Delete(req.NamespacedName)
+		}
+	}
+
+	// Replace runners that outlived spec.maxRunnerLifetime
+	if runnerGroup.Spec.MaxRunnerLifetime != nil {
+		retired, err := r.retireExpiredRunners(ctx, runnerGroup, jobList.Items, time.Now())
+		if err != nil {
+			logger.Error(err, "Failed to retire runners past their maximum lifetime")
+			return ctrl.Result{}, err
+		}
+		if len(retired) > 0 {
+			r.deletedRunTimes.add(req.NamespacedName, deletedRunTime(jobList.Items, retired, time.Now()))
+			jobList.Items = slices.DeleteFunc(jobList.Items, func(job batchv1.Job) bool { return retired[job.Name] })
+			r.polledGenerations.Delete(req.NamespacedName)
+		}
+	}
+
+	// Kill runners hung for longer than spec.maxJobDuration
+	if runnerGroup.Spec.MaxJobDuration != nil {
+		killed, err := r.killStuckRunners(ctx, runnerGroup, jobList.Items, time.Now())
+		if err != nil {
+			logger.Error(err, "Failed to kill stuck runners")
+			return ctrl.Result{}, err
+		}
+		if len(killed) > 0 {
+			r.deletedRunTimes.add(req.NamespacedName, deletedRunTime(jobList.Items, killed, time.Now()))
+			jobList.Items = slices.DeleteFunc(jobList.Items, func(job batchv1.Job) bool { return killed[job.Name] })
+			r.polledGenerations.Delete(req.NamespacedName)
+		}
+	}
+
+	// Add capacity granted by active BurstRequests
+	burstRequestList := &giteav1alpha1.BurstRequestList{}
+	if err := r.List(ctx, burstRequestList, client.InNamespace(runnerGroup.Namespace)); err != nil {
+		logger.Error(err, "Failed to list BurstRequests")
+		return ctrl.Result{}, err
+	}
+	burstRunners := getBurstRunners(burstRequestList.Items, runnerGroup.Name, time.Now())
+	baseMaxActiveRunners := runnerGroup.Spec.MaxActiveRunners
+	if schedule := activeAutoscalingSchedule(runnerGroup.Spec.Autoscaling, time.Now()); schedule != nil {
+		logger.V(1).Info("Autoscaling schedule active", "schedule", schedule.Name, "maxActiveRunners", schedule.MaxActiveRunners)
+		baseMaxActiveRunners = schedule.MaxActiveRunners
+	}
+	maxActiveRunners := baseMaxActiveRunners + burstRunners
+	recordLabelCapacity(req.NamespacedName, getEffectiveLabels(runnerGroup.Spec.Labels), maxActiveRunners)
+
+	// Runners whose Job the Runner controller hasn't created yet already take a slot
+	runners, err := r.listRunners(ctx, runnerGroup)
+	if err != nil {
+		logger.Error(err, "Failed to list Runners")
+		return ctrl.Result{}, err
+	}
+	awaitingJob := runnersAwaitingJob(runners, jobList.Items)
 
 	// 3. Update Status - count non-completed jobs
-	activeRunners := 0
+	activeRunners := awaitingJob
 	for _, job := range jobList.Items {
 		// Job is active if it's not completed (no completion time)
 		if job.Status.CompletionTime == nil {
@@ -97,76 +404,591 @@ func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Update status
+	statusBefore := runnerGroup.Status.DeepCopy()
+	runnerGroup.Status.ObservedGeneration = runnerGroup.Generation
 	runnerGroup.Status.ActiveRunners = activeRunners
+	runnerGroup.Status.BurstRunners = burstRunners
+	phases := countRunnersByPhase(jobList.Items)
+	runnerGroup.Status.PendingRunners = phases.pending + awaitingJob
+	runnerGroup.Status.RunningRunners = phases.running
+	runnerGroup.Status.FailedRunners = phases.failed
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(runnerGroup.Namespace),
+		client.MatchingLabels{runnerGroupNameLabel: runnerGroup.Name}); err != nil {
+		logger.Error(err, "Failed to list runner pods")
+		return ctrl.Result{}, err
+	}
+	runnerGroup.Status.RunnerFailureReasons = countPodFailureReasons(podList.Items)
+	r.updateRunnersUnschedulable(runnerGroup, podList.Items, time.Now())
+	// Finished runners are marked only once the status counting them is written, so a failed
+	// write doesn't lose them
+	if finished := r.recordFinishedRunners(runnerGroup, jobList.Items, time.Now()); len(finished) > 0 {
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		if err := r.markRunnersRecorded(ctx, finished); err != nil {
+			logger.Error(err, "Failed to record finished runners")
+			return ctrl.Result{}, err
+		}
+	}
+	runnerGroup.Status.EffectiveConfig = r.effectiveConfig(runnerGroup, maxActiveRunners)
+	recordRunnerGroupMetrics(req.NamespacedName, &runnerGroup.Status, time.Now())
+	recordCapacityMetrics(req.NamespacedName, activeRunners, maxActiveRunners)
+
+	// Report what deleting the group would affect, if asked to
+	if runnerGroup.Annotations[deletionPreviewAnnotation] == "true" {
+		runnerGroup.Status.DeletionPreview = buildDeletionPreview(jobList.Items, runnerGroup.Status.DeletionPreview)
+	} else {
+		runnerGroup.Status.DeletionPreview = nil
+	}
+
+	// Flag duplicates of older RunnerGroups, which would provision runners for the same jobs
+	allRunnerGroups := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, allRunnerGroups); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return ctrl.Result{}, err
+	}
+	if conflict := r.conflictingRunnerGroup(runnerGroup, allRunnerGroups.Items); conflict != nil {
+		message := fmt.Sprintf("RunnerGroup %s/%s already provisions runners for the same Gitea instance, scope and labels", conflict.Namespace, conflict.Name)
+		if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionConflict) {
+			logger.Info("RunnerGroup duplicates an older RunnerGroup", "conflictsWith", client.ObjectKeyFromObject(conflict))
+			if r.Recorder != nil {
+				r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "DuplicateRunnerGroup", message)
+			}
+		}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionConflict,
+			Status:             metav1.ConditionTrue,
+			Reason:             "DuplicateRunnerGroup",
+			Message:            message,
+			ObservedGeneration: runnerGroup.Generation,
+		})
+	} else if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionConflict) {
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionConflict,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoConflict",
+			Message:            "No older RunnerGroup has the same Gitea instance, scope and labels",
+			ObservedGeneration: runnerGroup.Generation,
+		})
+	}
+
+	// Halt runner creation, and drain runners if asked to, while an emergency stop is active
+	stop, err := r.emergencyStop(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to check for an emergency stop")
+		return ctrl.Result{}, err
+	}
+	if stop != nil {
+		drained := 0
+		if stop.Drain {
+			if drained, err = r.drainRunners(ctx, runnerGroup, jobList.Items); err != nil {
+				logger.Error(err, "Failed to drain runners")
+				return ctrl.Result{}, err
+			}
+		}
+		logger.Info("Emergency stop active, not spawning runners", "drain", stop.Drain, "drainedRunners", drained)
+		message := "Runner creation is halted by an emergency stop"
+		if stop.Reason != "" {
+			message += ": " + stop.Reason
+		}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionEmergencyStop,
+			Status:             metav1.ConditionTrue,
+			Reason:             "EmergencyStop",
+			Message:            message,
+			ObservedGeneration: runnerGroup.Generation,
+		})
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: r.currentPollInterval()}, nil
+	}
+	if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionEmergencyStop) {
+		logger.Info("Emergency stop lifted, resuming runner creation")
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionEmergencyStop,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoEmergencyStop",
+			Message:            "Runner creation is not halted",
+			ObservedGeneration: runnerGroup.Generation,
+		})
+	}
+
+	// Spawn nothing while draining, and remove runners as soon as they aren't running a job
+	if runnerGroup.Spec.Drain {
+		remaining, err := r.drainRunnerGroup(ctx, runnerGroup, runners, jobList.Items, time.Now())
+		if err != nil {
+			logger.Error(err, "Failed to drain runners")
+			return ctrl.Result{}, err
+		}
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		if remaining > 0 {
+			logger.Info("Draining, waiting for runners to finish their jobs", "remainingRunners", remaining)
+			return ctrl.Result{RequeueAfter: drainRecheckInterval}, nil
+		}
+		return ctrl.Result{RequeueAfter: r.currentPollInterval()}, nil
+	}
+	if runnerGroup.Status.Drain != nil {
+		logger.Info("Drain lifted, resuming runner creation")
+		endDrain(runnerGroup)
+	}
+
+	// Leave runners and the last poll's results alone while paused by spec or annotation
+	if reason, paused := pauseReason(runnerGroup); paused {
+		if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionPaused) {
+			logger.Info("RunnerGroup paused, not polling Gitea", "reason", reason)
+			if r.Recorder != nil {
+				r.Recorder.Event(runnerGroup, corev1.EventTypeNormal, "Paused", "Stopped polling Gitea and spawning runners")
+			}
+		}
+		setPaused(runnerGroup, reason)
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: r.currentPollInterval()}, nil
+	}
+	if clearPaused(runnerGroup) {
+		// The queue has moved on while paused; poll right away
+		logger.Info("RunnerGroup resumed, polling Gitea")
+		r.polledGenerations.Delete(req.NamespacedName)
+	}
+
+	// Back off from spawning while the group's runners keep failing
+	if pause := r.checkFailureBudget(runnerGroup, jobList.Items, podList.Items, time.Now()); pause > 0 {
+		logger.Info("Runners keep failing, backing off from spawning", "retryIn", pause.Round(time.Second))
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: pause}, nil
+	}
+
+	r.updateInsecureTLS(runnerGroup, giteaInstance)
+
+	// Groups of instances that send webhooks only poll as a fallback for missed deliveries
+	interval := r.groupPollInterval(giteaInstance)
+	runnerGroup.Status.EffectiveConfig.PollInterval = metav1.Duration{Duration: interval}
+	interval = tokenPollInterval(runnerGroup, interval)
+
+	// Queued jobs another group of the same scope found within the interval are recent enough,
+	// unless someone asked for this poll
+	pollNotBefore := time.Now().Add(-interval)
+	if r.requestedPoll(runnerGroup) {
+		logger.Info("Poll requested through annotation", "annotation", pollNowAnnotation)
+		r.polledGenerations.Delete(req.NamespacedName)
+		pollNotBefore = time.Now()
+	}
+
+	// Fast path: owned Job churn between polls only needs the recount above,
+	// Gitea itself is polled once per interval
+	if pollDue, wait := r.isPollDue(runnerGroup, interval, time.Now()); !pollDue {
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Recounted active runners, next poll not due yet", "active", activeRunners, "nextPollIn", wait)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// Keep the group's alerting rules in sync; a missing Prometheus Operator mustn't stop scaling
+	if r.EnablePrometheusRules {
+		r.syncPrometheusRule(ctx, runnerGroup, time.Now())
+	}
+
+	// Don't spawn before startup adoption has finished, or jobs provisioned before a restart are provisioned again
+	if r.adoptionPending.Load() {
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Waiting for runner adoption to finish")
+		return ctrl.Result{RequeueAfter: adoptionWaitInterval}, nil
+	}
+
+	// Suspend polling and spawning while the Gitea instance is in a maintenance window
+	if window := activeMaintenanceWindow(giteaInstance, time.Now()); window != nil {
+		message := fmt.Sprintf("Gitea instance %s is in maintenance until %s", giteaInstance.Name, window.End.UTC().Format(time.RFC3339))
+		if window.Reason != "" {
+			message += ": " + window.Reason
+		}
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionMaintenance,
+			Status:             metav1.ConditionTrue,
+			Reason:             "MaintenanceWindow",
+			Message:            message,
+			ObservedGeneration: runnerGroup.Generation,
+		})
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		logger.Info("Gitea instance in maintenance, skipping poll", "giteaInstance", giteaInstance.Name, "until", window.End.Time)
+		return ctrl.Result{RequeueAfter: min(time.Until(window.End.Time), interval)}, nil
+	}
+	if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionMaintenance) {
+		logger.Info("Gitea instance maintenance over, resuming polling")
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionMaintenance,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoMaintenance",
+			Message:            "Gitea instance is not in a maintenance window",
+			ObservedGeneration: runnerGroup.Generation,
+		})
+	}
+
+	// Check Gitea itself is up, so an outage isn't mistaken for an empty queue
+	r.probeGitea(ctx, runnerGroup)
+	registered := r.updateRegisteredRunners(ctx, runnerGroup, runners, time.Now())
+
+	// Reap runners that registered but never picked up a job within spec.startupClaimTimeout
+	if runnerGroup.Spec.StartupClaimTimeout != nil && registered != nil {
+		reaped, err := r.reapIdleRunners(ctx, runnerGroup, jobList.Items, registered, time.Now())
+		if err != nil {
+			logger.Error(err, "Failed to reap idle runners")
+			return ctrl.Result{}, err
+		}
+		if len(reaped) > 0 {
+			r.deletedRunTimes.add(req.NamespacedName, deletedRunTime(jobList.Items, reaped, time.Now()))
+			jobList.Items = slices.DeleteFunc(jobList.Items, func(job batchv1.Job) bool { return reaped[job.Name] })
+			activeRunners -= len(reaped)
+			recordIdleRunners(runnerGroup, len(reaped))
+			runnerGroup.Status.ActiveRunners = activeRunners
+		}
+	}
+
+	// A poll no webhook asked for is a fallback poll; what it finds, deliveries missed
+	triggeredAt, webhookTriggered := r.webhookTriggered.LoadAndDelete(req.NamespacedName)
+	fallbackPoll := r.receivesWebhooks(giteaInstance) && !webhookTriggered
+	if webhookTriggered {
+		pollNotBefore = triggeredAt.(time.Time)
+	}
+
+	// At capacity Gitea isn't polled; the jobs the last poll found keep waiting, and aging, less
+	// the ones that got a runner since. Report-only groups spawn nothing, so they poll at any capacity.
+	atCapacity := activeRunners >= maxActiveRunners && !runnerGroup.Spec.ReportOnly
+	if atCapacity {
+		if last, ok := r.lastPolls.Load(req.NamespacedName); ok {
+			polled := last.(polledJobs)
+			runnerGroup.Status.WaitingJobs = waitingJobs(polled.jobs, polled.deferred, &r.SpawnedJobsCache, time.Now())
+			recordWaitingJobs(req.NamespacedName, polled.jobs, polled.deferred, &r.SpawnedJobsCache, time.Now())
+		}
+		if runnerGroup.Status.QueuedJobs > 0 {
+			r.setScaleUpBlocked(runnerGroup, scaleUpBlockedMaxActiveRunners,
+				scaleUpBlockedMessage(scaleUpBlockedMaxActiveRunners, runnerGroup.Status.QueuedJobs))
+		} else {
+			clearScaleUpBlocked(runnerGroup)
+		}
+		recordBacklogExceeded(req.NamespacedName,
+			r.updateBacklog(runnerGroup, runnerGroup.Status.QueuedJobs, maxActiveRunners, time.Now()))
+	}
+
+	// The poll time alone is only written every lastCheckTimeRefresh; the poll schedule and the
+	// watchdog go by the time remembered in lastChecks
 	now := metav1.Now()
-	runnerGroup.Status.LastCheckTime = &now
-	if err := r.Status().Update(ctx, runnerGroup); err != nil {
+	r.lastChecks.Store(req.NamespacedName, now.Time)
+	if last := runnerGroup.Status.LastCheckTime; last == nil || now.Sub(last.Time) >= lastCheckTimeRefresh ||
+		!equality.Semantic.DeepEqual(statusBefore, &runnerGroup.Status) {
+		runnerGroup.Status.LastCheckTime = &now
+	}
+	if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
 		logger.Error(err, "Failed to update RunnerGroup status")
 		return ctrl.Result{}, err
 	}
+	r.polledGenerations.Store(req.NamespacedName, runnerGroup.Generation)
 
-	logger.Info("Checked active runners", "active", activeRunners, "max", runnerGroup.Spec.MaxActiveRunners)
+	logger.Info("Checked active runners", "active", activeRunners, "max", maxActiveRunners, "burst", burstRunners)
 
 	// 4. Capacity Check
-	if activeRunners >= runnerGroup.Spec.MaxActiveRunners {
+	if atCapacity {
 		logger.Info("Max active runners reached, skipping scaling",
 			"activeRunners", activeRunners,
-			"maxActiveRunners", runnerGroup.Spec.MaxActiveRunners)
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			"maxActiveRunners", maxActiveRunners)
+		return ctrl.Result{RequeueAfter: interval}, nil
 	}
 
 	// 5. Poll Gitea
 	// Retrieve Auth Token from Secret
-	authToken, err := r.getSecretValue(ctx, runnerGroup.Namespace, runnerGroup.Spec.AuthTokenRef)
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
 	if err != nil {
 		logger.Error(err, "Failed to get auth token from secret")
+		r.setScaleUpBlocked(runnerGroup, scaleUpBlockedAuthToken, fmt.Sprintf("Failed to read the auth token: %v", err))
+		if updateErr := r.updateStatus(ctx, runnerGroup, statusBefore); updateErr != nil {
+			logger.Error(updateErr, "Failed to update RunnerGroup status")
+		}
 		return ctrl.Result{}, err
 	}
 
+	// Don't poll a scope Gitea doesn't know or the token can't read
+	if !r.verifyScope(ctx, runnerGroup, authToken) {
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: scopeRetryInterval}, nil
+	}
+
 	logger.Info("Checking Gitea for queued jobs", "url", runnerGroup.Spec.GiteaURL, "scope", runnerGroup.Spec.Scope)
 
 	// Calculate effective labels (spec labels + defaults)
-	effectiveLabels := r.getEffectiveLabels(runnerGroup.Spec.Labels)
-
-	// Query for queued workflow runs
-	stats, err := r.GiteaClient.GetRunnerStats(
-		ctx,
-		runnerGroup.Spec.GiteaURL,
-		authToken,
-		runnerGroup.Spec.Scope,
-		runnerGroup.Spec.Org,
-		runnerGroup.Spec.User,
-		runnerGroup.Spec.Repo,
-		effectiveLabels,
-	)
+	effectiveLabels := getEffectiveLabels(runnerGroup.Spec.Labels)
+
+	// Query for all queued workflow runs; they are matched here, so jobs no RunnerGroup's
+	// labels match can be reported, and groups polling the same scope share one crawl
+	scopeJobs, sharedPoll, err := r.queuedJobs.get(ctx, newQueuedJobsKey(ctx, runnerGroup, authToken), pollNotBefore,
+		func() ([]gitea.ActionWorkflowJob, error) {
+			stats, err := r.GiteaClient.GetRunnerStats(
+				ctx,
+				runnerGroup.Spec.GiteaURL,
+				authToken,
+				runnerGroup.Spec.Scope,
+				runnerGroup.Spec.Org,
+				runnerGroup.Spec.User,
+				runnerGroup.Spec.Repo,
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return stats.QueuedJobs, nil
+		})
+	if sharedPoll && err == nil {
+		logger.V(1).Info("Reusing queued jobs another RunnerGroup of the scope polled", "queuedJobs", len(scopeJobs))
+		sharedPolls.WithLabelValues(req.Namespace, req.Name).Inc()
+	}
+	if delay, throttled := rateLimitRequeue(err, interval); throttled {
+		// Gitea is up but asked the operator to slow down; polling on an error backoff would
+		// only keep it throttled
+		logger.Info("Gitea is rate limiting the operator, backing off", "retryAfter", delay, "error", err.Error())
+		if r.updateRateLimited(req.NamespacedName, true) && r.Recorder != nil {
+			r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, "GiteaRateLimited",
+				fmt.Sprintf("Gitea rate limited polling queued jobs, polling again in %s", delay))
+		}
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+	r.updateRateLimited(req.NamespacedName, false)
 	if err != nil {
 		logger.Error(err, "Failed to query Gitea for runner stats")
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+		if authTokenRejected(err) {
+			// Retrying won't revive the token; poll slowly until it's replaced
+			r.setScaleUpBlocked(runnerGroup, scaleUpBlockedAuthToken, fmt.Sprintf("Gitea rejected the auth token: %v", err))
+			r.setTokenInvalid(runnerGroup, "polling queued jobs", err)
+			if updateErr := r.updateStatus(ctx, runnerGroup, statusBefore); updateErr != nil {
+				logger.Error(updateErr, "Failed to record rejected auth token in status")
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{RequeueAfter: tokenRetryInterval}, nil
+		}
+		r.updateDegraded(runnerGroup, err)
+		if runnerGroup.Status.GiteaUnreachableSince == nil {
+			outageStart := metav1.Now()
+			runnerGroup.Status.GiteaUnreachableSince = &outageStart
+		}
+		if updateErr := r.updateStatus(ctx, runnerGroup, statusBefore); updateErr != nil {
+			logger.Error(updateErr, "Failed to record Gitea outage in status")
+		}
+		// Erroring out would retry right away, only to be short-circuited again
+		if delay, open := circuitOpenRequeue(err, interval); open {
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+		// Returning the error too would queue a second, immediate retry
+		reconcileErrors.WithLabelValues(req.Namespace, req.Name).Inc()
+		delay := r.pollErrorRequeue(req.NamespacedName, interval, time.Now())
+		logger.Info("Backing off from polling Gitea", "retryIn", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+	r.pollFailures.Delete(req.NamespacedName)
+
+	allQueuedJobs := scopeJobs
+	stats := &gitea.RunnerStats{QueuedJobs: filterJobsForRunnerGroup(runnerGroup, effectiveLabels, scopeJobs)}
+	runs := workflowRuns{}
+	if runnerGroup.Spec.EventFilter != nil {
+		stats.QueuedJobs = r.filterJobsByEvent(ctx, runnerGroup, authToken, stats.QueuedJobs, runs)
 	}
 
 	logger.Info("Gitea query result", "queuedJobs", len(stats.QueuedJobs))
+	recordQueueMetrics(req.NamespacedName, stats.QueuedJobs, time.Now())
 
 	// 6. Scale Up and Cache Management
-	availableSlots := runnerGroup.Spec.MaxActiveRunners - activeRunners
+	availableSlots := maxActiveRunners - activeRunners
+	// What limited availableSlots last, for explaining jobs left without a runner
+	slotsLimitedBy := scaleUpBlockedMaxActiveRunners
+
+	// Ramp capacity back up gradually if Gitea just came back from an outage
+	if runnerGroup.Status.GiteaUnreachableSince != nil && runnerGroup.Spec.OutageRecovery != nil {
+		logger.Info("Gitea reachable again, ramping up capacity",
+			"unreachableSince", runnerGroup.Status.GiteaUnreachableSince.Time,
+			"lastKnownQueuedJobs", runnerGroup.Status.LastKnownQueuedJobs)
+		recoveryStart := metav1.Now()
+		runnerGroup.Status.RecoveryStartTime = &recoveryStart
+	}
+	runnerGroup.Status.GiteaUnreachableSince = nil
+	setTokenValid(runnerGroup)
+	r.updateDegraded(runnerGroup, nil)
+	runnerGroup.Status.LastKnownQueuedJobs = len(stats.QueuedJobs)
+	runnerGroup.Status.QueuedJobs = len(stats.QueuedJobs)
+	runnerGroup.Status.TopQueuedRepos = topRepoQueues(countQueuedJobsByRepo(stats.QueuedJobs), topQueuedReposPerGroup)
+	recordBacklogExceeded(req.NamespacedName, r.updateBacklog(runnerGroup, len(stats.QueuedJobs), maxActiveRunners, time.Now()))
+
+	if runnerGroup.Status.RecoveryStartTime != nil {
+		budget, done := recoveryRampBudget(runnerGroup, maxActiveRunners, activeRunners, len(stats.QueuedJobs), interval, time.Now())
+		if done {
+			logger.Info("Outage recovery ramp finished")
+			runnerGroup.Status.RecoveryStartTime = nil
+		} else if budget < availableSlots {
+			availableSlots = budget
+			slotsLimitedBy = scaleUpBlockedOutageRecovery
+			logger.Info("Limiting spawns while recovering from Gitea outage", "activeRunners", activeRunners, "availableSlots", availableSlots)
+		}
+	}
+
+	// Decide which queued jobs get the free slots first
+	queuedJobs := stats.QueuedJobs
+	if runnerGroup.Spec.SpawnOrder != nil && availableSlots < len(queuedJobs) {
+		queuedJobs = orderJobsBySpawnOrder(queuedJobs, runnerGroup.Spec.SpawnOrder)
+	}
+	if runnerGroup.Spec.Scope == giteav1alpha1.RunnerGroupScopeUser && availableSlots < len(queuedJobs) {
+		// A user's repositories are enumerated in the same order every poll; always take turns
+		var repoWeights map[string]int
+		if runnerGroup.Spec.FairShare != nil {
+			repoWeights = runnerGroup.Spec.FairShare.RepoWeights
+		}
+		lastServed, _ := r.lastServedRepos.Load(req.NamespacedName)
+		lastServedRepo, _ := lastServed.(string)
+		queuedJobs = orderJobsRoundRobin(queuedJobs, repoWeights, lastServedRepo)
+	} else if runnerGroup.Spec.FairShare != nil && availableSlots < len(queuedJobs) {
+		queuedJobs = orderJobsFairly(queuedJobs, runnerGroup.Spec.FairShare.RepoWeights)
+	}
+
+	r.spawnMu.Lock()
+	defer r.spawnMu.Unlock()
+
+	// Leave jobs that higher-priority groups can serve to them; only act on overflow
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return ctrl.Result{}, err
+	}
+	deferredJobs := r.jobsForHigherPriorityGroups(runnerGroup, runnerGroupList.Items, giteaInstance, queuedJobs, runs, time.Now())
+	r.lastPolls.Store(req.NamespacedName, polledJobs{jobs: queuedJobs, deferred: deferredJobs})
+
+	// Report-only groups leave their queued jobs to whatever scales on status.waitingJobs
+	if runnerGroup.Spec.ReportOnly {
+		runnerGroup.Status.DesiredRunners = activeRunners
+		runnerGroup.Status.WaitingJobs = waitingJobs(queuedJobs, deferredJobs, &r.SpawnedJobsCache, time.Now())
+		clearScaleUpBlocked(runnerGroup)
+		recordWaitingJobs(req.NamespacedName, queuedJobs, deferredJobs, &r.SpawnedJobsCache, time.Now())
+		if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+			logger.Error(err, "Failed to update RunnerGroup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	runnerGroup.Status.DesiredRunners = desiredRunners(queuedJobs, deferredJobs, &r.SpawnedJobsCache,
+		activeRunners, maxActiveRunners, time.Now())
+	if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+		logger.Error(err, "Failed to update RunnerGroup status")
+		return ctrl.Result{}, err
+	}
+
+	// Hold scale-ups to the autoscaling policy
+	if runnerGroup.Spec.Autoscaling != nil {
+		var lastScaleUp *time.Time
+		if runnerGroup.Status.LastScaleDecision != nil {
+			lastScaleUp = &runnerGroup.Status.LastScaleDecision.Time.Time
+		}
+		waiting := waitingJobs(queuedJobs, deferredJobs, &r.SpawnedJobsCache, time.Now())
+		budget, cooldown := scaleUpBudget(runnerGroup.Spec.Autoscaling, waiting, lastScaleUp, time.Now())
+		if cooldown > 0 {
+			logger.V(1).Info("Scale-up cooldown active", "remaining", cooldown)
+		}
+		if budget < availableSlots {
+			availableSlots = budget
+			slotsLimitedBy = scaleUpBlockedAutoscaling
+		}
+	}
+
+	// Keep all RunnerGroups together under the operator-wide runner cap
+	if globalMax := r.Config.Config().MaxActiveRunners; globalMax > 0 {
+		totalRunners, err := r.countAllActiveRunners(ctx, time.Now())
+		if err != nil {
+			logger.Error(err, "Failed to count active runners of all RunnerGroups")
+			return ctrl.Result{}, err
+		}
+		if globalMax-totalRunners < availableSlots {
+			availableSlots = max(globalMax-totalRunners, 0)
+			slotsLimitedBy = scaleUpBlockedOperatorCap
+			logger.Info("Limiting spawns to the operator-wide runner cap", "maxActiveRunners", globalMax, "activeRunners", totalRunners, "availableSlots", availableSlots)
+		}
+	}
+
+	// Count active runners per repository for the per-repo quota
+	var repoRunners map[string]int
+	if runnerGroup.Spec.MaxRunnersPerRepo > 0 {
+		repoRunners = countActiveRunnersByRepo(jobList.Items)
+	}
 
 	// Track current queued IDs for cache cleanup
 	currentQueuedIDs := make(map[int64]bool)
 
-	// Retrieve Registration Token from Secret (only if we need to spawn)
-	var registrationToken string
-	tokenFetched := false
+	// Record which jobs got runners for the decision audit
+	decision := giteav1alpha1.ScaleDecision{
+		Time:           metav1.Now(),
+		QueuedJobs:     len(queuedJobs),
+		AvailableSlots: availableSlots,
+	}
 
-	for _, giteaJob := range stats.QueuedJobs {
+	// Jobs left without a runner, by the reason of the ScaleUpBlocked condition
+	blockedJobs := make(map[string]int)
+
+	// Spawn the batch with a current registration token, so a token reset in Gitea doesn't leave
+	// its runners unable to register
+	if runnerGroup.Spec.RegistrationTokenRef == nil && availableSlots > 0 &&
+		waitingJobs(queuedJobs, deferredJobs, &r.SpawnedJobsCache, time.Now()) > 0 {
+		if _, err := refreshRegistrationToken(ctx, r.Client, r.GiteaClient, r.RegistrationTokens, runnerGroup,
+			r.AuthTokenSources); err != nil {
+			logger.Error(err, "Failed to refresh registration token")
+		}
+	}
+
+	for _, giteaJob := range queuedJobs {
 		currentQueuedIDs[giteaJob.ID] = true
 
+		// Jobs with labels served outside the cluster don't take a slot; hand them off instead
+		if provisioner := externalProvisionerForJob(runnerGroup.Spec.ExternalProvisioners, giteaJob); provisioner != nil {
+			if deferredJobs[giteaJob.ID] {
+				continue
+			}
+			if err := r.delegateJob(ctx, runnerGroup, provisioner, giteaJob); err != nil {
+				logger.Error(err, "Failed to hand job off to external provisioner",
+					"giteaJobID", giteaJob.ID, "provisioner", provisioner.Name)
+			}
+			continue
+		}
+
 		if availableSlots <= 0 {
+			if jobWaiting(giteaJob, deferredJobs, &r.SpawnedJobsCache, time.Now()) {
+				blockedJobs[slotsLimitedBy]++
+			}
+			continue
+		}
+
+		if deferredJobs[giteaJob.ID] {
+			logger.V(1).Info("Job left to a higher-priority RunnerGroup", "giteaJobID", giteaJob.ID)
 			continue
 		}
 
 		// Check if we already spawned a runner for this job
 		if value, loaded := r.SpawnedJobsCache.Load(giteaJob.ID); loaded {
 			spawnTime := value.(time.Time)
-			if time.Since(spawnTime) < 5*time.Minute {
+			if time.Since(spawnTime) < spawnRetryTimeout {
 				// Already handling this job recently
 				continue
 			}
@@ -174,32 +996,112 @@ func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			logger.Info("Job stuck in queue for too long, retrying runner spawn", "giteaJobID", giteaJob.ID)
 		}
 
-		// Need to spawn a runner
-		if !tokenFetched {
-			registrationToken, err = r.getSecretValue(ctx, runnerGroup.Namespace, runnerGroup.Spec.RegistrationTokenRef)
-			if err != nil {
-				logger.Error(err, "Failed to get registration token from secret")
-				return ctrl.Result{}, err
+		repo := giteaJob.RepoFullName()
+		if repoRunners != nil && repo != "" && repoRunners[repo] >= runnerGroup.Spec.MaxRunnersPerRepo {
+			logger.V(1).Info("Repository runner quota reached, skipping job", "giteaJobID", giteaJob.ID, "repo", repo)
+			blockedJobs[scaleUpBlockedRepoQuota]++
+			continue
+		}
+
+		// Pick the image for the node architecture the runner will land on, and don't spawn
+		// runners that could never be scheduled or would run a binary of the wrong architecture
+		nodeSelector := getNodeSelectorForJob(runnerGroup.Spec.LabelNodeSelectors, giteaJob.Labels)
+		image, arch, nodeSelector, err := resolveRunnerImage(r.RunnerImages, r.fallbackRunnerImage(), r.DefaultRunnerArch, nodeSelector)
+		if err == nil && arch != "" {
+			var schedulable bool
+			schedulable, err = r.archSchedulable(ctx, arch)
+			if err == nil && !schedulable {
+				err = fmt.Errorf("no nodes with architecture %q", arch)
+			}
+		}
+		if err != nil {
+			logger.Error(err, "Skipping job, no runner can be spawned for it", "giteaJobID", giteaJob.ID)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(runnerGroup, corev1.EventTypeWarning, "RunnerArchUnavailable",
+					"Not spawning a runner for job %d: %v", giteaJob.ID, err)
 			}
-			tokenFetched = true
+			blockedJobs[scaleUpBlockedRunnerArch]++
+			continue
 		}
 
-		job, err := r.constructJobForRunnerGroup(runnerGroup, registrationToken, effectiveLabels)
+		// Claim the job so other RunnerGroups and operator replicas don't provision it too
+		claimed, err := r.claimJob(ctx, runnerGroup, giteaJob.ID)
 		if err != nil {
-			logger.Error(err, "Failed to construct Job")
+			logger.Error(err, "Failed to claim job", "giteaJobID", giteaJob.ID)
 			return ctrl.Result{}, err
 		}
+		if !claimed {
+			logger.V(1).Info("Job already claimed, skipping", "giteaJobID", giteaJob.ID)
+			continue
+		}
+
+		runnerLabels := effectiveLabels
+		if runnerGroup.Spec.LabelMatching != nil && runnerGroup.Spec.JobLabelSelector == nil {
+			runnerLabels = runnerLabelsForJob(runnerGroup.Spec.LabelMatching, effectiveLabels, giteaJob.Labels)
+		}
 
-		if err := r.Create(ctx, job); err != nil {
-			logger.Error(err, "Failed to create Job", "jobName", job.Name)
+		// The Runner controller creates the runner's Job
+		runner, err := r.constructRunner(runnerGroup, giteaJob, runnerLabels, nodeSelector, image)
+		if err != nil {
+			logger.Error(err, "Failed to construct Runner")
 			return ctrl.Result{}, err
 		}
 
-		logger.Info("Created Job for Gitea Run", "jobName", job.Name, "giteaJobID", giteaJob.ID)
+		if err := r.Create(ctx, runner); err != nil {
+			logger.Error(err, "Failed to create Runner", "runner", runner.Name)
+			// Give up the claim so the job can be retried on the next reconcile
+			claim := &giteav1alpha1.JobClaim{ObjectMeta: metav1.ObjectMeta{Name: jobClaimName(runnerGroup.Spec.GiteaURL, giteaJob.ID)}}
+			if deleteErr := r.Delete(ctx, claim); deleteErr != nil && !errors.IsNotFound(deleteErr) {
+				logger.Error(deleteErr, "Failed to release JobClaim", "giteaJobID", giteaJob.ID)
+			}
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("Created Runner for Gitea job", "runner", runner.Name, "giteaJobID", giteaJob.ID)
+		r.unseenRunners.Store(client.ObjectKeyFromObject(runner), time.Now())
 
 		// Mark as spawned
 		r.SpawnedJobsCache.Store(giteaJob.ID, time.Now())
+		recordRunnerSpawned(req.NamespacedName, giteaJob, time.Now())
+		decision.SpawnedJobIDs = append(decision.SpawnedJobIDs, giteaJob.ID)
 		availableSlots--
+		if repoRunners != nil && repo != "" {
+			repoRunners[repo]++
+		}
+		if runnerGroup.Spec.Scope == giteav1alpha1.RunnerGroupScopeUser && repo != "" {
+			r.lastServedRepos.Store(req.NamespacedName, repo)
+		}
+	}
+
+	runnerGroup.Status.DelegatedJobs = pruneDelegatedJobs(runnerGroup.Status.DelegatedJobs, currentQueuedIDs)
+
+	if fallbackPoll && len(decision.SpawnedJobIDs) > 0 {
+		logger.Info("Fallback poll found queued jobs no webhook announced", "spawnedJobIDs", decision.SpawnedJobIDs)
+		webhookMissedJobs.WithLabelValues(req.Namespace, req.Name).Add(float64(len(decision.SpawnedJobIDs)))
+	}
+	if len(decision.SpawnedJobIDs) > 0 {
+		logger.Info("Scaled up", "queuedJobs", decision.QueuedJobs, "availableSlots", decision.AvailableSlots,
+			"spawnedJobIDs", decision.SpawnedJobIDs)
+		scaleUps.WithLabelValues(req.Namespace, req.Name).Inc()
+		runnerGroup.Status.LastScaleDecision = &decision
+	}
+	runnerGroup.Status.WaitingJobs = waitingJobs(queuedJobs, deferredJobs, &r.SpawnedJobsCache, time.Now())
+	recordWaitingJobs(req.NamespacedName, queuedJobs, deferredJobs, &r.SpawnedJobsCache, time.Now())
+
+	// Explain why queued jobs are left waiting, if they are
+	var unserved []gitea.ActionWorkflowJob
+	if len(stats.QueuedJobs) == 0 && len(allQueuedJobs) > 0 {
+		unserved = r.unservedJobs(runnerGroup, runnerGroupList.Items, allQueuedJobs)
+	}
+	if reason, message, blocked := scaleUpBlock(blockedJobs, len(decision.SpawnedJobIDs), unserved); blocked {
+		logger.Info("Queued jobs got no runners", "reason", reason, "message", message)
+		r.setScaleUpBlocked(runnerGroup, reason, message)
+	} else {
+		clearScaleUpBlocked(runnerGroup)
+	}
+
+	if err := r.updateStatus(ctx, runnerGroup, statusBefore); err != nil {
+		logger.Error(err, "Failed to record scale decision in status")
 	}
 
 	// Cleanup cache: remove jobs that are no longer queued in Gitea
@@ -212,19 +1114,95 @@ func (r *RunnerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return true
 	})
 
+	// Release claims for jobs that are no longer queued
+	if err := r.releaseJobClaims(ctx, req.NamespacedName, currentQueuedIDs); err != nil {
+		logger.Error(err, "Failed to release JobClaims")
+	}
+
 	// 7. Requeue for continuous polling
-	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// isPollDue reports whether Gitea should be polled for the RunnerGroup now, and if not,
+// how long until the next poll is due
+func (r *RunnerGroupReconciler) isPollDue(runnerGroup *giteav1alpha1.RunnerGroup, interval time.Duration, now time.Time) (bool, time.Duration) {
+	lastCheck := r.lastCheckTime(runnerGroup)
+	if lastCheck == nil {
+		return true, 0
+	}
+
+	key := client.ObjectKeyFromObject(runnerGroup)
+	if generation, ok := r.polledGenerations.Load(key); !ok || generation.(int64) != runnerGroup.Generation {
+		return true, 0
+	}
+
+	// Job and pod events mustn't cut short the backoff of a group whose polls keep failing
+	if notBefore := r.pollBackoffUntil(key); now.Before(notBefore) {
+		return false, notBefore.Sub(now)
+	}
+
+	elapsed := now.Sub(lastCheck.Time)
+	if elapsed >= interval {
+		return true, 0
+	}
+	return false, interval - elapsed
+}
+
+// lastCheckTime returns when the RunnerGroup last polled Gitea, which may be later than its
+// status.lastCheckTime
+func (r *RunnerGroupReconciler) lastCheckTime(runnerGroup *giteav1alpha1.RunnerGroup) *metav1.Time {
+	lastCheck := runnerGroup.Status.LastCheckTime
+	if polled, ok := r.lastChecks.Load(client.ObjectKeyFromObject(runnerGroup)); ok &&
+		(lastCheck == nil || polled.(time.Time).After(lastCheck.Time)) {
+		lastCheck = &metav1.Time{Time: polled.(time.Time)}
+	}
+	return lastCheck
+}
+
+// updateStatus writes the RunnerGroup's status if it changed from statusBefore, as a merge patch
+// that fails on conflicting writes like an update does, and makes statusBefore the written status
+func (r *RunnerGroupReconciler) updateStatus(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, statusBefore *giteav1alpha1.RunnerGroupStatus) error {
+	// The run time of deleted runners is dropped from the ledger only once it is written
+	key := client.ObjectKeyFromObject(runnerGroup)
+	usage := runnerGroup.Status.Usage.DeepCopy()
+	deletedRunTime := r.deletedRunTimes.pending(key)
+	recordRunnerTime(runnerGroup, deletedRunTime, time.Now())
+	if equality.Semantic.DeepEqual(statusBefore, &runnerGroup.Status) {
+		return nil
+	}
+	var err error
+	if statusBefore.ObservedGeneration == 0 {
+		// A merge patch would create a status that was never written without its required fields
+		err = r.Status().Update(ctx, runnerGroup)
+	} else {
+		original := runnerGroup.DeepCopy()
+		original.Status = *statusBefore
+		err = r.Status().Patch(ctx, runnerGroup, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	}
+	if err != nil {
+		runnerGroup.Status.Usage = usage
+		return err
+	}
+	r.deletedRunTimes.settle(key, deletedRunTime)
+	recordRunnerSeconds(key, statusBefore, &runnerGroup.Status)
+	runnerGroup.Status.DeepCopyInto(statusBefore)
+	return nil
 }
 
 // getSecretValue retrieves a value from a secret
 func (r *RunnerGroupReconciler) getSecretValue(ctx context.Context, namespace string, selector corev1.SecretKeySelector) (string, error) {
+	return readSecretValue(ctx, r.Client, namespace, selector)
+}
+
+// readSecretValue retrieves a value from a secret with the given client
+func readSecretValue(ctx context.Context, c client.Reader, namespace string, selector corev1.SecretKeySelector) (string, error) {
 	secret := &corev1.Secret{}
 	secretName := client.ObjectKey{
 		Namespace: namespace,
 		Name:      selector.Name,
 	}
 
-	if err := r.Get(ctx, secretName, secret); err != nil {
+	if err := c.Get(ctx, secretName, secret); err != nil {
 		return "", fmt.Errorf("failed to get secret %s: %w", selector.Name, err)
 	}
 
@@ -237,7 +1215,7 @@ func (r *RunnerGroupReconciler) getSecretValue(ctx context.Context, namespace st
 }
 
 // getEffectiveLabels merges spec labels with default labels
-func (r *RunnerGroupReconciler) getEffectiveLabels(specLabels []string) []string {
+func getEffectiveLabels(specLabels []string) []string {
 	defaultLabels := []string{
 		"ubuntu-latest:docker://node:16-bullseye",
 		"ubuntu-22.04:docker://node:16-bullseye",
@@ -271,97 +1249,131 @@ func (r *RunnerGroupReconciler) getEffectiveLabels(specLabels []string) []string
 	return effectiveLabels
 }
 
-// constructJobForRunnerGroup creates a Job object for the RunnerGroup
-func (r *RunnerGroupReconciler) constructJobForRunnerGroup(runnerGroup *giteav1alpha1.RunnerGroup, registrationToken string, labels []string) (*batchv1.Job, error) {
-	// Generate random suffix for name
-	name := fmt.Sprintf("%s-%s", runnerGroup.Name, randString(8))
-
-	// Construct Env Vars
-	envVars := []corev1.EnvVar{
-		{Name: "GITEA_INSTANCE_URL", Value: runnerGroup.Spec.GiteaURL},
-		{Name: "GITEA_RUNNER_REGISTRATION_TOKEN", Value: registrationToken},
-		{Name: "GITEA_RUNNER_EPHEMERAL", Value: "true"},
-		{Name: "GITEA_RUNNER_NAME", Value: name},
-		{Name: "DOCKER_HOST", Value: "tcp://localhost:2376"},
-		{Name: "DOCKER_CERT_PATH", Value: "/certs/client"},
-		{Name: "DOCKER_TLS_VERIFY", Value: "1"},
+// getNodeSelectorForJob merges the node selectors configured for each of the job's labels.
+// Labels are compared by name, ignoring any ":schema" suffix.
+func getNodeSelectorForJob(labelNodeSelectors map[string]map[string]string, jobLabels []string) map[string]string {
+	if len(labelNodeSelectors) == 0 {
+		return nil
 	}
 
-	if len(labels) > 0 {
-		labelsStr := strings.Join(labels, ",")
-		envVars = append(envVars, corev1.EnvVar{Name: "GITEA_RUNNER_LABELS", Value: labelsStr})
+	var nodeSelector map[string]string
+	for _, jobLabel := range jobLabels {
+		key := gitea.NormalizeLabel(jobLabel)
+		selector, ok := labelNodeSelectors[key]
+		if !ok {
+			continue
+		}
+		if nodeSelector == nil {
+			nodeSelector = make(map[string]string, len(selector))
+		}
+		for k, v := range selector {
+			nodeSelector[k] = v
+		}
 	}
 
-	// Construct Job
-	job := &batchv1.Job{
+	return nodeSelector
+}
+
+// constructRunner creates a Runner of the RunnerGroup for a queued Gitea job
+func (r *RunnerGroupReconciler) constructRunner(runnerGroup *giteav1alpha1.RunnerGroup, giteaJob gitea.ActionWorkflowJob, labels []string, nodeSelector map[string]string, image string) (*giteav1alpha1.Runner, error) {
+	runner := &giteav1alpha1.Runner{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
+			// Generate random suffix for name
+			Name:      fmt.Sprintf("%s-%s", runnerGroup.Name, randString(runnerNameSuffixLength)),
 			Namespace: runnerGroup.Namespace,
 			Labels: map[string]string{
-				"app":                           runnerGroup.Name,
-				"gitea.bpg.pw/runnergroup-name": runnerGroup.Name,
-				"gitea.bpg.pw/managed-by":       "gitea-runner-operator",
+				runnerGroupNameLabel: runnerGroup.Name,
+				managedByLabel:       "gitea-runner-operator",
 			},
 		},
-		Spec: batchv1.JobSpec{
-			TTLSecondsAfterFinished: ptr.To(int32(600)),
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyOnFailure,
-					SecurityContext: &corev1.PodSecurityContext{
-						FSGroup: ptr.To(int64(1000)),
-					},
-					Containers: []corev1.Container{
-						{
-							Name:            "runner",
-							Image:           "gitea/act_runner:nightly-dind-rootless",
-							ImagePullPolicy: corev1.PullAlways,
-							SecurityContext: &corev1.SecurityContext{
-								Privileged: ptr.To(true),
-							},
-							Env: envVars,
-							VolumeMounts: []corev1.VolumeMount{
-								{Name: "runner-data", MountPath: "/data"},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: "runner-data",
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
-						},
-					},
-				},
-			},
+		Spec: giteav1alpha1.RunnerSpec{
+			RunnerGroupName: runnerGroup.Name,
+			GiteaJobID:      giteaJob.ID,
+			Repository:      giteaJob.RepoFullName(),
+			Labels:          labels,
+			NodeSelector:    nodeSelector,
+			Image:           image,
 		},
 	}
 
 	// Set Controller Reference
-	if err := ctrl.SetControllerReference(runnerGroup, job, r.Scheme); err != nil {
+	if err := ctrl.SetControllerReference(runnerGroup, runner, r.Scheme); err != nil {
 		return nil, err
 	}
 
-	return job, nil
+	return runner, nil
 }
 
+const (
+	// runnerNameSuffixLength is the length of the random suffix of runner names
+	runnerNameSuffixLength = 8
+	// runnerNameCharset is what the random suffix of runner names is made of
+	runnerNameCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
 // randString generates a random string of the given length
 func randString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 	seededRand := rand.New(rand.NewSource(time.Now().UnixNano()))
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
+		b[i] = runnerNameCharset[seededRand.Intn(len(runnerNameCharset))]
 	}
 	return string(b)
 }
 
+// runnerGroupForBurstRequest maps a BurstRequest to the RunnerGroup it boosts
+func (r *RunnerGroupReconciler) runnerGroupForBurstRequest(ctx context.Context, obj client.Object) []reconcile.Request {
+	burstRequest, ok := obj.(*giteav1alpha1.BurstRequest)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: burstRequest.Namespace,
+			Name:      burstRequest.Spec.RunnerGroupName,
+		},
+	}}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *RunnerGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.adoptionPending.Store(true)
+	r.pollTriggers = make(chan event.GenericEvent, pollTriggerBuffer)
+	if err := indexOwners(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	if err := mgr.Add(&runnerAdopter{reconciler: r, concurrency: r.StartupConcurrency, qps: r.StartupQPS}); err != nil {
+		return err
+	}
+	if err := mgr.Add(&pollWatchdog{reconciler: r}); err != nil {
+		return err
+	}
+	if err := mgr.Add(&staleRunnerGC{reconciler: r}); err != nil {
+		return err
+	}
+	if err := mgr.Add(&orphanedJobCollector{reconciler: r}); err != nil {
+		return err
+	}
+	if err := mgr.Add(leaderIndicator{}); err != nil {
+		return err
+	}
+	if r.InstancePollers {
+		if err := mgr.Add(&instancePollers{reconciler: r}); err != nil {
+			return err
+		}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&giteav1alpha1.RunnerGroup{}).
+		// Runner Jobs are owned by their Runner and reach the group through its status. Only Jobs
+		// spawned before the Runner resource existed are owned by the RunnerGroup directly.
 		Owns(&batchv1.Job{}).
+		Owns(&giteav1alpha1.Runner{}).
+		Watches(&giteav1alpha1.BurstRequest{}, handler.EnqueueRequestsFromMapFunc(r.runnerGroupForBurstRequest)).
+		Watches(&giteav1alpha1.GiteaInstance{}, handler.EnqueueRequestsFromMapFunc(r.runnerGroupsForGiteaInstance)).
+		Watches(&giteav1alpha1.RunnerFleet{}, handler.EnqueueRequestsFromMapFunc(r.runnerGroupsForRunnerFleet),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(source.Channel(r.pollTriggers, &handler.EnqueueRequestForObject{})).
 		Named("runnergroup").
+		WithOptions(r.Workqueue.controllerOptions()).
 		Complete(r)
 }
@@ -24,11 +24,13 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +46,46 @@ func (c *fakeGiteaClient) GetRunnerStats(ctx context.Context, giteaURL, authToke
 	return &gitea.RunnerStats{QueuedJobs: []gitea.ActionWorkflowJob{}}, nil
 }
 
+func (c *fakeGiteaClient) GetVersion(ctx context.Context, giteaURL, authToken string) (string, error) {
+	return "1.25.0", nil
+}
+
+func (c *fakeGiteaClient) GetRegistrationToken(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) (string, error) {
+	return "fetched-token", nil
+}
+
+func (c *fakeGiteaClient) RegisterRunner(ctx context.Context, giteaURL, registrationToken, name string, labels []string, ephemeral bool) (*gitea.RegisteredRunner, error) {
+	return &gitea.RegisteredRunner{ID: 7, UUID: "runner-uuid", Token: "runner-token", Name: name, Labels: labels, Ephemeral: ephemeral}, nil
+}
+
+func (c *fakeGiteaClient) RefreshOAuth2Token(ctx context.Context, giteaURL, clientID, clientSecret, refreshToken string) (*gitea.OAuth2Token, error) {
+	return &gitea.OAuth2Token{AccessToken: "access-token", RefreshToken: refreshToken + "-rotated", Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func (c *fakeGiteaClient) DeleteRunner(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string, id int64) error {
+	return nil
+}
+
+func (c *fakeGiteaClient) DeregisterRunner(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string, name string) error {
+	return nil
+}
+
+func (c *fakeGiteaClient) GetRunner(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string, name string) (*gitea.ActionRunner, error) {
+	return nil, nil
+}
+
+func (c *fakeGiteaClient) ListRunners(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) ([]gitea.ActionRunner, error) {
+	return nil, nil
+}
+
+func (c *fakeGiteaClient) VerifyScope(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) error {
+	return nil
+}
+
+func (c *fakeGiteaClient) GetWorkflowRun(ctx context.Context, giteaURL, authToken, repo string, runID int64) (*gitea.ActionWorkflowRun, error) {
+	return &gitea.ActionWorkflowRun{ID: runID}, nil
+}
+
 var _ = Describe("RunnerGroup Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
@@ -84,7 +126,7 @@ var _ = Describe("RunnerGroup Controller", func() {
 						Scope:            giteav1alpha1.RunnerGroupScopeGlobal,
 						GiteaURL:         "https://gitea.example.com",
 						MaxActiveRunners: 1,
-						RegistrationTokenRef: corev1.SecretKeySelector{
+						RegistrationTokenRef: &corev1.SecretKeySelector{
 							LocalObjectReference: corev1.LocalObjectReference{Name: "gitea-secret"},
 							Key:                  "token",
 						},
@@ -106,6 +148,12 @@ var _ = Describe("RunnerGroup Controller", func() {
 
 			By("Cleanup the specific resource instance RunnerGroup")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("Removing the finalizer of the deleted RunnerGroup")
+			controllerReconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), GiteaClient: &fakeGiteaClient{}}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
@@ -119,8 +167,153 @@ var _ = Describe("RunnerGroup Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("Recording the processed generation")
+			resource := &giteav1alpha1.RunnerGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
 		})
 	})
 })
+
+var _ = Describe("getNodeSelectorForJob", func() {
+	labelNodeSelectors := map[string]map[string]string{
+		"arm64":   {"kubernetes.io/arch": "arm64"},
+		"windows": {"kubernetes.io/os": "windows"},
+		"gpu":     {"nvidia.com/gpu.present": "true"},
+	}
+
+	It("should return nil when no job label is mapped", func() {
+		Expect(getNodeSelectorForJob(labelNodeSelectors, []string{"ubuntu-latest"})).To(BeNil())
+	})
+
+	It("should merge selectors for every mapped job label", func() {
+		Expect(getNodeSelectorForJob(labelNodeSelectors, []string{"arm64", "gpu"})).To(Equal(map[string]string{
+			"kubernetes.io/arch":     "arm64",
+			"nvidia.com/gpu.present": "true",
+		}))
+	})
+
+	It("should ignore the schema suffix of job labels", func() {
+		Expect(getNodeSelectorForJob(labelNodeSelectors, []string{"arm64:docker://node:20"})).To(Equal(map[string]string{
+			"kubernetes.io/arch": "arm64",
+		}))
+	})
+})
+
+var _ = Describe("isPollDue", func() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newGroup := func(lastCheck *metav1.Time) *giteav1alpha1.RunnerGroup {
+		return &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "poll", Namespace: "default", Generation: 2},
+			Status:     giteav1alpha1.RunnerGroupStatus{LastCheckTime: lastCheck},
+		}
+	}
+
+	It("should poll a group that was never polled", func() {
+		reconciler := &RunnerGroupReconciler{}
+		due, _ := reconciler.isPollDue(newGroup(nil), pollInterval, now)
+		Expect(due).To(BeTrue())
+	})
+
+	It("should only recount between polls of an unchanged group", func() {
+		reconciler := &RunnerGroupReconciler{}
+		lastCheck := metav1.NewTime(now.Add(-4 * time.Second))
+		group := newGroup(&lastCheck)
+		reconciler.polledGenerations.Store(client.ObjectKeyFromObject(group), group.Generation)
+
+		due, wait := reconciler.isPollDue(group, pollInterval, now)
+		Expect(due).To(BeFalse())
+		Expect(wait).To(Equal(pollInterval - 4*time.Second))
+
+		due, _ = reconciler.isPollDue(group, pollInterval, now.Add(pollInterval))
+		Expect(due).To(BeTrue())
+	})
+
+	It("should poll immediately after a spec change", func() {
+		reconciler := &RunnerGroupReconciler{}
+		lastCheck := metav1.NewTime(now.Add(-time.Second))
+		group := newGroup(&lastCheck)
+		reconciler.polledGenerations.Store(client.ObjectKeyFromObject(group), group.Generation-1)
+
+		due, _ := reconciler.isPollDue(group, pollInterval, now)
+		Expect(due).To(BeTrue())
+	})
+
+	It("should go by the last poll rather than the last written lastCheckTime", func() {
+		reconciler := &RunnerGroupReconciler{}
+		lastCheck := metav1.NewTime(now.Add(-4 * time.Minute))
+		group := newGroup(&lastCheck)
+		reconciler.polledGenerations.Store(client.ObjectKeyFromObject(group), group.Generation)
+		reconciler.lastChecks.Store(client.ObjectKeyFromObject(group), now.Add(-4*time.Second))
+
+		due, wait := reconciler.isPollDue(group, pollInterval, now)
+		Expect(due).To(BeFalse())
+		Expect(wait).To(Equal(pollInterval - 4*time.Second))
+	})
+
+	It("should wait out the backoff of a group whose polls keep failing", func() {
+		reconciler := &RunnerGroupReconciler{}
+		lastCheck := metav1.NewTime(now)
+		group := newGroup(&lastCheck)
+		key := client.ObjectKeyFromObject(group)
+		reconciler.polledGenerations.Store(key, group.Generation)
+		for range 5 {
+			reconciler.pollErrorRequeue(key, pollInterval, now)
+		}
+
+		due, wait := reconciler.isPollDue(group, pollInterval, now.Add(time.Minute))
+		Expect(due).To(BeFalse())
+		Expect(wait).To(Equal(pollErrorBackoff(pollInterval, 4) - time.Minute))
+
+		due, _ = reconciler.isPollDue(group, pollInterval, now.Add(pollErrorBackoff(pollInterval, 4)))
+		Expect(due).To(BeTrue())
+	})
+})
+
+var _ = Describe("updateStatus", func() {
+	ctx := context.Background()
+
+	It("should only write a changed status, and fail on conflicting writes", func() {
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "gitea-secret"}, Key: "token"}
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "status-patch", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:                giteav1alpha1.RunnerGroupScopeGlobal,
+				GiteaURL:             "https://gitea.example.com",
+				MaxActiveRunners:     2,
+				RegistrationTokenRef: &secretRef,
+				AuthTokenRef:         secretRef,
+			},
+		}
+		Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+		By("writing the first status in full")
+		statusBefore := runnerGroup.Status.DeepCopy()
+		runnerGroup.Status.ObservedGeneration = runnerGroup.Generation
+		Expect(reconciler.updateStatus(ctx, runnerGroup, statusBefore)).To(Succeed())
+		Expect(statusBefore.ObservedGeneration).To(Equal(runnerGroup.Generation))
+
+		By("skipping an unchanged status")
+		resourceVersion := runnerGroup.ResourceVersion
+		Expect(reconciler.updateStatus(ctx, runnerGroup, statusBefore)).To(Succeed())
+		Expect(runnerGroup.ResourceVersion).To(Equal(resourceVersion))
+
+		By("patching a changed status")
+		runnerGroup.Status.ActiveRunners = 1
+		Expect(reconciler.updateStatus(ctx, runnerGroup, statusBefore)).To(Succeed())
+		Expect(runnerGroup.ResourceVersion).NotTo(Equal(resourceVersion))
+		updated := &giteav1alpha1.RunnerGroup{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(runnerGroup), updated)).To(Succeed())
+		Expect(updated.Status.ActiveRunners).To(Equal(1))
+
+		By("failing when someone else wrote in between")
+		updated.Status.QueuedJobs = 3
+		Expect(k8sClient.Status().Update(ctx, updated)).To(Succeed())
+		runnerGroup.Status.ActiveRunners = 2
+		Expect(reconciler.updateStatus(ctx, runnerGroup, statusBefore)).To(Satisfy(errors.IsConflict))
+	})
+})
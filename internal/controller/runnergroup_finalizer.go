@@ -0,0 +1,168 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// runnerGroupFinalizer holds a deleted RunnerGroup until its runners are cleaned up
+	runnerGroupFinalizer = "gitea.bpg.pw/runner-cleanup"
+	// defaultDeletionGracePeriod is how long a deleted group waits for running jobs by default
+	defaultDeletionGracePeriod = 30 * time.Minute
+	// deletionRecheckInterval is how often a deleted group checks whether its runners finished
+	deletionRecheckInterval = 10 * time.Second
+)
+
+// deletionGracePeriod returns how long the group's deletion waits for running jobs
+func deletionGracePeriod(runnerGroup *giteav1alpha1.RunnerGroup) time.Duration {
+	if runnerGroup.Spec.DeletionGracePeriod != nil {
+		return runnerGroup.Spec.DeletionGracePeriod.Duration
+	}
+	return defaultDeletionGracePeriod
+}
+
+// finalizeRunnerGroup cleans up after a deleted RunnerGroup: runners that aren't running a job
+// are deleted right away, running ones once they finish or the grace period is over. Then the
+// group's runners are deregistered from Gitea and the finalizer is removed. Deregistration
+// failures are logged rather than holding up the deletion.
+func (r *RunnerGroupReconciler) finalizeRunnerGroup(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, now time.Time) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if !controllerutil.ContainsFinalizer(runnerGroup, runnerGroupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	key := client.ObjectKeyFromObject(runnerGroup)
+
+	jobs, err := r.listRunnerJobs(ctx, runnerGroup)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list runner Jobs: %w", err)
+	}
+	authToken, tokenErr := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	busy := r.busyRunners(ctx, runnerGroup, authToken, tokenErr)
+	deadline := runnerGroup.DeletionTimestamp.Add(deletionGracePeriod(runnerGroup))
+	running := 0
+	for i := range jobs {
+		job := &jobs[i]
+		if job.DeletionTimestamp != nil {
+			continue
+		}
+		if job.Status.CompletionTime == nil && !jobFailed(job) && runningJob(job, busy) && now.Before(deadline) {
+			running++
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete runner Job %s: %w", job.Name, err)
+		}
+		r.deletedRunTimes.add(key, deletedRunnerRunTime(job, now))
+	}
+	// Runners still running keep the group around; its usage counts the ones deleted so far
+	if err := r.updateStatus(ctx, runnerGroup, runnerGroup.Status.DeepCopy()); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update RunnerGroup status: %w", err)
+	}
+	if running > 0 {
+		// Only changes in the number of running jobs are worth an event, not every recheck
+		if last, ok := r.deletionWaits.Swap(key, running); !ok || last.(int) != running {
+			logger.Info("RunnerGroup is being deleted, waiting for running jobs to finish", "runningRunners", running, "until", deadline)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "WaitingForRunners",
+					"Waiting for %d running jobs to finish before deleting the group, at most until %s", running, deadline.UTC().Format(time.RFC3339))
+			}
+		}
+		return ctrl.Result{RequeueAfter: min(deletionRecheckInterval, deadline.Sub(now))}, nil
+	}
+	r.deletionWaits.Delete(key)
+
+	runners, err := r.listRunners(ctx, runnerGroup)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list Runners: %w", err)
+	}
+	if tokenErr != nil {
+		logger.Error(tokenErr, "Failed to read auth token, leaving runners registered in Gitea")
+	} else {
+		for i := range runners {
+			runner := &runners[i]
+			if !mayBeRegistered(runner) {
+				continue
+			}
+			if err := deregisterGiteaRunner(ctx, r.GiteaClient, runnerGroup, authToken, runner); err != nil {
+				logger.Error(err, "Failed to deregister runner from Gitea", "runner", runner.Name)
+				if r.Recorder != nil {
+					r.Recorder.Eventf(runnerGroup, corev1.EventTypeWarning, "DeregistrationFailed",
+						"Failed to deregister runner %s from Gitea: %v", runner.Name, err)
+				}
+			}
+		}
+	}
+
+	controllerutil.RemoveFinalizer(runnerGroup, runnerGroupFinalizer)
+	if err := r.Update(ctx, runnerGroup); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logger.Info("Cleaned up runners of deleted RunnerGroup")
+	return ctrl.Result{}, nil
+}
+
+// busyRunners returns which of the deleted group's runners Gitea shows running a job, by Job name.
+// It returns nil if Gitea can't be asked, so running is judged by the Jobs' ready pods instead.
+func (r *RunnerGroupReconciler) busyRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, authToken string, tokenErr error) map[string]bool {
+	if tokenErr != nil {
+		return nil
+	}
+	registered, err := r.GiteaClient.ListRunners(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to list runners registered in Gitea, judging running jobs by ready pods", "error", err.Error())
+		return nil
+	}
+	busy := make(map[string]bool)
+	for _, runner := range registered {
+		if runner.Busy {
+			busy[runner.Name] = true
+		}
+	}
+	return busy
+}
+
+// runningJob reports whether the runner Job is running a Gitea job: whether Gitea shows its
+// runner busy or, without Gitea's view, whether its pod is ready
+func runningJob(job *batchv1.Job, busy map[string]bool) bool {
+	if busy == nil {
+		return ptr.Deref(job.Status.Ready, 0) > 0
+	}
+	return busy[job.Name]
+}
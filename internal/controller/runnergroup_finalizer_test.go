@@ -0,0 +1,139 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("RunnerGroup finalizer", func() {
+	ctx := context.Background()
+
+	It("should wait for running jobs, then delete the runners and deregister them", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "finalizer-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "admin-token"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "token"}
+
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "finalized", Namespace: "default", Finalizers: []string{runnerGroupFinalizer}},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL:             "https://gitea.example.com",
+				Scope:                giteav1alpha1.RunnerGroupScopeGlobal,
+				MaxActiveRunners:     2,
+				RegistrationTokenRef: &secretRef,
+				AuthTokenRef:         secretRef,
+				DeletionGracePeriod:  &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		}
+		Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+
+		newRunner := func(name string) (*giteav1alpha1.Runner, *batchv1.Job) {
+			runner := &giteav1alpha1.Runner{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{runnerGroupNameLabel: runnerGroup.Name}},
+				Spec:       giteav1alpha1.RunnerSpec{RunnerGroupName: runnerGroup.Name, Image: "gitea/act_runner"},
+			}
+			Expect(ctrl.SetControllerReference(runnerGroup, runner, k8sClient.Scheme())).To(Succeed())
+			Expect(k8sClient.Create(ctx, runner)).To(Succeed())
+			DeferCleanup(func() { Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, runner))).To(Succeed()) })
+
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{runnerGroupNameLabel: runnerGroup.Name}},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers:    []corev1.Container{{Name: "runner", Image: "gitea/act_runner"}},
+				}}},
+			}
+			Expect(ctrl.SetControllerReference(runner, job, k8sClient.Scheme())).To(Succeed())
+			Expect(k8sClient.Create(ctx, job)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)))).To(Succeed())
+			})
+			return runner, job
+		}
+		_, pending := newRunner("finalized-pending")
+		_, idle := newRunner("finalized-idle")
+		runner, running := newRunner("finalized-running")
+		startTime := metav1.Now()
+		for _, job := range []*batchv1.Job{idle, running} {
+			job.Status = batchv1.JobStatus{StartTime: &startTime, Active: 1, Ready: ptr.To[int32](1)}
+			Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+		}
+		runner.Status.GiteaRunnerID = 9
+		Expect(k8sClient.Status().Update(ctx, runner)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(runnerGroup), runnerGroup)).To(Succeed())
+
+		// Both runner pods are ready, but Gitea only shows one of them running a job
+		giteaClient := &deletingGiteaClient{registered: []gitea.ActionRunner{
+			{ID: 8, Name: idle.Name, Status: "online"},
+			{ID: 9, Name: running.Name, Status: "online", Busy: true},
+		}}
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &RunnerGroupReconciler{Client: k8sClient, GiteaClient: giteaClient, Recorder: recorder}
+
+		By("deleting idle runners and waiting for running ones")
+		now := runnerGroup.DeletionTimestamp.Time
+		result, err := reconciler.finalizeRunnerGroup(ctx, runnerGroup, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(deletionRecheckInterval))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pending), &batchv1.Job{})).To(Satisfy(errors.IsNotFound))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(idle), &batchv1.Job{})).To(Satisfy(errors.IsNotFound))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(running), &batchv1.Job{})).To(Succeed())
+		Expect(giteaClient.deleted).To(BeEmpty())
+
+		By("recording the wait once rather than on every recheck")
+		_, err = reconciler.finalizeRunnerGroup(ctx, runnerGroup, now.Add(deletionRecheckInterval))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("WaitingForRunners"))
+
+		By("giving up on running jobs after the grace period")
+		_, err = reconciler.finalizeRunnerGroup(ctx, runnerGroup, now.Add(10*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(giteaClient.deleted).To(Equal([]int64{9}))
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(runnerGroup), runnerGroup))
+		}).Should(BeTrue())
+	})
+})
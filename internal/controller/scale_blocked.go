@@ -0,0 +1,150 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// Reasons of the ScaleUpBlocked condition
+const (
+	scaleUpBlockedMaxActiveRunners = "MaxActiveRunnersReached"
+	scaleUpBlockedOperatorCap      = "OperatorRunnerCapReached"
+	scaleUpBlockedAutoscaling      = "AutoscalingPolicy"
+	scaleUpBlockedOutageRecovery   = "OutageRecoveryRamp"
+	scaleUpBlockedRepoQuota        = "RepoQuotaReached"
+	scaleUpBlockedRunnerArch       = "RunnerArchUnavailable"
+	scaleUpBlockedLabelMismatch    = "LabelMismatch"
+	scaleUpBlockedAuthToken        = "AuthTokenError"
+)
+
+// scaleUpBlockedCauses describes why jobs blocked for a reason got no runner
+var scaleUpBlockedCauses = map[string]string{
+	scaleUpBlockedMaxActiveRunners: "all of the group's maxActiveRunners slots are in use",
+	scaleUpBlockedOperatorCap:      "the operator-wide maxActiveRunners is reached",
+	scaleUpBlockedAutoscaling:      "the autoscaling policy holds back scale-ups",
+	scaleUpBlockedOutageRecovery:   "capacity is ramping up after a Gitea outage",
+	scaleUpBlockedRepoQuota:        "their repositories reached maxRunnersPerRepo",
+	scaleUpBlockedRunnerArch:       "no node can run a runner for their architecture",
+}
+
+// scaleUpBlockedMessage explains how many queued jobs got no runner for the reason
+func scaleUpBlockedMessage(reason string, jobs int) string {
+	return fmt.Sprintf("%d queued jobs got no runner: %s", jobs, scaleUpBlockedCauses[reason])
+}
+
+// scaleUpBlock explains why a poll that spawned no runners left queued jobs waiting. blocked
+// counts the jobs skipped for each reason; unserved are queued jobs in scope no RunnerGroup's
+// labels match. It returns false if nothing was blocked.
+func scaleUpBlock(blocked map[string]int, spawned int, unserved []gitea.ActionWorkflowJob) (string, string, bool) {
+	if spawned > 0 {
+		return "", "", false
+	}
+	if len(blocked) > 0 {
+		// Report the reason most jobs are stuck on
+		reasons := make([]string, 0, len(blocked))
+		for reason := range blocked {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if blocked[reasons[i]] != blocked[reasons[j]] {
+				return blocked[reasons[i]] > blocked[reasons[j]]
+			}
+			return reasons[i] < reasons[j]
+		})
+		return reasons[0], scaleUpBlockedMessage(reasons[0], blocked[reasons[0]]), true
+	}
+	if len(unserved) > 0 {
+		return scaleUpBlockedLabelMismatch, fmt.Sprintf("%d queued jobs match the labels of no RunnerGroup, e.g. job %d requesting %v",
+			len(unserved), unserved[0].ID, unserved[0].Labels), true
+	}
+	return "", "", false
+}
+
+// unservedJobs returns the jobs that no RunnerGroup of the same Gitea instance both covers with
+// its scope and matches with its labels
+func (r *RunnerGroupReconciler) unservedJobs(runnerGroup *giteav1alpha1.RunnerGroup, others []giteav1alpha1.RunnerGroup, jobs []gitea.ActionWorkflowJob) []gitea.ActionWorkflowJob {
+	var unserved []gitea.ActionWorkflowJob
+	for _, job := range jobs {
+		served := false
+		for i := range others {
+			other := &others[i]
+			if (other.Namespace == runnerGroup.Namespace && other.Name == runnerGroup.Name) ||
+				!other.DeletionTimestamp.IsZero() || !sameGiteaURL(other.Spec.GiteaURL, runnerGroup.Spec.GiteaURL) {
+				continue
+			}
+			if scopeCoversRepo(other, job.RepoFullName()) && jobMatchesRunnerGroup(other, getEffectiveLabels(other.Spec.Labels), job) {
+				served = true
+				break
+			}
+		}
+		if !served {
+			unserved = append(unserved, job)
+		}
+	}
+	return unserved
+}
+
+// setScaleUpBlocked marks the RunnerGroup's queued jobs as getting no runners, announcing each
+// new reason with an event
+func (r *RunnerGroupReconciler) setScaleUpBlocked(runnerGroup *giteav1alpha1.RunnerGroup, reason, message string) {
+	current := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScaleUpBlocked)
+	if (current == nil || current.Status != metav1.ConditionTrue || current.Reason != reason) && r.Recorder != nil {
+		r.Recorder.Event(runnerGroup, corev1.EventTypeWarning, reason, message)
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionScaleUpBlocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
+
+// clearScaleUpBlocked records that the RunnerGroup's queued jobs get runners again
+func clearScaleUpBlocked(runnerGroup *giteav1alpha1.RunnerGroup) {
+	if !meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScaleUpBlocked) {
+		return
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionScaleUpBlocked,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotBlocked",
+		Message:            "Queued jobs get runners",
+		ObservedGeneration: runnerGroup.Generation,
+	})
+}
+
+// authTokenRejected reports whether Gitea refused the auth token, as opposed to being unreachable
+func authTokenRejected(err error) bool {
+	return errors.Is(err, gitea.ErrUnauthorized) || errors.Is(err, gitea.ErrForbidden)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+var _ = Describe("Blocked scale-ups", func() {
+	orgGroup := func(name string, labels ...string) giteav1alpha1.RunnerGroup {
+		return giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:    giteav1alpha1.RunnerGroupScopeOrg,
+				Org:      "myorg",
+				GiteaURL: "https://gitea.example.com",
+				Labels:   labels,
+			},
+		}
+	}
+	job := func(id int64, labels ...string) gitea.ActionWorkflowJob {
+		return gitea.ActionWorkflowJob{ID: id, Labels: labels, URL: "https://gitea.example.com/api/v1/repos/myorg/app/actions/jobs/1"}
+	}
+
+	It("should not report a block when runners were spawned", func() {
+		_, _, blocked := scaleUpBlock(map[string]int{scaleUpBlockedRepoQuota: 2}, 1, nil)
+		Expect(blocked).To(BeFalse())
+		_, _, blocked = scaleUpBlock(map[string]int{}, 0, nil)
+		Expect(blocked).To(BeFalse())
+	})
+
+	It("should report the reason most jobs are blocked on", func() {
+		reason, message, blocked := scaleUpBlock(map[string]int{
+			scaleUpBlockedRepoQuota:   1,
+			scaleUpBlockedAutoscaling: 3,
+		}, 0, nil)
+		Expect(blocked).To(BeTrue())
+		Expect(reason).To(Equal(scaleUpBlockedAutoscaling))
+		Expect(message).To(Equal("3 queued jobs got no runner: the autoscaling policy holds back scale-ups"))
+	})
+
+	It("should report queued jobs no RunnerGroup's labels match", func() {
+		reason, message, blocked := scaleUpBlock(nil, 0, []gitea.ActionWorkflowJob{job(7, "gpu")})
+		Expect(blocked).To(BeTrue())
+		Expect(reason).To(Equal(scaleUpBlockedLabelMismatch))
+		Expect(message).To(ContainSubstring("job 7 requesting [gpu]"))
+	})
+
+	It("should not count jobs another RunnerGroup serves as unserved", func() {
+		reconciler := &RunnerGroupReconciler{}
+		runnerGroup := orgGroup("linux", "linux")
+		gpuGroup := orgGroup("gpu", "gpu")
+		otherOrgGroup := orgGroup("windows", "windows")
+		otherOrgGroup.Spec.Org = "otherorg"
+		groups := []giteav1alpha1.RunnerGroup{runnerGroup, gpuGroup, otherOrgGroup}
+
+		unserved := reconciler.unservedJobs(&runnerGroup, groups, []gitea.ActionWorkflowJob{job(1, "gpu"), job(2, "windows")})
+		Expect(unserved).To(HaveLen(1))
+		Expect(unserved[0].ID).To(Equal(int64(2)))
+	})
+
+	It("should announce each new reason once and clear the condition", func() {
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &RunnerGroupReconciler{Recorder: recorder}
+		runnerGroup := orgGroup("linux", "linux")
+
+		reconciler.setScaleUpBlocked(&runnerGroup, scaleUpBlockedRepoQuota, scaleUpBlockedMessage(scaleUpBlockedRepoQuota, 1))
+		reconciler.setScaleUpBlocked(&runnerGroup, scaleUpBlockedRepoQuota, scaleUpBlockedMessage(scaleUpBlockedRepoQuota, 2))
+		reconciler.setScaleUpBlocked(&runnerGroup, scaleUpBlockedAuthToken, "Gitea rejected the auth token")
+		Expect(recorder.Events).To(HaveLen(2))
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScaleUpBlocked)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(scaleUpBlockedAuthToken))
+
+		clearScaleUpBlocked(&runnerGroup)
+		Expect(meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScaleUpBlocked)).To(BeTrue())
+	})
+})
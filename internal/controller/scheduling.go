@@ -0,0 +1,248 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// orderJobsBySpawnOrder sorts queued jobs so the ones that should get a runner first come first.
+// The sort is stable, so jobs that compare equal keep the order Gitea returned them in.
+func orderJobsBySpawnOrder(jobs []gitea.ActionWorkflowJob, spawnOrder *giteav1alpha1.SpawnOrderSpec) []gitea.ActionWorkflowJob {
+	ordered := slices.Clone(jobs)
+	usePriority := spawnOrder.Strategy == giteav1alpha1.SpawnOrderPriority
+
+	slices.SortStableFunc(ordered, func(a, b gitea.ActionWorkflowJob) int {
+		if usePriority {
+			if c := cmp.Compare(jobPriority(b, spawnOrder.PriorityLabels), jobPriority(a, spawnOrder.PriorityLabels)); c != 0 {
+				return c
+			}
+		}
+		// Jobs without a creation time can't be aged; they go after the dated ones and keep their
+		// position relative to each other
+		if a.CreatedAt.IsZero() || b.CreatedAt.IsZero() {
+			return cmp.Compare(boolRank(a.CreatedAt.IsZero()), boolRank(b.CreatedAt.IsZero()))
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return ordered
+}
+
+// boolRank sorts false before true
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// jobPriority returns the highest priority among the job's labels
+func jobPriority(job gitea.ActionWorkflowJob, priorityLabels map[string]int) int {
+	priority := 0
+	found := false
+	for _, label := range job.Labels {
+		key := gitea.NormalizeLabel(label)
+		if p, ok := priorityLabels[key]; ok && (!found || p > priority) {
+			priority = p
+			found = true
+		}
+	}
+	return priority
+}
+
+// orderJobsFairly interleaves queued jobs across repositories using weighted round-robin,
+// so a single busy repository can't take every free slot. Each round, a repository
+// contributes up to its weight (default 1) jobs. Within a repository, and between
+// repositories, the original order is preserved.
+func orderJobsFairly(jobs []gitea.ActionWorkflowJob, repoWeights map[string]int) []gitea.ActionWorkflowJob {
+	return orderJobsRoundRobin(jobs, repoWeights, "")
+}
+
+// orderJobsRoundRobin is orderJobsFairly with every round starting at the repository after
+// lastServed, so the repository enumerated first doesn't get the only free slot every poll
+func orderJobsRoundRobin(jobs []gitea.ActionWorkflowJob, repoWeights map[string]int, lastServed string) []gitea.ActionWorkflowJob {
+	var repos []string
+	jobsByRepo := make(map[string][]gitea.ActionWorkflowJob)
+	for _, job := range jobs {
+		repo := job.RepoFullName()
+		if _, seen := jobsByRepo[repo]; !seen {
+			repos = append(repos, repo)
+		}
+		jobsByRepo[repo] = append(jobsByRepo[repo], job)
+	}
+	if i := slices.Index(repos, lastServed); i >= 0 {
+		repos = slices.Concat(repos[i+1:], repos[:i+1])
+	}
+
+	ordered := make([]gitea.ActionWorkflowJob, 0, len(jobs))
+	for len(ordered) < len(jobs) {
+		for _, repo := range repos {
+			weight := repoWeights[repo]
+			if weight < 1 {
+				weight = 1
+			}
+			queue := jobsByRepo[repo]
+			n := min(weight, len(queue))
+			ordered = append(ordered, queue[:n]...)
+			jobsByRepo[repo] = queue[n:]
+		}
+	}
+
+	return ordered
+}
+
+// countActiveRunnersByRepo counts the runner Jobs that are still active per repository,
+// based on the repository they were spawned for
+func countActiveRunnersByRepo(jobs []batchv1.Job) map[string]int {
+	counts := make(map[string]int)
+	for _, job := range jobs {
+		if job.Status.CompletionTime != nil {
+			continue
+		}
+		if repo := job.Annotations[repositoryAnnotation]; repo != "" {
+			counts[repo]++
+		}
+	}
+	return counts
+}
+
+// jobsForHigherPriorityGroups returns the IDs of queued jobs that a RunnerGroup should leave to
+// higher-priority groups on the same Gitea instance. Higher-priority groups claim the jobs they
+// match, most important group first, up to their free capacity; whatever they can't take is
+// overflow the lower-priority group may serve. Groups that can't reach Gitea, whose token or
+// scope Gitea rejected, that duplicate an older group or don't spawn runners right now claim
+// nothing. Groups with an event filter only claim jobs whose workflow run is among runs and
+// passes their filter.
+func (r *RunnerGroupReconciler) jobsForHigherPriorityGroups(runnerGroup *giteav1alpha1.RunnerGroup, runnerGroups []giteav1alpha1.RunnerGroup,
+	giteaInstance *giteav1alpha1.GiteaInstance, jobs []gitea.ActionWorkflowJob, runs workflowRuns, now time.Time) map[int64]bool {
+	var higher []giteav1alpha1.RunnerGroup
+	for _, other := range runnerGroups {
+		if other.Spec.Priority <= runnerGroup.Spec.Priority || other.Status.GiteaUnreachableSince != nil ||
+			!other.DeletionTimestamp.IsZero() || !sameGiteaURL(other.Spec.GiteaURL, runnerGroup.Spec.GiteaURL) {
+			continue
+		}
+		if meta.IsStatusConditionFalse(other.Status.Conditions, giteav1alpha1.ConditionTokenValid) ||
+			meta.IsStatusConditionFalse(other.Status.Conditions, giteav1alpha1.ConditionScopeVerified) ||
+			meta.IsStatusConditionTrue(other.Status.Conditions, giteav1alpha1.ConditionConflict) {
+			continue
+		}
+		if !spawnsRunners(&other, giteaInstance, now) {
+			continue
+		}
+		higher = append(higher, other)
+	}
+	if len(higher) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(higher, func(a, b giteav1alpha1.RunnerGroup) int {
+		return cmp.Compare(b.Spec.Priority, a.Spec.Priority)
+	})
+
+	claimed := make(map[int64]bool)
+	for _, other := range higher {
+		freeSlots := effectiveMaxActiveRunners(&other, now) - other.Status.ActiveRunners
+		labels := getEffectiveLabels(other.Spec.Labels)
+		for _, job := range jobs {
+			if freeSlots <= 0 {
+				break
+			}
+			if claimed[job.ID] || !scopeCoversRepo(&other, job.RepoFullName()) || !jobMatchesRunnerGroup(&other, labels, job) {
+				continue
+			}
+			if other.Spec.EventFilter != nil {
+				if run := runs[workflowRunKey(job)]; run == nil || !matchesEventFilter(other.Spec.EventFilter, run) {
+					continue
+				}
+			}
+			claimed[job.ID] = true
+			freeSlots--
+		}
+	}
+	return claimed
+}
+
+// spawnsRunners reports whether a RunnerGroup would spawn runners for its queued jobs now, as
+// last recorded in its status. Paused, draining and report-only groups don't, nor do groups
+// halted by an emergency stop, backing off after their runners kept failing, or of a Gitea
+// instance in a maintenance window.
+func spawnsRunners(runnerGroup *giteav1alpha1.RunnerGroup, giteaInstance *giteav1alpha1.GiteaInstance, now time.Time) bool {
+	if _, paused := pauseReason(runnerGroup); paused || runnerGroup.Spec.Drain || runnerGroup.Spec.ReportOnly {
+		return false
+	}
+	if meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionEmergencyStop) {
+		return false
+	}
+	if backingOffFromFailures(runnerGroup) {
+		return false
+	}
+	return activeMaintenanceWindow(giteaInstance, now) == nil
+}
+
+// effectiveMaxActiveRunners returns how many runners a RunnerGroup may run at now: the
+// maxActiveRunners of its open autoscaling schedule, or of its spec, plus the burst capacity
+// it last recorded
+func effectiveMaxActiveRunners(runnerGroup *giteav1alpha1.RunnerGroup, now time.Time) int {
+	maxActiveRunners := runnerGroup.Spec.MaxActiveRunners
+	if schedule := activeAutoscalingSchedule(runnerGroup.Spec.Autoscaling, now); schedule != nil {
+		maxActiveRunners = schedule.MaxActiveRunners
+	}
+	return maxActiveRunners + runnerGroup.Status.BurstRunners
+}
+
+// scopeCoversRepo reports whether a RunnerGroup's scope includes the "owner/name" repository.
+// Gitea owner and repository names are case-insensitive. Only global groups cover jobs whose
+// repository is unknown.
+func scopeCoversRepo(runnerGroup *giteav1alpha1.RunnerGroup, repo string) bool {
+	if runnerGroup.Spec.Scope == giteav1alpha1.RunnerGroupScopeGlobal {
+		return true
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return false
+	}
+	switch runnerGroup.Spec.Scope {
+	case giteav1alpha1.RunnerGroupScopeOrg:
+		return strings.EqualFold(owner, runnerGroup.Spec.Org)
+	case giteav1alpha1.RunnerGroupScopeUser:
+		return strings.EqualFold(owner, runnerGroup.Spec.User)
+	case giteav1alpha1.RunnerGroupScopeRepo:
+		return (strings.EqualFold(owner, runnerGroup.Spec.User) || strings.EqualFold(owner, runnerGroup.Spec.Org)) &&
+			strings.EqualFold(name, runnerGroup.Spec.Repo)
+	}
+	return false
+}
+
+// sameGiteaURL compares Gitea base URLs, ignoring a trailing slash
+func sameGiteaURL(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+func queuedJob(id int64, repo string) gitea.ActionWorkflowJob {
+	return gitea.ActionWorkflowJob{
+		ID:  id,
+		URL: fmt.Sprintf("https://gitea.example.com/api/v1/repos/%s/actions/jobs/%d", repo, id),
+	}
+}
+
+func jobIDs(jobs []gitea.ActionWorkflowJob) []int64 {
+	ids := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+var _ = Describe("orderJobsFairly", func() {
+	jobs := []gitea.ActionWorkflowJob{
+		queuedJob(1, "org/busy"),
+		queuedJob(2, "org/busy"),
+		queuedJob(3, "org/busy"),
+		queuedJob(4, "org/busy"),
+		queuedJob(5, "org/small"),
+		queuedJob(6, "org/other"),
+	}
+
+	It("should interleave repositories round-robin", func() {
+		Expect(jobIDs(orderJobsFairly(jobs, nil))).To(Equal([]int64{1, 5, 6, 2, 3, 4}))
+	})
+
+	It("should give weighted repositories more jobs per round", func() {
+		weights := map[string]int{"org/busy": 2}
+		Expect(jobIDs(orderJobsFairly(jobs, weights))).To(Equal([]int64{1, 2, 5, 6, 3, 4}))
+	})
+
+	It("should start each round after the repository served last", func() {
+		Expect(jobIDs(orderJobsRoundRobin(jobs, nil, "org/busy"))).To(Equal([]int64{5, 6, 1, 2, 3, 4}))
+		Expect(jobIDs(orderJobsRoundRobin(jobs, nil, "org/other"))).To(Equal([]int64{1, 5, 6, 2, 3, 4}))
+		Expect(jobIDs(orderJobsRoundRobin(jobs, nil, "org/gone"))).To(Equal([]int64{1, 5, 6, 2, 3, 4}))
+	})
+})
+
+var _ = Describe("countActiveRunnersByRepo", func() {
+	It("should count only active runner Jobs with a known repository", func() {
+		finished := metav1.Now()
+		jobs := []batchv1.Job{
+			{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{repositoryAnnotation: "org/a"}}},
+			{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{repositoryAnnotation: "org/a"}}},
+			{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{repositoryAnnotation: "org/b"}}},
+			{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{repositoryAnnotation: "org/b"}},
+				Status:     batchv1.JobStatus{CompletionTime: &finished},
+			},
+			{},
+		}
+		Expect(countActiveRunnersByRepo(jobs)).To(Equal(map[string]int{"org/a": 2, "org/b": 1}))
+	})
+})
+
+var _ = Describe("orderJobsBySpawnOrder", func() {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	jobs := []gitea.ActionWorkflowJob{
+		{ID: 1, CreatedAt: base.Add(3 * time.Minute), Labels: []string{"linux"}},
+		{ID: 2, CreatedAt: base.Add(1 * time.Minute), Labels: []string{"linux", "release"}},
+		{ID: 3, CreatedAt: base, Labels: []string{"linux"}},
+		{ID: 4, CreatedAt: base.Add(2 * time.Minute), Labels: []string{"hotfix"}},
+	}
+
+	It("should serve the oldest jobs first", func() {
+		spawnOrder := &giteav1alpha1.SpawnOrderSpec{Strategy: giteav1alpha1.SpawnOrderOldest}
+		Expect(jobIDs(orderJobsBySpawnOrder(jobs, spawnOrder))).To(Equal([]int64{3, 2, 4, 1}))
+	})
+
+	It("should serve the highest priority label first, then the oldest", func() {
+		spawnOrder := &giteav1alpha1.SpawnOrderSpec{
+			Strategy:       giteav1alpha1.SpawnOrderPriority,
+			PriorityLabels: map[string]int{"release": 10, "hotfix": 20},
+		}
+		Expect(jobIDs(orderJobsBySpawnOrder(jobs, spawnOrder))).To(Equal([]int64{4, 2, 3, 1}))
+	})
+
+	It("should serve jobs without a creation time after the dated ones", func() {
+		spawnOrder := &giteav1alpha1.SpawnOrderSpec{Strategy: giteav1alpha1.SpawnOrderOldest}
+		undated := []gitea.ActionWorkflowJob{
+			{ID: 1, CreatedAt: base.Add(2 * time.Minute)},
+			{ID: 2},
+			{ID: 3, CreatedAt: base},
+			{ID: 4},
+			{ID: 5, CreatedAt: base.Add(time.Minute)},
+		}
+		Expect(jobIDs(orderJobsBySpawnOrder(undated, spawnOrder))).To(Equal([]int64{3, 5, 1, 2, 4}))
+	})
+
+	It("should not modify the input slice", func() {
+		spawnOrder := &giteav1alpha1.SpawnOrderSpec{Strategy: giteav1alpha1.SpawnOrderOldest}
+		orderJobsBySpawnOrder(jobs, spawnOrder)
+		Expect(jobIDs(jobs)).To(Equal([]int64{1, 2, 3, 4}))
+	})
+})
+
+var _ = Describe("jobsForHigherPriorityGroups", func() {
+	reconciler := &RunnerGroupReconciler{}
+	newGroup := func(name string, priority, maxActiveRunners, activeRunners int) giteav1alpha1.RunnerGroup {
+		return giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				Scope:            giteav1alpha1.RunnerGroupScopeOrg,
+				Org:              "org",
+				GiteaURL:         "https://gitea.example.com",
+				MaxActiveRunners: maxActiveRunners,
+				Priority:         priority,
+			},
+			Status: giteav1alpha1.RunnerGroupStatus{ActiveRunners: activeRunners},
+		}
+	}
+	jobs := []gitea.ActionWorkflowJob{
+		queuedJob(1, "org/a"),
+		queuedJob(2, "org/b"),
+		queuedJob(3, "other/c"),
+	}
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	It("should leave jobs to higher-priority groups up to their free capacity", func() {
+		low := newGroup("low", 0, 10, 0)
+		high := newGroup("high", 10, 2, 1)
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, high}, nil, jobs, nil, now)
+		Expect(deferred).To(Equal(map[int64]bool{1: true}))
+	})
+
+	It("should not defer to groups on another Gitea instance or that can't reach Gitea", func() {
+		low := newGroup("low", 0, 10, 0)
+		otherInstance := newGroup("other", 10, 5, 0)
+		otherInstance.Spec.GiteaURL = "https://git.example.org"
+		unreachable := newGroup("unreachable", 10, 5, 0)
+		since := metav1.Now()
+		unreachable.Status.GiteaUnreachableSince = &since
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, otherInstance, unreachable}, nil, jobs, nil, now)
+		Expect(deferred).To(BeEmpty())
+	})
+
+	It("should not defer to paused groups", func() {
+		low := newGroup("low", 0, 10, 0)
+		paused := newGroup("paused", 10, 5, 0)
+		paused.Spec.Paused = true
+		annotated := newGroup("annotated", 20, 5, 0)
+		annotated.Annotations = map[string]string{pausedAnnotation: "true"}
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, paused, annotated}, nil, jobs, nil, now)
+		Expect(deferred).To(BeEmpty())
+	})
+
+	It("should not defer to groups that don't spawn runners", func() {
+		low := newGroup("low", 0, 10, 0)
+		draining := newGroup("draining", 10, 5, 0)
+		draining.Spec.Drain = true
+		stopped := newGroup("stopped", 20, 5, 0)
+		meta.SetStatusCondition(&stopped.Status.Conditions, metav1.Condition{
+			Type: giteav1alpha1.ConditionEmergencyStop, Status: metav1.ConditionTrue, Reason: "EmergencyStop"})
+		failing := newGroup("failing", 30, 5, 0)
+		meta.SetStatusCondition(&failing.Status.Conditions, metav1.Condition{
+			Type: giteav1alpha1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: degradedRunnerFailures})
+		reporting := newGroup("reporting", 40, 5, 0)
+		reporting.Spec.ReportOnly = true
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, draining, stopped, failing, reporting}, nil, jobs, nil, now)
+		Expect(deferred).To(BeEmpty())
+	})
+
+	It("should not defer to groups whose token or scope Gitea rejected or that duplicate another group", func() {
+		low := newGroup("low", 0, 10, 0)
+		var rejected []giteav1alpha1.RunnerGroup
+		for i, condition := range []metav1.Condition{
+			{Type: giteav1alpha1.ConditionTokenValid, Status: metav1.ConditionFalse, Reason: "Unauthorized"},
+			{Type: giteav1alpha1.ConditionScopeVerified, Status: metav1.ConditionFalse, Reason: "NotFound"},
+			{Type: giteav1alpha1.ConditionConflict, Status: metav1.ConditionTrue, Reason: "DuplicateRunnerGroup"},
+		} {
+			group := newGroup(condition.Type, 10+i, 5, 0)
+			meta.SetStatusCondition(&group.Status.Conditions, condition)
+			rejected = append(rejected, group)
+		}
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, append(rejected, low), nil, jobs, nil, now)
+		Expect(deferred).To(BeEmpty())
+	})
+
+	It("should only defer to event-filtered groups the jobs whose run passed their filter", func() {
+		low := newGroup("low", 0, 10, 0)
+		high := newGroup("high", 10, 5, 0)
+		high.Spec.EventFilter = &giteav1alpha1.EventFilterSpec{Events: []string{"push"}}
+		filteredJobs := slices.Clone(jobs)
+		for i := range filteredJobs {
+			filteredJobs[i].RunID = int64(10 + i)
+		}
+		Expect(reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, high}, nil, filteredJobs, nil, now)).To(BeEmpty())
+
+		runs := workflowRuns{
+			workflowRunKey(filteredJobs[0]): {ID: 10, Event: "push"},
+			workflowRunKey(filteredJobs[1]): {ID: 11, Event: "pull_request"},
+		}
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, high}, nil, filteredJobs, runs, now)
+		Expect(deferred).To(Equal(map[int64]bool{1: true}))
+	})
+
+	It("should not defer to groups of a Gitea instance in maintenance", func() {
+		low := newGroup("low", 0, 10, 0)
+		high := newGroup("high", 10, 5, 0)
+		instance := &giteav1alpha1.GiteaInstance{Spec: giteav1alpha1.GiteaInstanceSpec{
+			URL: "https://gitea.example.com",
+			MaintenanceWindows: []giteav1alpha1.MaintenanceWindow{{
+				Start: metav1.NewTime(now.Add(-time.Hour)),
+				End:   metav1.NewTime(now.Add(time.Hour)),
+			}},
+		}}
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, high}, instance, jobs, nil, now)
+		Expect(deferred).To(BeEmpty())
+	})
+
+	It("should base free capacity on the higher-priority group's effective maximum", func() {
+		low := newGroup("low", 0, 10, 0)
+		high := newGroup("high", 10, 1, 1)
+		high.Spec.Autoscaling = &giteav1alpha1.AutoscalingSpec{Schedules: []giteav1alpha1.AutoscalingSchedule{{
+			Start: "08:00", End: "18:00", MaxActiveRunners: 2,
+		}}}
+		high.Status.BurstRunners = 1
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, high}, nil, jobs, nil, now)
+		Expect(deferred).To(Equal(map[int64]bool{1: true, 2: true}))
+	})
+
+	It("should not defer jobs outside the higher-priority group's scope", func() {
+		low := newGroup("low", 0, 10, 0)
+		high := newGroup("high", 10, 5, 0)
+		deferred := reconciler.jobsForHigherPriorityGroups(&low, []giteav1alpha1.RunnerGroup{low, high}, nil, jobs, nil, now)
+		Expect(deferred).To(Equal(map[int64]bool{1: true, 2: true}))
+	})
+})
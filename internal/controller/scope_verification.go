@@ -0,0 +1,89 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// scopeRetryInterval is how often a RunnerGroup whose scope failed verification checks again
+const scopeRetryInterval = time.Minute
+
+// verifyScope checks the group's org, user or repo against Gitea once per generation, so a typo
+// or a token without access shows up as a ScopeVerified=False condition instead of failing every
+// poll. It returns false if Gitea rejected the scope; errors that may be transient, like Gitea
+// being unreachable, are left to the poll.
+func (r *RunnerGroupReconciler) verifyScope(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, authToken string) bool {
+	logger := log.FromContext(ctx)
+
+	verified := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified)
+	if verified != nil && verified.Status == metav1.ConditionTrue && verified.ObservedGeneration == runnerGroup.Generation {
+		return true
+	}
+
+	err := r.GiteaClient.VerifyScope(ctx, runnerGroup.Spec.GiteaURL, authToken, runnerGroup.Spec.Scope,
+		runnerGroup.Spec.Org, runnerGroup.Spec.User, runnerGroup.Spec.Repo)
+	var reason string
+	switch {
+	case err == nil:
+		meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+			Type:               giteav1alpha1.ConditionScopeVerified,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Verified",
+			Message:            "The scope exists in Gitea and the auth token can read its Actions jobs",
+			ObservedGeneration: runnerGroup.Generation,
+		})
+		return true
+	case errors.Is(err, gitea.ErrNotFound):
+		reason = "NotFound"
+	case errors.Is(err, gitea.ErrUnauthorized):
+		reason = "Unauthorized"
+	case errors.Is(err, gitea.ErrForbidden):
+		reason = "Forbidden"
+	default:
+		logger.Error(err, "Failed to verify scope against Gitea, polling anyway")
+		return true
+	}
+
+	logger.Info("Gitea rejected the RunnerGroup's scope", "reason", reason, "error", err.Error())
+	if authTokenRejected(err) {
+		r.setTokenInvalid(runnerGroup, "verifying the scope", err)
+	}
+	meta.SetStatusCondition(&runnerGroup.Status.Conditions, metav1.Condition{
+		Type:               giteav1alpha1.ConditionScopeVerified,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: runnerGroup.Generation,
+	})
+	return false
+}
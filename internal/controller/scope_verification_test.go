@@ -0,0 +1,100 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// scopeGiteaClient fails scope verification with err and counts the verifications
+type scopeGiteaClient struct {
+	fakeGiteaClient
+	err           error
+	verifications int
+}
+
+func (c *scopeGiteaClient) VerifyScope(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) error {
+	c.verifications++
+	return c.err
+}
+
+var _ = Describe("Scope verification", func() {
+	ctx := context.Background()
+
+	newRunnerGroup := func() *giteav1alpha1.RunnerGroup {
+		return &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Spec:       giteav1alpha1.RunnerGroupSpec{Scope: giteav1alpha1.RunnerGroupScopeOrg, Org: "typo"},
+		}
+	}
+
+	It("should verify once per generation", func() {
+		giteaClient := &scopeGiteaClient{}
+		reconciler := &RunnerGroupReconciler{GiteaClient: giteaClient}
+		runnerGroup := newRunnerGroup()
+
+		Expect(reconciler.verifyScope(ctx, runnerGroup, "token")).To(BeTrue())
+		Expect(reconciler.verifyScope(ctx, runnerGroup, "token")).To(BeTrue())
+		Expect(giteaClient.verifications).To(Equal(1))
+		Expect(meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified)).To(BeTrue())
+
+		runnerGroup.Generation = 2
+		Expect(reconciler.verifyScope(ctx, runnerGroup, "token")).To(BeTrue())
+		Expect(giteaClient.verifications).To(Equal(2))
+	})
+
+	It("should hold off polling a scope Gitea rejected", func() {
+		giteaClient := &scopeGiteaClient{err: fmt.Errorf("%w for verify organization typo", gitea.ErrNotFound)}
+		reconciler := &RunnerGroupReconciler{GiteaClient: giteaClient}
+		runnerGroup := newRunnerGroup()
+
+		Expect(reconciler.verifyScope(ctx, runnerGroup, "token")).To(BeFalse())
+		condition := meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("NotFound"))
+
+		// A rejected scope is checked again on the next reconcile
+		giteaClient.err = nil
+		Expect(reconciler.verifyScope(ctx, runnerGroup, "token")).To(BeTrue())
+		Expect(giteaClient.verifications).To(Equal(2))
+	})
+
+	It("should leave transient errors to the poll", func() {
+		reconciler := &RunnerGroupReconciler{GiteaClient: &scopeGiteaClient{err: fmt.Errorf("connection refused")}}
+		runnerGroup := newRunnerGroup()
+
+		Expect(reconciler.verifyScope(ctx, runnerGroup, "token")).To(BeTrue())
+		Expect(meta.FindStatusCondition(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified)).To(BeNil())
+	})
+})
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// spotNodes is how a platform marks its spot nodes: the node label they carry and, if it taints
+// them, the taint's effect
+type spotNodes struct {
+	labelKey    string
+	labelValue  string
+	taintEffect corev1.TaintEffect
+}
+
+// spotProviders are the spot node labels and taints of the supported platforms
+var spotProviders = map[giteav1alpha1.SpotProvider]spotNodes{
+	giteav1alpha1.SpotProviderGKE:       {labelKey: "cloud.google.com/gke-spot", labelValue: "true", taintEffect: corev1.TaintEffectNoSchedule},
+	giteav1alpha1.SpotProviderEKS:       {labelKey: "eks.amazonaws.com/capacityType", labelValue: "SPOT"},
+	giteav1alpha1.SpotProviderKarpenter: {labelKey: "karpenter.sh/capacity-type", labelValue: "spot"},
+	giteav1alpha1.SpotProviderAKS:       {labelKey: "kubernetes.azure.com/scalesetpriority", labelValue: "spot", taintEffect: corev1.TaintEffectNoSchedule},
+}
+
+// podPreemptedReasons are the pod status reasons of pods stopped because their node went away
+var podPreemptedReasons = map[string]bool{
+	"Terminated":   true,
+	"Shutdown":     true,
+	"NodeShutdown": true,
+	"NodeLost":     true,
+}
+
+// applySpotProfile makes the runner pod tolerate the provider's spot taint and steers it to
+// spot nodes, requiring them only if the group asks to
+func applySpotProfile(podSpec *corev1.PodSpec, spot *giteav1alpha1.SpotSpec) {
+	if spot == nil {
+		return
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, spot.Tolerations...)
+	nodes, ok := spotProviders[spot.Provider]
+	if !ok {
+		return
+	}
+	if nodes.taintEffect != "" {
+		podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+			Key:      nodes.labelKey,
+			Operator: corev1.TolerationOpEqual,
+			Value:    nodes.labelValue,
+			Effect:   nodes.taintEffect,
+		})
+	}
+
+	term := corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+		Key:      nodes.labelKey,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{nodes.labelValue},
+	}}}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if spot.Required {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{term},
+		}
+		return
+	}
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{Weight: 100, Preference: term})
+}
+
+// podPreempted reports whether the pod was stopped because its spot node was reclaimed or shut
+// down, rather than because the runner failed or an on-demand node went away. node is the pod's
+// node, nil if it was deleted.
+func podPreempted(pod *corev1.Pod, node *corev1.Node, spot *giteav1alpha1.SpotSpec) bool {
+	if !onSpotNode(node, spot) {
+		return false
+	}
+	if podPreemptedReasons[pod.Status.Reason] {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue &&
+			condition.Reason != "PreemptionByScheduler" {
+			return true
+		}
+	}
+	return false
+}
+
+// onSpotNode reports whether the node carries the provider's spot label. Reclaimed spot nodes
+// are often deleted before their pods are looked at; a deleted node only counts as a spot node
+// if the group requires spot nodes, since its runners can't have run anywhere else.
+func onSpotNode(node *corev1.Node, spot *giteav1alpha1.SpotSpec) bool {
+	nodes, ok := spotProviders[spot.Provider]
+	if !ok {
+		return false
+	}
+	if node == nil {
+		return spot.Required
+	}
+	return node.Labels[nodes.labelKey] == nodes.labelValue
+}
+
+// preemptedRunners returns the unfinished runner Jobs whose latest pod was preempted, with that
+// pod. nodes holds the pods' nodes by name, nil for deleted ones.
+func preemptedRunners(jobs []batchv1.Job, pods []corev1.Pod, nodes map[string]*corev1.Node, spot *giteav1alpha1.SpotSpec) map[*batchv1.Job]*corev1.Pod {
+	podsByJob := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if jobName := pod.Labels[batchv1.JobNameLabel]; jobName != "" {
+			podsByJob[jobName] = append(podsByJob[jobName], pod)
+		}
+	}
+	preempted := make(map[*batchv1.Job]*corev1.Pod)
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime != nil || job.DeletionTimestamp != nil {
+			continue
+		}
+		// A replacement pod the Job controller already started supersedes the preempted one
+		if pod := newestPod(podsByJob[job.Name]); pod != nil && pod.Spec.NodeName != "" && podPreempted(pod, nodes[pod.Spec.NodeName], spot) {
+			preempted[job] = pod
+		}
+	}
+	return preempted
+}
+
+// respawnPreemptedRunners removes the runners whose spot node was reclaimed, releasing their Gitea
+// job so the next poll spawns a replacement if it is still queued. Removing them before the Job
+// controller gives up on them keeps them from counting against the failure budget. It returns the
+// names of the runner Jobs it removed.
+func (r *RunnerGroupReconciler) respawnPreemptedRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job) (map[string]bool, error) {
+	logger := log.FromContext(ctx)
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(runnerGroup.Namespace), client.MatchingLabels{
+		runnerGroupNameLabel: runnerGroup.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list runner pods: %w", err)
+	}
+
+	nodes := make(map[string]*corev1.Node)
+	for _, pod := range podList.Items {
+		if _, ok := nodes[pod.Spec.NodeName]; ok || pod.Spec.NodeName == "" {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+			}
+			node = nil
+		}
+		nodes[pod.Spec.NodeName] = node
+	}
+
+	removed := make(map[string]bool)
+	for job, pod := range preemptedRunners(jobs, podList.Items, nodes, runnerGroup.Spec.Spot) {
+		logger.Info("Replacing runner whose spot node was reclaimed", "jobName", job.Name, "pod", pod.Name, "node", pod.Spec.NodeName)
+		if err := r.removeRunner(ctx, runnerGroup, job, pod); err != nil {
+			return removed, err
+		}
+		removed[job.Name] = true
+		runnersPreempted.WithLabelValues(runnerGroup.Namespace, runnerGroup.Name).Inc()
+		if r.Recorder != nil {
+			r.Recorder.Eventf(runnerGroup, corev1.EventTypeNormal, "RunnerPreempted",
+				"Runner %s lost its spot node %s; its job gets a new runner if it is still queued", job.Name, pod.Spec.NodeName)
+		}
+	}
+	return removed, nil
+}
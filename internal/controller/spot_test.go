@@ -0,0 +1,129 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Spot runners", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	spot := &giteav1alpha1.SpotSpec{Provider: giteav1alpha1.SpotProviderKarpenter}
+	spotNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "spot", Labels: map[string]string{"karpenter.sh/capacity-type": "spot"}}}
+	onDemandNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "on-demand", Labels: map[string]string{"karpenter.sh/capacity-type": "on-demand"}}}
+
+	runnerPod := func(name, jobName string, created time.Time) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{batchv1.JobNameLabel: jobName},
+				CreationTimestamp: metav1.Time{Time: created},
+			},
+			Spec: corev1.PodSpec{NodeName: spotNode.Name},
+		}
+	}
+
+	It("should tolerate the spot taint and prefer spot nodes", func() {
+		podSpec := corev1.PodSpec{}
+		applySpotProfile(&podSpec, &giteav1alpha1.SpotSpec{
+			Provider:    giteav1alpha1.SpotProviderGKE,
+			Tolerations: []corev1.Toleration{{Key: "pool", Operator: corev1.TolerationOpExists}},
+		})
+
+		Expect(podSpec.Tolerations).To(ConsistOf(
+			corev1.Toleration{Key: "pool", Operator: corev1.TolerationOpExists},
+			corev1.Toleration{Key: "cloud.google.com/gke-spot", Operator: corev1.TolerationOpEqual, Value: "true",
+				Effect: corev1.TaintEffectNoSchedule},
+		))
+		Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeNil())
+		preferred := podSpec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+		Expect(preferred).To(HaveLen(1))
+		Expect(preferred[0].Preference.MatchExpressions[0].Key).To(Equal("cloud.google.com/gke-spot"))
+	})
+
+	It("should require spot nodes when asked to", func() {
+		podSpec := corev1.PodSpec{}
+		applySpotProfile(&podSpec, &giteav1alpha1.SpotSpec{Provider: giteav1alpha1.SpotProviderKarpenter, Required: true})
+
+		// Karpenter doesn't taint its spot nodes
+		Expect(podSpec.Tolerations).To(BeEmpty())
+		required := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		Expect(required.NodeSelectorTerms).To(HaveLen(1))
+		Expect(required.NodeSelectorTerms[0].MatchExpressions[0].Values).To(Equal([]string{"spot"}))
+	})
+
+	It("should tell preempted pods from failed ones", func() {
+		shutdown := runnerPod("shutdown", "job", now)
+		shutdown.Status.Reason = "Terminated"
+		disrupted := runnerPod("disrupted", "job", now)
+		disrupted.Status.Conditions = []corev1.PodCondition{{
+			Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: "DeletionByTaintManager",
+		}}
+		failed := runnerPod("failed", "job", now)
+		failed.Status.Phase = corev1.PodFailed
+
+		Expect(podPreempted(&shutdown, spotNode, spot)).To(BeTrue())
+		Expect(podPreempted(&disrupted, spotNode, spot)).To(BeTrue())
+		Expect(podPreempted(&failed, spotNode, spot)).To(BeFalse())
+	})
+
+	It("should only count pods of spot nodes as preempted", func() {
+		shutdown := runnerPod("shutdown", "job", now)
+		shutdown.Status.Reason = "NodeShutdown"
+
+		Expect(podPreempted(&shutdown, onDemandNode, spot)).To(BeFalse())
+
+		By("counting pods of deleted nodes only if the group requires spot nodes")
+		Expect(podPreempted(&shutdown, nil, spot)).To(BeFalse())
+		Expect(podPreempted(&shutdown, nil, &giteav1alpha1.SpotSpec{Provider: giteav1alpha1.SpotProviderKarpenter, Required: true})).To(BeTrue())
+	})
+
+	It("should only replace runners whose latest pod was preempted", func() {
+		preemptedPod := runnerPod("preempted-1", "preempted", now)
+		preemptedPod.Status.Reason = "NodeShutdown"
+		supersededPod := runnerPod("superseded-1", "superseded", now.Add(-time.Minute))
+		supersededPod.Status.Reason = "NodeShutdown"
+		jobs := []batchv1.Job{
+			{ObjectMeta: metav1.ObjectMeta{Name: "preempted"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "superseded"}},
+		}
+		pods := []corev1.Pod{preemptedPod, supersededPod, runnerPod("superseded-2", "superseded", now)}
+
+		preempted := preemptedRunners(jobs, pods, map[string]*corev1.Node{spotNode.Name: spotNode}, spot)
+		Expect(preempted).To(HaveLen(1))
+		for job, pod := range preempted {
+			Expect(job.Name).To(Equal("preempted"))
+			Expect(pod.Name).To(Equal("preempted-1"))
+		}
+	})
+})
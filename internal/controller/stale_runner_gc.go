@@ -0,0 +1,197 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+)
+
+// staleRunnerGCInterval is how often Gitea is checked for stale runners
+const staleRunnerGCInterval = 5 * time.Minute
+
+// staleRunnerGC deletes runners the operator registered in Gitea that stayed offline for the
+// operator config's staleRunnerAge, e.g. of pods killed before they could deregister and left
+// behind by Gitea versions that don't remove ephemeral runners. Gitea doesn't tell how long a
+// runner has been offline, so the collector measures it from when it first saw the runner offline.
+type staleRunnerGC struct {
+	reconciler *RunnerGroupReconciler
+	// offlineSince holds when each offline runner was first seen offline
+	offlineSince map[staleRunnerKey]time.Time
+}
+
+// staleRunnerKey identifies a runner registered in a Gitea instance
+type staleRunnerKey struct {
+	giteaURL string
+	id       int64
+}
+
+// Start implements manager.Runnable
+func (g *staleRunnerGC) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-time.After(staleRunnerGCInterval):
+			g.collect(ctx, now)
+		}
+	}
+}
+
+// gcScope is a scope RunnerGroups provision runners for, with the context and auth token of the
+// first of them to list its runners with
+type gcScope struct {
+	ctx          context.Context
+	authToken    string
+	runnerGroups []*giteav1alpha1.RunnerGroup
+}
+
+// collect lists the runners of every scope the RunnerGroups provision runners for and deletes
+// those of the groups that have been offline for staleRunnerAge. Runners with a Runner are left
+// to the Runner controller.
+func (g *staleRunnerGC) collect(ctx context.Context, now time.Time) {
+	logger := log.FromContext(ctx).WithName("stale-runner-gc")
+	r := g.reconciler
+
+	age := r.staleRunnerAge()
+	if age <= 0 {
+		g.offlineSince = nil
+		return
+	}
+	if g.offlineSince == nil {
+		g.offlineSince = make(map[staleRunnerKey]time.Time)
+	}
+
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return
+	}
+	runnerList := &giteav1alpha1.RunnerList{}
+	if err := r.List(ctx, runnerList); err != nil {
+		logger.Error(err, "Failed to list Runners")
+		return
+	}
+	live := make(map[string]bool, len(runnerList.Items))
+	for _, runner := range runnerList.Items {
+		live[runner.Name] = true
+	}
+
+	scopes := make(map[queuedJobsKey]*gcScope)
+	for i := range runnerGroupList.Items {
+		runnerGroup := &runnerGroupList.Items[i]
+		if meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified) {
+			continue
+		}
+		groupCtx, err := withGiteaInstance(withGroupProxy(ctx, runnerGroup), r.Client, runnerGroup.Spec.GiteaURL)
+		if err != nil {
+			logger.Error(err, "Failed to get GiteaInstance", "runnerGroup", client.ObjectKeyFromObject(runnerGroup))
+			continue
+		}
+		if runnerGroup.Spec.Sudo != "" {
+			groupCtx = gitea.WithSudo(groupCtx, runnerGroup.Spec.Sudo)
+		}
+		authToken, err := readAuthToken(groupCtx, r.Client, runnerGroup, r.AuthTokenSources)
+		if err != nil {
+			continue
+		}
+		key := newQueuedJobsKey(groupCtx, runnerGroup, authToken)
+		scope := scopes[key]
+		if scope == nil {
+			scope = &gcScope{ctx: groupCtx, authToken: authToken}
+			scopes[key] = scope
+		}
+		scope.runnerGroups = append(scope.runnerGroups, runnerGroup)
+	}
+
+	seen := make(map[staleRunnerKey]bool)
+	unlisted := make(map[string]bool)
+	for key, scope := range scopes {
+		registered, err := r.GiteaClient.ListRunners(scope.ctx, key.giteaURL, scope.authToken, key.scope, key.org, key.user, key.repo)
+		if err != nil {
+			logger.Info("Failed to list runners registered in Gitea", "giteaURL", key.giteaURL, "scope", key.scope,
+				"org", key.org, "user", key.user, "repo", key.repo, "error", err.Error())
+			unlisted[key.giteaURL] = true
+			continue
+		}
+		for _, runner := range registered {
+			if live[runner.Name] || !provisionedRunner(runner, scope.runnerGroups) {
+				continue
+			}
+			runnerKey := staleRunnerKey{giteaURL: key.giteaURL, id: runner.ID}
+			seen[runnerKey] = true
+			if runner.Online() {
+				delete(g.offlineSince, runnerKey)
+				continue
+			}
+			since, known := g.offlineSince[runnerKey]
+			if !known {
+				g.offlineSince[runnerKey] = now
+				continue
+			}
+			if now.Sub(since) < age {
+				continue
+			}
+			err := r.GiteaClient.DeleteRunner(scope.ctx, key.giteaURL, scope.authToken, key.scope, key.org, key.user, key.repo, runner.ID)
+			if err != nil {
+				logger.Error(err, "Failed to delete stale runner from Gitea", "giteaURL", key.giteaURL, "runner", runner.Name)
+				continue
+			}
+			logger.Info("Deleted runner that stayed offline from Gitea", "giteaURL", key.giteaURL, "runner", runner.Name,
+				"offlineFor", now.Sub(since).Round(time.Second))
+			staleRunnersDeleted.Inc()
+			delete(g.offlineSince, runnerKey)
+		}
+	}
+
+	// Forget runners that are gone, unless their instance couldn't be asked
+	for runnerKey := range g.offlineSince {
+		if !seen[runnerKey] && !unlisted[runnerKey.giteaURL] {
+			delete(g.offlineSince, runnerKey)
+		}
+	}
+}
+
+// provisionedRunner reports whether the operator provisioned the runner for one of the
+// RunnerGroups: it has the name the operator gives the group's runners, the group's name and a
+// random suffix, and registered with the group's provisionedRunnerLabel
+func provisionedRunner(runner gitea.ActionRunner, runnerGroups []*giteav1alpha1.RunnerGroup) bool {
+	for _, runnerGroup := range runnerGroups {
+		suffix, ok := strings.CutPrefix(runner.Name, runnerGroup.Name+"-")
+		if !ok || len(suffix) != runnerNameSuffixLength || strings.Trim(suffix, runnerNameCharset) != "" {
+			continue
+		}
+		if runner.HasLabel(provisionedRunnerLabel(runnerGroup)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"github.com/bapung/gitea-runner-operator/internal/gitea"
+	"github.com/bapung/gitea-runner-operator/internal/operatorconfig"
+)
+
+// staleGiteaClient lists the runners set in registered and records the IDs of deleted ones
+type staleGiteaClient struct {
+	fakeGiteaClient
+	registered []gitea.ActionRunner
+	deleted    []int64
+}
+
+func (c *staleGiteaClient) ListRunners(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string) ([]gitea.ActionRunner, error) {
+	return c.registered, nil
+}
+
+func (c *staleGiteaClient) DeleteRunner(ctx context.Context, giteaURL, authToken string, scope giteav1alpha1.RunnerGroupScope, org string, user string, repo string, id int64) error {
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+var _ = Describe("Stale runner GC", func() {
+	ctx := context.Background()
+
+	It("should delete the groups' runners once they stayed offline for staleRunnerAge", func() {
+		configFile := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(configFile, []byte("staleRunnerAge: 1h\n"), 0o600)).To(Succeed())
+		config := &operatorconfig.Watcher{Path: configFile}
+		Expect(config.Load()).To(Succeed())
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gc-secret", Namespace: "default"},
+			StringData: map[string]string{"token": "admin-token"},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+		secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "token"}
+		runnerGroup := &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "gc", Namespace: "default"},
+			Spec: giteav1alpha1.RunnerGroupSpec{
+				GiteaURL:             "https://gc.example.com",
+				Scope:                giteav1alpha1.RunnerGroupScopeOrg,
+				Org:                  "myorg",
+				MaxActiveRunners:     1,
+				RegistrationTokenRef: &secretRef,
+				AuthTokenRef:         secretRef,
+			},
+		}
+		Expect(k8sClient.Create(ctx, runnerGroup)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runnerGroup)).To(Succeed()) })
+		runner := &giteav1alpha1.Runner{
+			ObjectMeta: metav1.ObjectMeta{Name: "gc-live0000", Namespace: "default"},
+			Spec:       giteav1alpha1.RunnerSpec{RunnerGroupName: runnerGroup.Name, Image: "act_runner"},
+		}
+		Expect(k8sClient.Create(ctx, runner)).To(Succeed())
+		DeferCleanup(func() { Expect(k8sClient.Delete(ctx, runner)).To(Succeed()) })
+
+		provisioned := []gitea.ActionRunnerLabel{{Name: "ubuntu-latest"}, {Name: provisionedRunnerLabel(runnerGroup)}}
+		giteaClient := &staleGiteaClient{registered: []gitea.ActionRunner{
+			{ID: 1, Name: "gc-abcd1234", Status: "offline", Labels: provisioned},
+			{ID: 2, Name: "gc-live0000", Status: "offline", Labels: provisioned},
+			{ID: 3, Name: "gc-efgh5678", Status: "online", Labels: provisioned},
+			{ID: 4, Name: "gc-hand-made", Status: "offline"},
+			{ID: 5, Name: "someone-elses", Status: "offline"},
+			{ID: 6, Name: "gc-ijkl9012", Status: "offline", Labels: []gitea.ActionRunnerLabel{{Name: "ubuntu-latest"}}},
+		}}
+		gc := &staleRunnerGC{reconciler: &RunnerGroupReconciler{Client: k8sClient, GiteaClient: giteaClient, Config: config}}
+
+		now := time.Now()
+		gc.collect(ctx, now)
+		gc.collect(ctx, now.Add(30*time.Minute))
+		Expect(giteaClient.deleted).To(BeEmpty())
+
+		gc.collect(ctx, now.Add(time.Hour))
+		Expect(giteaClient.deleted).To(Equal([]int64{1}))
+	})
+
+	It("should only claim runners named and labeled like the ones the operator provisions", func() {
+		linux := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux", UID: "linux-uid"}}
+		linuxArm := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "linux-arm", UID: "linux-arm-uid"}}
+		runner := func(name string, runnerGroup *giteav1alpha1.RunnerGroup) gitea.ActionRunner {
+			return gitea.ActionRunner{Name: name, Labels: []gitea.ActionRunnerLabel{{Name: provisionedRunnerLabel(runnerGroup)}}}
+		}
+		groups := []*giteav1alpha1.RunnerGroup{linux}
+
+		Expect(provisionedRunner(runner("linux-abcd1234", linux), groups)).To(BeTrue())
+		Expect(provisionedRunner(gitea.ActionRunner{Name: "linux-abcd1234"}, groups)).To(BeFalse())
+		Expect(provisionedRunner(runner("linux-arm-abcd1234", linux), groups)).To(BeFalse())
+		Expect(provisionedRunner(runner("linux-arm-abcd1234", linuxArm), groups)).To(BeFalse())
+		Expect(provisionedRunner(runner("linux-arm-abcd1234", linuxArm), []*giteav1alpha1.RunnerGroup{linux, linuxArm})).To(BeTrue())
+		Expect(provisionedRunner(runner("linux-ABCD1234", linux), groups)).To(BeFalse())
+		Expect(provisionedRunner(runner("linux-abcd123", linux), groups)).To(BeFalse())
+	})
+})
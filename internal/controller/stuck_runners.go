@@ -0,0 +1,98 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// stuckRunnerJobs returns the runner Jobs whose runner has been up for longer than maxDuration
+func stuckRunnerJobs(jobs []batchv1.Job, maxDuration time.Duration, now time.Time) []*batchv1.Job {
+	var stuck []*batchv1.Job
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.CompletionTime != nil || job.DeletionTimestamp != nil || jobFailed(job) {
+			continue
+		}
+		if job.Status.StartTime == nil || ptr.Deref(job.Status.Ready, 0) == 0 {
+			continue
+		}
+		if now.Sub(job.Status.StartTime.Time) > maxDuration {
+			stuck = append(stuck, job)
+		}
+	}
+	return stuck
+}
+
+// killStuckRunners deregisters the runners running for longer than the group's maximum job
+// duration from Gitea and deletes their Jobs, so hung builds don't hold a slot forever. It
+// returns the names of the Jobs it deleted. A failed deregistration doesn't keep the Job; the
+// Runner controller deregisters the runner again once the Job is gone.
+func (r *RunnerGroupReconciler) killStuckRunners(ctx context.Context, runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job, now time.Time) (map[string]bool, error) {
+	logger := log.FromContext(ctx)
+	maxDuration := runnerGroup.Spec.MaxJobDuration.Duration
+
+	stuck := stuckRunnerJobs(jobs, maxDuration, now)
+	if len(stuck) == 0 {
+		return nil, nil
+	}
+	authToken, err := readAuthToken(ctx, r.Client, runnerGroup, r.AuthTokenSources)
+	if err != nil {
+		logger.Error(err, "Failed to read auth token, leaving stuck runners to the Runner controller to deregister")
+	}
+
+	killed := make(map[string]bool)
+	for _, job := range stuck {
+		runner := &giteav1alpha1.Runner{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(job), runner); err != nil && !errors.IsNotFound(err) {
+			return killed, fmt.Errorf("failed to get Runner %s: %w", job.Name, err)
+		} else if err == nil && authToken != "" && mayBeRegistered(runner) {
+			if err := deregisterGiteaRunner(ctx, r.GiteaClient, runnerGroup, authToken, runner); err != nil {
+				logger.Error(err, "Failed to deregister stuck runner from Gitea", "runner", runner.Name)
+			}
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return killed, fmt.Errorf("failed to delete runner Job %s: %w", job.Name, err)
+		}
+		killed[job.Name] = true
+		running := now.Sub(job.Status.StartTime.Time).Round(time.Second)
+		logger.Info("Killed runner running past the maximum job duration", "job", job.Name, "running", running, "maxJobDuration", maxDuration)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(runnerGroup, corev1.EventTypeWarning, "RunnerStuck",
+				"Killed runner %s after running for %s, exceeding the maximum job duration of %s", job.Name, running, maxDuration)
+		}
+	}
+	return killed, nil
+}
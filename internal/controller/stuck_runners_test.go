@@ -0,0 +1,65 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Maximum job duration", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	runningSince := func(name string, started time.Time) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: started}, Ready: ptr.To[int32](1)},
+		}
+	}
+
+	It("should only report runners up for longer than the maximum duration", func() {
+		finished := runningSince("finished", now.Add(-3*time.Hour))
+		finished.Status.CompletionTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+		pending := runningSince("pending", now.Add(-3*time.Hour))
+		pending.Status.Ready = ptr.To[int32](0)
+		deleting := runningSince("deleting", now.Add(-3*time.Hour))
+		deleting.DeletionTimestamp = &metav1.Time{Time: now}
+		jobs := []batchv1.Job{
+			runningSince("recent", now.Add(-30*time.Minute)),
+			runningSince("hung", now.Add(-2*time.Hour)),
+			finished,
+			pending,
+			deleting,
+		}
+
+		stuck := stuckRunnerJobs(jobs, time.Hour, now)
+		Expect(stuck).To(HaveLen(1))
+		Expect(stuck[0].Name).To(Equal("hung"))
+	})
+})
@@ -24,16 +24,23 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -86,8 +93,11 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
-	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	directClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
+	indexed := &indexingClient{Client: directClient, indexes: make(map[indexKey]client.IndexerFunc)}
+	Expect(indexOwners(ctx, indexed)).To(Succeed())
+	k8sClient = indexed
 	Expect(k8sClient).NotTo(BeNil())
 })
 
@@ -120,3 +130,63 @@ func getFirstFoundEnvTestBinaryDir() string {
 	}
 	return ""
 }
+
+type indexKey struct {
+	gvk   schema.GroupVersionKind
+	field string
+}
+
+// indexingClient serves the field indexes the manager's cache would, which the API server
+// doesn't know, by filtering List results with the registered index functions
+type indexingClient struct {
+	client.Client
+	indexes map[indexKey]client.IndexerFunc
+}
+
+// IndexField implements client.FieldIndexer
+func (c *indexingClient) IndexField(_ context.Context, obj client.Object, field string, extract client.IndexerFunc) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	c.indexes[indexKey{gvk: gvk, field: field}] = extract
+	return nil
+}
+
+func (c *indexingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	if listOptions.FieldSelector == nil || listOptions.FieldSelector.Empty() {
+		return c.Client.List(ctx, list, opts...)
+	}
+	requirements := listOptions.FieldSelector.Requirements()
+	listOptions.FieldSelector = nil
+	if err := c.Client.List(ctx, list, listOptions); err != nil {
+		return err
+	}
+
+	gvk, err := apiutil.GVKForObject(list, c.Scheme())
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	var matching []runtime.Object
+	for _, item := range items {
+		matches := true
+		for _, requirement := range requirements {
+			extract, ok := c.indexes[indexKey{gvk: gvk, field: requirement.Field}]
+			if !ok {
+				return fmt.Errorf("no index for field %s of %s", requirement.Field, gvk.Kind)
+			}
+			matches = matches && slices.Contains(extract(item.(client.Object)), requirement.Value)
+		}
+		if matches {
+			matching = append(matching, item)
+		}
+	}
+	return meta.SetList(list, matching)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// utilizationRecordedAnnotation marks a finished runner Job already counted in status.utilization
+const utilizationRecordedAnnotation = "gitea.bpg.pw/utilization-recorded"
+
+// unrecordedFinishedRunners returns the runner Jobs that completed or failed and aren't counted
+// in the group's utilization and usage yet
+func unrecordedFinishedRunners(jobs []batchv1.Job) []*batchv1.Job {
+	var finished []*batchv1.Job
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status.StartTime == nil || job.DeletionTimestamp != nil {
+			continue
+		}
+		if job.Status.CompletionTime == nil && !jobFailed(job) {
+			continue
+		}
+		if job.Annotations[utilizationRecordedAnnotation] == "true" {
+			continue
+		}
+		finished = append(finished, job)
+	}
+	return finished
+}
+
+// recordRunnersWithJob adds runners that ran a job, and how long they ran, to the group's utilization
+func recordRunnersWithJob(runnerGroup *giteav1alpha1.RunnerGroup, durations []time.Duration) {
+	if len(durations) == 0 {
+		return
+	}
+	utilization := groupUtilization(runnerGroup)
+	total := utilization.AverageJobDuration.Duration * time.Duration(utilization.RunnersWithJob)
+	for _, duration := range durations {
+		total += duration
+	}
+	utilization.RunnersWithJob += int64(len(durations))
+	utilization.AverageJobDuration = metav1.Duration{Duration: (total / time.Duration(utilization.RunnersWithJob)).Round(time.Second)}
+	utilization.UtilizationPercent = utilizationPercent(utilization)
+}
+
+// recordIdleRunners adds runners deleted without ever picking up a job to the group's utilization
+func recordIdleRunners(runnerGroup *giteav1alpha1.RunnerGroup, idle int) {
+	if idle == 0 {
+		return
+	}
+	utilization := groupUtilization(runnerGroup)
+	utilization.IdleRunners += int64(idle)
+	utilization.UtilizationPercent = utilizationPercent(utilization)
+}
+
+// groupUtilization returns the group's utilization status, creating it on first use
+func groupUtilization(runnerGroup *giteav1alpha1.RunnerGroup) *giteav1alpha1.RunnerUtilization {
+	if runnerGroup.Status.Utilization == nil {
+		runnerGroup.Status.Utilization = &giteav1alpha1.RunnerUtilization{}
+	}
+	return runnerGroup.Status.Utilization
+}
+
+// utilizationPercent is the share of the finished runners that ran a job
+func utilizationPercent(utilization *giteav1alpha1.RunnerUtilization) int {
+	finished := utilization.RunnersWithJob + utilization.IdleRunners
+	if finished == 0 {
+		return 0
+	}
+	return int(utilization.RunnersWithJob * 100 / finished)
+}
+
+// runnerOutcome is whether a finished runner ran a job
+type runnerOutcome int
+
+const (
+	// runnerOutcomeUnknown is a failed runner Gitea never listed, which counts as neither
+	runnerOutcomeUnknown runnerOutcome = iota
+	runnerOutcomeRanJob
+	runnerOutcomeIdle
+)
+
+// finishedRunnerOutcome tells whether a finished runner Job's runner ran a job. seen holds the
+// runners Gitea listed, by Job name, and whether it ever showed them busy. A runner Gitea showed
+// busy ran a job, and one it only ever showed idle exited without one. Runners Gitea never
+// listed ran a job if they completed, since ephemeral runners only exit cleanly after their job.
+func finishedRunnerOutcome(job *batchv1.Job, seen map[string]bool) runnerOutcome {
+	if busy, listed := seen[job.Name]; listed {
+		if busy {
+			return runnerOutcomeRanJob
+		}
+		return runnerOutcomeIdle
+	}
+	if job.Status.CompletionTime != nil {
+		return runnerOutcomeRanJob
+	}
+	return runnerOutcomeUnknown
+}
+
+// recordFinishedRunners counts the group's newly finished runner Jobs in its utilization and
+// usage. It returns the Jobs it counted, to be marked with markRunnersRecorded once the status
+// holding them is written.
+func (r *RunnerGroupReconciler) recordFinishedRunners(runnerGroup *giteav1alpha1.RunnerGroup, jobs []batchv1.Job, now time.Time) []*batchv1.Job {
+	key := client.ObjectKeyFromObject(runnerGroup)
+	var seen map[string]bool
+	if value, ok := r.seenRunners.Load(key); ok {
+		seen = value.(map[string]bool)
+	}
+
+	finished := unrecordedFinishedRunners(jobs)
+	var durations []time.Duration
+	var runTime time.Duration
+	idle := 0
+	for _, job := range finished {
+		runTime += runnerRunTime(job, now)
+		switch finishedRunnerOutcome(job, seen) {
+		case runnerOutcomeRanJob:
+			durations = append(durations, runnerRunTime(job, now))
+		case runnerOutcomeIdle:
+			idle++
+		}
+	}
+	recordRunnersWithJob(runnerGroup, durations)
+	recordIdleRunners(runnerGroup, idle)
+	recordRunnerTime(runnerGroup, runTime, now)
+	return finished
+}
+
+// markRunnersRecorded marks finished runner Jobs as counted, so they aren't counted again
+// before their TTL removes them
+func (r *RunnerGroupReconciler) markRunnersRecorded(ctx context.Context, jobs []*batchv1.Job) error {
+	for _, job := range jobs {
+		patch := client.MergeFrom(job.DeepCopy())
+		metav1.SetMetaDataAnnotation(&job.ObjectMeta, utilizationRecordedAnnotation, "true")
+		if err := r.Patch(ctx, job, patch); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to mark runner Job %s as recorded: %w", job.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Runner utilization", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	finishedAfter := func(name string, ran time.Duration) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: batchv1.JobStatus{
+				StartTime:      &metav1.Time{Time: now.Add(-ran)},
+				CompletionTime: &metav1.Time{Time: now},
+			},
+		}
+	}
+
+	It("should only return completed runner Jobs not counted yet", func() {
+		recorded := finishedAfter("recorded", time.Minute)
+		recorded.Annotations = map[string]string{utilizationRecordedAnnotation: "true"}
+		running := finishedAfter("running", time.Minute)
+		running.Status.CompletionTime = nil
+
+		finished := unrecordedFinishedRunners([]batchv1.Job{finishedAfter("finished", time.Minute), recorded, running})
+		Expect(finished).To(HaveLen(1))
+		Expect(finished[0].Name).To(Equal("finished"))
+	})
+
+	It("should tell runners that ran a job from those that exited without one", func() {
+		seen := map[string]bool{"busy": true, "idle": false}
+		failedAfter := func(name string) batchv1.Job {
+			job := finishedAfter(name, time.Minute)
+			job.Status.CompletionTime = nil
+			return job
+		}
+		busy, idle, unlisted := finishedAfter("busy", time.Minute), failedAfter("idle"), finishedAfter("unlisted", time.Minute)
+		failedBusy, failedUnlisted := failedAfter("busy"), failedAfter("unlisted")
+
+		Expect(finishedRunnerOutcome(&busy, seen)).To(Equal(runnerOutcomeRanJob))
+		Expect(finishedRunnerOutcome(&failedBusy, seen)).To(Equal(runnerOutcomeRanJob))
+		Expect(finishedRunnerOutcome(&idle, seen)).To(Equal(runnerOutcomeIdle))
+		Expect(finishedRunnerOutcome(&unlisted, seen)).To(Equal(runnerOutcomeRanJob))
+		Expect(finishedRunnerOutcome(&failedUnlisted, seen)).To(Equal(runnerOutcomeUnknown))
+	})
+
+	It("should count runners Gitea only showed idle as idle", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{ObjectMeta: metav1.ObjectMeta{Name: "utilization", Namespace: "default"}}
+		reconciler := &RunnerGroupReconciler{}
+		reconciler.seenRunners.Store(client.ObjectKeyFromObject(runnerGroup), map[string]bool{"busy": true, "idle": false})
+
+		finished := reconciler.recordFinishedRunners(runnerGroup, []batchv1.Job{
+			finishedAfter("busy", 2*time.Minute), finishedAfter("idle", 3*time.Minute), finishedAfter("unlisted", 4*time.Minute),
+		}, now)
+		Expect(finished).To(HaveLen(3))
+		Expect(runnerGroup.Status.Utilization.RunnersWithJob).To(Equal(int64(2)))
+		Expect(runnerGroup.Status.Utilization.IdleRunners).To(Equal(int64(1)))
+		Expect(runnerGroup.Status.Utilization.AverageJobDuration.Duration).To(Equal(3 * time.Minute))
+		Expect(runnerGroup.Status.Usage.RunnerSeconds).To(Equal(int64(9 * 60)))
+	})
+
+	It("should keep a running average of job durations", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+		recordRunnersWithJob(runnerGroup, []time.Duration{2 * time.Minute, 4 * time.Minute})
+		Expect(runnerGroup.Status.Utilization.AverageJobDuration.Duration).To(Equal(3 * time.Minute))
+
+		recordRunnersWithJob(runnerGroup, []time.Duration{6 * time.Minute})
+		Expect(runnerGroup.Status.Utilization.RunnersWithJob).To(Equal(int64(3)))
+		Expect(runnerGroup.Status.Utilization.AverageJobDuration.Duration).To(Equal(4 * time.Minute))
+	})
+
+	It("should report the share of runners that ran a job", func() {
+		runnerGroup := &giteav1alpha1.RunnerGroup{}
+		recordIdleRunners(runnerGroup, 0)
+		Expect(runnerGroup.Status.Utilization).To(BeNil())
+
+		recordRunnersWithJob(runnerGroup, []time.Duration{time.Minute, time.Minute, time.Minute})
+		recordIdleRunners(runnerGroup, 1)
+		Expect(runnerGroup.Status.Utilization.IdleRunners).To(Equal(int64(1)))
+		Expect(runnerGroup.Status.Utilization.UtilizationPercent).To(Equal(75))
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// stuckPollIntervals is how many poll intervals a RunnerGroup's LastCheckTime may fall behind
+// before the watchdog considers the group stuck
+const stuckPollIntervals = 3
+
+// pollWatchdog notices RunnerGroups whose polls have stopped, e.g. because their reconcile
+// was lost from the workqueue or hangs, logs what is known about them and requeues them
+type pollWatchdog struct {
+	reconciler *RunnerGroupReconciler
+}
+
+// Start implements manager.Runnable
+func (w *pollWatchdog) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-time.After(w.reconciler.currentPollInterval()):
+			w.check(ctx, now)
+		}
+	}
+}
+
+// check requeues every stuck RunnerGroup and publishes which groups are stuck
+func (w *pollWatchdog) check(ctx context.Context, now time.Time) {
+	logger := log.FromContext(ctx).WithName("poll-watchdog")
+	r := w.reconciler
+
+	if r.adoptionPending.Load() {
+		return
+	}
+
+	runnerGroupList := &giteav1alpha1.RunnerGroupList{}
+	if err := r.List(ctx, runnerGroupList); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		return
+	}
+	giteaInstanceList := &giteav1alpha1.GiteaInstanceList{}
+	if err := r.List(ctx, giteaInstanceList); err != nil {
+		logger.Error(err, "Failed to list GiteaInstances")
+		return
+	}
+
+	for i := range runnerGroupList.Items {
+		runnerGroup := &runnerGroupList.Items[i]
+		key := client.ObjectKeyFromObject(runnerGroup)
+		interval := r.groupPollInterval(giteaInstanceForURL(giteaInstanceList.Items, runnerGroup.Spec.GiteaURL))
+		runnerGroup.Status.LastCheckTime = r.lastCheckTime(runnerGroup)
+		stale, stuck := pollStuck(runnerGroup, interval, r.pollBackoffUntil(key), now)
+		recordPollStuck(key, stuck)
+		if !stuck {
+			continue
+		}
+
+		polledGeneration, _ := r.polledGenerations.Load(key)
+		logger.Info("RunnerGroup has not polled Gitea for several intervals, requeueing",
+			"runnerGroup", key,
+			"lastCheckTime", runnerGroup.Status.LastCheckTime,
+			"staleFor", stale.Round(time.Second),
+			"generation", runnerGroup.Generation,
+			"observedGeneration", runnerGroup.Status.ObservedGeneration,
+			"polledGeneration", polledGeneration,
+			"activeRunners", runnerGroup.Status.ActiveRunners,
+			"giteaUnreachableSince", runnerGroup.Status.GiteaUnreachableSince)
+		pollWatchdogRequeues.WithLabelValues(key.Namespace, key.Name).Inc()
+		if !r.triggerPoll(runnerGroup) {
+			logger.Info("Requeue dropped, controller busy", "runnerGroup", key)
+		}
+	}
+}
+
+// pollStuck returns how long ago the RunnerGroup last polled Gitea, and whether that is longer
+// than it should be. Groups held back on purpose, by a maintenance window, an emergency stop, a
+// pause, a drain or the failure budget, groups whose scope Gitea rejected and groups backing off from failed polls until backoffUntil
+// aren't stuck.
+func pollStuck(runnerGroup *giteav1alpha1.RunnerGroup, interval time.Duration, backoffUntil, now time.Time) (time.Duration, bool) {
+	if now.Before(backoffUntil) ||
+		meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionMaintenance) ||
+		meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionEmergencyStop) ||
+		meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionPaused) ||
+		meta.IsStatusConditionTrue(runnerGroup.Status.Conditions, giteav1alpha1.ConditionDraining) ||
+		backingOffFromFailures(runnerGroup) ||
+		meta.IsStatusConditionFalse(runnerGroup.Status.Conditions, giteav1alpha1.ConditionScopeVerified) {
+		return 0, false
+	}
+	lastCheck := runnerGroup.CreationTimestamp.Time
+	if runnerGroup.Status.LastCheckTime != nil {
+		lastCheck = runnerGroup.Status.LastCheckTime.Time
+	}
+	stale := now.Sub(lastCheck)
+	return stale, stale > stuckPollIntervals*interval
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+var _ = Describe("Poll watchdog", func() {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	runnerGroupCheckedAt := func(lastCheck time.Time) *giteav1alpha1.RunnerGroup {
+		return &giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Status:     giteav1alpha1.RunnerGroupStatus{LastCheckTime: &metav1.Time{Time: lastCheck}},
+		}
+	}
+
+	It("should flag groups whose last poll is several intervals old", func() {
+		_, stuck := pollStuck(runnerGroupCheckedAt(now.Add(-pollInterval)), pollInterval, time.Time{}, now)
+		Expect(stuck).To(BeFalse())
+
+		stale, stuck := pollStuck(runnerGroupCheckedAt(now.Add(-10*time.Minute)), pollInterval, time.Time{}, now)
+		Expect(stuck).To(BeTrue())
+		Expect(stale).To(Equal(10 * time.Minute))
+	})
+
+	It("should fall back to the creation time for groups that never polled", func() {
+		runnerGroup := runnerGroupCheckedAt(now)
+		runnerGroup.Status.LastCheckTime = nil
+		_, stuck := pollStuck(runnerGroup, pollInterval, time.Time{}, now)
+		Expect(stuck).To(BeTrue())
+	})
+
+	It("should not flag groups backing off from failed polls", func() {
+		runnerGroup := runnerGroupCheckedAt(now.Add(-4 * time.Minute))
+		_, stuck := pollStuck(runnerGroup, pollInterval, now.Add(time.Minute), now)
+		Expect(stuck).To(BeFalse())
+
+		_, stuck = pollStuck(runnerGroup, pollInterval, now.Add(-time.Minute), now)
+		Expect(stuck).To(BeTrue())
+	})
+
+	It("should not flag groups backing off after their runners kept failing", func() {
+		runnerGroup := runnerGroupCheckedAt(now.Add(-time.Hour))
+		runnerGroup.Status.Conditions = []metav1.Condition{{
+			Type: giteav1alpha1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: degradedRunnerFailures,
+		}}
+		_, stuck := pollStuck(runnerGroup, pollInterval, time.Time{}, now)
+		Expect(stuck).To(BeFalse())
+
+		runnerGroup.Status.Conditions[0].Reason = "CircuitOpen"
+		_, stuck = pollStuck(runnerGroup, pollInterval, time.Time{}, now)
+		Expect(stuck).To(BeTrue())
+	})
+
+	It("should not flag groups held back on purpose", func() {
+		for _, conditionType := range []string{giteav1alpha1.ConditionMaintenance, giteav1alpha1.ConditionEmergencyStop,
+			giteav1alpha1.ConditionPaused, giteav1alpha1.ConditionDraining} {
+			runnerGroup := runnerGroupCheckedAt(now.Add(-time.Hour))
+			runnerGroup.Status.Conditions = []metav1.Condition{{Type: conditionType, Status: metav1.ConditionTrue}}
+			_, stuck := pollStuck(runnerGroup, pollInterval, time.Time{}, now)
+			Expect(stuck).To(BeFalse(), conditionType)
+		}
+	})
+})
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Defaults of controller-runtime's rate limiter, used for the WorkqueueOptions left unset
+const (
+	defaultRetryBaseDelay = 5 * time.Millisecond
+	defaultRetryMaxDelay  = 1000 * time.Second
+	defaultRetryQPS       = 10
+	defaultRetryBurst     = 100
+)
+
+// WorkqueueOptions tune how many objects a controller reconciles in parallel and how fast it
+// retries failed reconciles. Zero values keep controller-runtime's defaults.
+type WorkqueueOptions struct {
+	// MaxConcurrentReconciles is how many objects are reconciled in parallel. RunnerGroups still
+	// spawn runners one group at a time, so they share the operator-wide cap correctly.
+	MaxConcurrentReconciles int
+
+	// RetryBaseDelay is the first retry delay of a failing object, doubled on every further failure
+	// up to RetryMaxDelay
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// RetryQPS and RetryBurst limit the retries of all objects together
+	RetryQPS   float64
+	RetryBurst int
+}
+
+// controllerOptions returns the controller options for the builder's WithOptions
+func (o WorkqueueOptions) controllerOptions() controller.Options {
+	options := controller.Options{MaxConcurrentReconciles: o.MaxConcurrentReconciles}
+	if o.RetryBaseDelay == 0 && o.RetryMaxDelay == 0 && o.RetryQPS == 0 && o.RetryBurst == 0 {
+		return options
+	}
+
+	baseDelay, maxDelay := o.RetryBaseDelay, o.RetryMaxDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	qps, burst := o.RetryQPS, o.RetryBurst
+	if qps <= 0 {
+		qps = defaultRetryQPS
+	}
+	if burst <= 0 {
+		burst = defaultRetryBurst
+	}
+	options.RateLimiter = workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](baseDelay, maxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+	return options
+}
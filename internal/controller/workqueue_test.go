@@ -0,0 +1,57 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("WorkqueueOptions", func() {
+	It("should keep controller-runtime's defaults when unset", func() {
+		options := WorkqueueOptions{}.controllerOptions()
+		Expect(options.MaxConcurrentReconciles).To(BeZero())
+		Expect(options.RateLimiter).To(BeNil())
+	})
+
+	It("should back off failed reconciles from the configured base delay", func() {
+		options := WorkqueueOptions{
+			MaxConcurrentReconciles: 8,
+			RetryBaseDelay:          time.Second,
+			RetryMaxDelay:           3 * time.Second,
+		}.controllerOptions()
+		Expect(options.MaxConcurrentReconciles).To(Equal(8))
+
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "busy"}}
+		Expect(options.RateLimiter.When(request)).To(Equal(time.Second))
+		Expect(options.RateLimiter.When(request)).To(Equal(2 * time.Second))
+		Expect(options.RateLimiter.When(request)).To(Equal(3 * time.Second))
+		options.RateLimiter.Forget(request)
+		Expect(options.RateLimiter.When(request)).To(Equal(time.Second))
+	})
+})
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package dashboard serves a small read-only web page showing the state of all RunnerGroups.
+package dashboard
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// Server serves the dashboard. It implements manager.Runnable so it runs alongside the controllers.
+type Server struct {
+	// Client reads RunnerGroups and the RunnerFleet
+	Client client.Reader
+	// BindAddress is the address the dashboard listens on, e.g. ":8082"
+	BindAddress string
+	// Username and Password protect the dashboard with HTTP basic auth
+	Username string
+	Password string
+}
+
+// LoadCredentials reads "username:password" from a file
+func LoadCredentials(path string) (string, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read dashboard credentials: %w", err)
+	}
+	username, password, ok := strings.Cut(strings.TrimSpace(string(data)), ":")
+	if !ok || username == "" || password == "" {
+		return "", "", errors.New("dashboard credentials must have the form username:password")
+	}
+	return username, password, nil
+}
+
+// Start runs the HTTP server until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("dashboard")
+
+	srv := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("Serving dashboard", "address", s.BindAddress)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// NeedLeaderElection lets every replica serve the dashboard, not just the leader
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the dashboard's HTTP handler, including authentication
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.serveIndex)
+	return s.requireAuth(mux)
+}
+
+// requireAuth rejects requests without the configured basic auth credentials
+func (s *Server) requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(username), []byte(s.Username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(s.Password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="gitea-runner-operator"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// pageData is what the index template renders
+type pageData struct {
+	Now    time.Time
+	Fleet  *giteav1alpha1.RunnerFleet
+	Groups []giteav1alpha1.RunnerGroup
+}
+
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	logger := log.FromContext(r.Context()).WithName("dashboard")
+
+	var runnerGroups giteav1alpha1.RunnerGroupList
+	if err := s.Client.List(r.Context(), &runnerGroups); err != nil {
+		logger.Error(err, "Failed to list RunnerGroups")
+		http.Error(w, "failed to list RunnerGroups", http.StatusInternalServerError)
+		return
+	}
+	slices.SortFunc(runnerGroups.Items, func(a, b giteav1alpha1.RunnerGroup) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+
+	data := pageData{Now: time.Now(), Groups: runnerGroups.Items}
+	fleet := &giteav1alpha1.RunnerFleet{}
+	if err := s.Client.Get(r.Context(), types.NamespacedName{Name: giteav1alpha1.RunnerFleetName}, fleet); err == nil {
+		data.Fleet = fleet
+	} else if client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to get RunnerFleet")
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, data); err != nil {
+		logger.Error(err, "Failed to render dashboard")
+	}
+}
+
+var indexTemplate = template.Must(template.New("index").Funcs(template.FuncMap{
+	"ago": func(now time.Time, t time.Time) string {
+		return now.Sub(t).Truncate(time.Second).String() + " ago"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>Gitea Runner Operator</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f4f4f4; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>Gitea Runner Operator</h1>
+{{- with .Fleet}}
+<p>{{.Status.TotalGroups}} groups, {{.Status.ActiveRunners}}/{{.Status.MaxActiveRunners}} runners active,
+{{.Status.QueuedJobs}} jobs queued{{if .Status.UnreachableGroups}}, <span class="error">{{.Status.UnreachableGroups}} groups can't reach Gitea</span>{{end}}</p>
+{{- if .Status.TopRepos}}
+<h2>Busiest repositories</h2>
+<table>
+<tr><th>Repository</th><th>Queued jobs</th></tr>
+{{- range .Status.TopRepos}}
+<tr><td>{{.Repo}}</td><td>{{.QueuedJobs}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+<h2>Runner groups</h2>
+<table>
+<tr><th>Namespace</th><th>Name</th><th>Scope</th><th>Runners</th><th>Queued</th><th>Last check</th><th>Last scale-up</th><th>Errors</th></tr>
+{{- $now := .Now}}
+{{- range .Groups}}
+<tr>
+<td>{{.Namespace}}</td>
+<td>{{.Name}}</td>
+<td>{{.Spec.Scope}}</td>
+<td>{{.Status.ActiveRunners}}/{{.Spec.MaxActiveRunners}}{{if .Status.BurstRunners}} (+{{.Status.BurstRunners}} burst){{end}}</td>
+<td>{{.Status.LastKnownQueuedJobs}}</td>
+<td>{{with .Status.LastCheckTime}}{{ago $now .Time}}{{else}}never{{end}}</td>
+<td>{{with .Status.LastScaleDecision}}{{len .SpawnedJobIDs}} runners for {{.QueuedJobs}} queued jobs, {{ago $now .Time.Time}}{{else}}-{{end}}</td>
+<td>{{with .Status.GiteaUnreachableSince}}<span class="error">Gitea unreachable since {{ago $now .Time}}</span>{{end}}</td>
+</tr>
+{{- else}}
+<tr><td colspan="8">No RunnerGroups</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Compatibility levels of a Gitea instance with the operator
+const (
+	// CompatibilityFull means the instance supports everything the operator uses
+	CompatibilityFull = "Full"
+	// CompatibilityLimited means the instance lacks features the operator works around
+	CompatibilityLimited = "Limited"
+	// CompatibilityUnknown means the instance's version couldn't be read; all features are
+	// assumed and missing ones are detected as they fail
+	CompatibilityUnknown = "Unknown"
+)
+
+var (
+	// jobsEndpointsVersion is the first Gitea version with the Actions jobs endpoints
+	jobsEndpointsVersion = version.MustParseGeneric("1.25.0")
+	// ephemeralRunnersVersion is the first Gitea version that removes ephemeral runners after
+	// their job
+	ephemeralRunnersVersion = version.MustParseGeneric("1.23.0")
+)
+
+// Capabilities are the features of a Gitea instance the operator adapts to
+type Capabilities struct {
+	// Version is the version the capabilities were derived from
+	Version string
+	// Known is whether Version could be parsed; unknown versions are assumed to support everything
+	Known bool
+	// JobsEndpoints is whether queued jobs can be listed through the Actions jobs endpoints,
+	// rather than the workflow runs of each repository
+	JobsEndpoints bool
+	// EphemeralRunners is whether Gitea removes runners registered as ephemeral after their job
+	EphemeralRunners bool
+}
+
+// CapabilitiesFor derives the capabilities of a Gitea instance from the version it reports.
+// Forgejo versions are read from the Gitea version they declare compatibility with, e.g.
+// "11.0.1+gitea-1.22.0".
+func CapabilitiesFor(v string) Capabilities {
+	caps := Capabilities{Version: v, JobsEndpoints: true, EphemeralRunners: true}
+	if _, compat, found := strings.Cut(v, "+gitea-"); found {
+		v = compat
+	}
+	parsed, err := version.ParseGeneric(v)
+	if err != nil {
+		return caps
+	}
+	caps.Known = true
+	caps.JobsEndpoints = parsed.AtLeast(jobsEndpointsVersion)
+	caps.EphemeralRunners = parsed.AtLeast(ephemeralRunnersVersion)
+	return caps
+}
+
+// Compatibility returns the compatibility level of the instance
+func (c Capabilities) Compatibility() string {
+	switch {
+	case !c.Known:
+		return CompatibilityUnknown
+	case len(c.Missing()) > 0:
+		return CompatibilityLimited
+	default:
+		return CompatibilityFull
+	}
+}
+
+// Missing describes the features the instance lacks
+func (c Capabilities) Missing() []string {
+	var missing []string
+	if !c.JobsEndpoints {
+		missing = append(missing, "Actions jobs endpoints")
+	}
+	if !c.EphemeralRunners {
+		missing = append(missing, "ephemeral runners")
+	}
+	return missing
+}
+
+// rememberCapabilities records the capabilities of the Gitea instance at giteaURL
+func (c *HTTPClient) rememberCapabilities(giteaURL string, caps Capabilities) {
+	c.capabilities.Store(strings.TrimSuffix(giteaURL, "/"), caps)
+}
+
+// knownCapabilities returns the capabilities of the Gitea instance at giteaURL recorded when
+// its version was last fetched
+func (c *HTTPClient) knownCapabilities(giteaURL string) (Capabilities, bool) {
+	caps, ok := c.capabilities.Load(strings.TrimSuffix(giteaURL, "/"))
+	if !ok {
+		return Capabilities{}, false
+	}
+	return caps.(Capabilities), true
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+func TestCapabilitiesFor(t *testing.T) {
+	tests := []struct {
+		version       string
+		jobsEndpoints bool
+		ephemeral     bool
+		compatibility string
+	}{
+		{version: "1.25.0", jobsEndpoints: true, ephemeral: true, compatibility: CompatibilityFull},
+		{version: "1.26.0+dev-12-gabcdef", jobsEndpoints: true, ephemeral: true, compatibility: CompatibilityFull},
+		{version: "1.24.2", ephemeral: true, compatibility: CompatibilityLimited},
+		{version: "1.22.3", compatibility: CompatibilityLimited},
+		{version: "11.0.1+gitea-1.22.0", compatibility: CompatibilityLimited},
+		{version: "development", jobsEndpoints: true, ephemeral: true, compatibility: CompatibilityUnknown},
+		{version: "", jobsEndpoints: true, ephemeral: true, compatibility: CompatibilityUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			caps := CapabilitiesFor(tt.version)
+			if caps.JobsEndpoints != tt.jobsEndpoints || caps.EphemeralRunners != tt.ephemeral {
+				t.Errorf("CapabilitiesFor(%q) = %+v", tt.version, caps)
+			}
+			if got := caps.Compatibility(); got != tt.compatibility {
+				t.Errorf("Compatibility() = %s, want %s", got, tt.compatibility)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_LegacyActionsFromVersion(t *testing.T) {
+	var jobsRequests atomic.Int32
+	legacy := legacyGitea(&jobsRequests)
+	defer legacy.Close()
+	// Answer the version endpoint like Gitea 1.22 and everything else like legacyGitea
+	legacy.Config.Handler = versionHandler("1.22.3", legacy.Config.Handler)
+
+	client := NewHTTPClient()
+	if _, err := client.GetVersion(context.Background(), legacy.URL, "test-token"); err != nil {
+		t.Fatalf("GetVersion() error = %v", err)
+	}
+	stats, err := client.GetRunnerStats(context.Background(), legacy.URL, "test-token", v1alpha1.RunnerGroupScopeOrg, "myorg", "", "", nil)
+	if err != nil {
+		t.Fatalf("GetRunnerStats() error = %v", err)
+	}
+	if len(stats.QueuedJobs) != 1 {
+		t.Errorf("QueuedJobs = %d, want 1", len(stats.QueuedJobs))
+	}
+	if jobsRequests.Load() != 0 {
+		t.Errorf("jobs endpoint requests = %d, want none for a version without them", jobsRequests.Load())
+	}
+}
+
+// versionHandler answers the version endpoint with version and passes other requests to next
+func versionHandler(version string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/api/v1/version") {
+			_, _ = w.Write([]byte(`{"version": "` + version + `"}`))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
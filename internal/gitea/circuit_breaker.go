@@ -0,0 +1,185 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// circuitFailureThreshold is how many requests to a host must fail in a row to open its circuit
+	circuitFailureThreshold = 5
+	// circuitCooldown is how long an open circuit rejects requests before letting one through
+	// to check whether the host recovered
+	circuitCooldown = 30 * time.Second
+)
+
+// circuitOpen is 1 for Gitea hosts, per proxy they are reached through, whose circuit is open
+var circuitOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "gitea_client_circuit_open",
+	Help: "Whether requests to a Gitea host through a proxy are short-circuited after repeated failures (1) or not (0).",
+}, []string{"host", "proxy"})
+
+func init() {
+	metrics.Registry.MustRegister(circuitOpen)
+}
+
+// CircuitOpenError is returned for requests to a host whose circuit is open
+type CircuitOpenError struct {
+	Host string
+	// Proxy is the host of the proxy the circuit is for, or empty for direct requests
+	Proxy string
+	// RetryAfter is how long the circuit stays open
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *CircuitOpenError) Error() string {
+	host := e.Host
+	if e.Proxy != "" {
+		host += " through proxy " + e.Proxy
+	}
+	return fmt.Sprintf("%s for %s after %d consecutive failures: retry after %s",
+		ErrCircuitOpen, host, circuitFailureThreshold, e.RetryAfter)
+}
+
+// Is makes CircuitOpenErrors match ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// circuitBreaker tracks consecutive failures per Gitea host and proxy. After
+// circuitFailureThreshold of them, requests to the host fail right away for circuitCooldown
+// instead of each waiting for a timeout, which would tie up the reconcile workers of all the
+// host's groups. Then a single request is let through; its success closes the circuit, its
+// failure opens it again. A broken proxy of one group only opens the circuit of the groups
+// behind that proxy.
+type circuitBreaker struct {
+	mu    sync.Mutex
+	hosts map[circuitKey]*hostCircuit
+}
+
+// circuitKey identifies a circuit: the Gitea host and the host of the proxy requests to it go
+// through, if a RunnerGroup set one
+type circuitKey struct {
+	host  string
+	proxy string
+}
+
+// circuitKeyFor returns the circuit a request belongs to
+func circuitKeyFor(req *http.Request) circuitKey {
+	key := circuitKey{host: req.URL.Host}
+	if proxyURL := ProxyFromContext(req.Context()); proxyURL != "" {
+		// The proxy URL may carry credentials, which don't belong in a metric label
+		if parsed, err := url.Parse(proxyURL); err == nil {
+			key.proxy = parsed.Host
+		} else {
+			key.proxy = "invalid"
+		}
+	}
+	return key
+}
+
+// hostCircuit is the circuit state of one host
+type hostCircuit struct {
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow returns whether a request to host may be sent, and if not, how long the circuit stays open
+func (b *circuitBreaker) allow(host circuitKey, now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	circuit := b.hosts[host]
+	if circuit == nil || circuit.failures < circuitFailureThreshold {
+		return 0, true
+	}
+	if now.Before(circuit.openUntil) {
+		return circuit.openUntil.Sub(now), false
+	}
+	if circuit.probing {
+		return circuitCooldown, false
+	}
+	circuit.probing = true
+	return 0, true
+}
+
+// record records the outcome of a request to host
+func (b *circuitBreaker) record(host circuitKey, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		if b.hosts[host] != nil {
+			delete(b.hosts, host)
+			circuitOpen.WithLabelValues(host.host, host.proxy).Set(0)
+		}
+		return
+	}
+	if b.hosts == nil {
+		b.hosts = make(map[circuitKey]*hostCircuit)
+	}
+	circuit := b.hosts[host]
+	if circuit == nil {
+		circuit = &hostCircuit{}
+		b.hosts[host] = circuit
+	}
+	circuit.failures++
+	circuit.probing = false
+	if circuit.failures >= circuitFailureThreshold {
+		circuit.openUntil = now.Add(circuitCooldown)
+		circuitOpen.WithLabelValues(host.host, host.proxy).Set(1)
+	}
+}
+
+// release ends a request to host that was cancelled before it had an outcome
+func (b *circuitBreaker) release(host circuitKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if circuit := b.hosts[host]; circuit != nil {
+		circuit.probing = false
+	}
+}
+
+// do sends a request unless the circuit of its host and proxy is open, and records its outcome.
+// Retries of a request count as one outcome.
+func (c *HTTPClient) do(req *http.Request) (*http.Response, error) {
+	host := circuitKeyFor(req)
+	if wait, ok := c.circuits.allow(host, time.Now()); !ok {
+		return nil, &CircuitOpenError{Host: host.host, Proxy: host.proxy, RetryAfter: wait}
+	}
+	resp, err := c.doWithRetries(req)
+	if req.Context().Err() != nil {
+		c.circuits.release(host)
+	} else {
+		c.circuits.record(host, transientFailure(resp, err), time.Now())
+	}
+	return resp, err
+}
@@ -25,19 +25,34 @@ package gitea
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
 	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
 )
 
+// Log verbosity of the client. Response bodies may contain repository names, commit messages
+// and other data users don't expect in logs, so they are only logged at bodyLogLevel.
+const (
+	requestLogLevel = 1
+	bodyLogLevel    = 2
+)
+
 // Client defines the interface for interacting with Gitea API
 type Client interface {
-	// GetRunnerStats queries Gitea for queued workflow runs matching the scope and labels
+	// GetRunnerStats queries Gitea for queued workflow runs matching the scope and labels.
+	// Nil labels return all queued jobs in scope.
 	GetRunnerStats(
 		ctx context.Context,
 		giteaURL string,
@@ -48,25 +63,187 @@ type Client interface {
 		repo string,
 		labels []string,
 	) (*RunnerStats, error)
+
+	// GetWorkflowRun fetches a workflow run of a repository ("owner/name"). Runs are cached for a
+	// few minutes, so the status of the run returned may be out of date.
+	GetWorkflowRun(ctx context.Context, giteaURL, authToken, repo string, runID int64) (*ActionWorkflowRun, error)
+
+	// GetVersion returns the version of the Gitea instance. It is a cheap request, suited to
+	// checking that the instance is reachable. The features the version lacks are worked around
+	// by later requests to the instance.
+	GetVersion(ctx context.Context, giteaURL, authToken string) (string, error)
+
+	// DeleteRunner removes the runner with the given Gitea ID from the scope. A runner that is
+	// not registered (anymore) is not an error.
+	DeleteRunner(
+		ctx context.Context,
+		giteaURL string,
+		authToken string,
+		scope v1alpha1.RunnerGroupScope,
+		org string,
+		user string,
+		repo string,
+		id int64,
+	) error
+
+	// DeregisterRunner removes the runner with the given name from the scope. A runner that
+	// is not registered (anymore) is not an error.
+	DeregisterRunner(
+		ctx context.Context,
+		giteaURL string,
+		authToken string,
+		scope v1alpha1.RunnerGroupScope,
+		org string,
+		user string,
+		repo string,
+		name string,
+	) error
+
+	// GetRunner returns the runner with the given name registered in the scope, or nil if
+	// no such runner is registered
+	GetRunner(
+		ctx context.Context,
+		giteaURL string,
+		authToken string,
+		scope v1alpha1.RunnerGroupScope,
+		org string,
+		user string,
+		repo string,
+		name string,
+	) (*ActionRunner, error)
+
+	// ListRunners returns the runners registered in the scope
+	ListRunners(
+		ctx context.Context,
+		giteaURL string,
+		authToken string,
+		scope v1alpha1.RunnerGroupScope,
+		org string,
+		user string,
+		repo string,
+	) ([]ActionRunner, error)
+
+	// GetRegistrationToken returns a token runners can register in the scope with. It requires a
+	// token allowed to manage the scope's runners.
+	GetRegistrationToken(
+		ctx context.Context,
+		giteaURL string,
+		authToken string,
+		scope v1alpha1.RunnerGroupScope,
+		org string,
+		user string,
+		repo string,
+	) (string, error)
+
+	// RegisterRunner registers a runner with a registration token, the way act_runner does, and
+	// returns the credentials the runner connects to Gitea with. Ephemeral runners are removed by
+	// Gitea after running one job; Gitea versions without ephemeral runners ignore the flag.
+	RegisterRunner(ctx context.Context, giteaURL, registrationToken, name string, labels []string, ephemeral bool) (*RegisteredRunner, error)
+
+	// RefreshOAuth2Token exchanges an OAuth2 refresh token for a new access token with the
+	// credentials of a Gitea OAuth2 application
+	RefreshOAuth2Token(ctx context.Context, giteaURL, clientID, clientSecret, refreshToken string) (*OAuth2Token, error)
+
+	// VerifyScope checks that the scope's org, user or repo exists and that the token can read
+	// its Actions jobs
+	VerifyScope(
+		ctx context.Context,
+		giteaURL string,
+		authToken string,
+		scope v1alpha1.RunnerGroupScope,
+		org string,
+		user string,
+		repo string,
+	) error
 }
 
+var (
+	// ErrUnauthorized is returned when Gitea rejects the token
+	ErrUnauthorized = errors.New("authentication failed")
+	// ErrForbidden is returned when the token lacks permissions for a request
+	ErrForbidden = errors.New("access denied")
+	// ErrNotFound is returned when the requested resource doesn't exist
+	ErrNotFound = errors.New("resource not found")
+	// ErrRateLimited is returned when Gitea, or a proxy in front of it, throttles requests.
+	// Errors matching it are RateLimitErrors.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrCircuitOpen is returned without contacting Gitea while its host keeps failing. Errors
+	// matching it are CircuitOpenErrors.
+	ErrCircuitOpen = errors.New("circuit breaker open")
+)
+
 // RunnerStats contains lists of jobs in different states
 type RunnerStats struct {
 	QueuedJobs []ActionWorkflowJob
 }
 
+// defaultTimeout limits Gitea requests unless configured otherwise
+const defaultTimeout = 30 * time.Second
+
+// defaultRepoConcurrency is how many repositories a user-scope poll fetches jobs of at once
+// unless configured otherwise
+const defaultRepoConcurrency = 8
+
 // HTTPClient is the default implementation of the Gitea Client interface
 type HTTPClient struct {
-	httpClient *http.Client
+	httpClient atomic.Pointer[http.Client]
+	dump       atomic.Bool
+	maxRetries atomic.Int32
+	circuits   circuitBreaker
+	limiters   hostLimiters
+	jobPages   jobPageCache
+	userScans  userScans
+	runs       workflowRunCache
+	// legacyHosts holds the Gitea instances without Actions jobs endpoints
+	legacyHosts sync.Map
+	// capabilities holds the Capabilities of each Gitea instance whose version was fetched
+	capabilities sync.Map
+	// repoConcurrency bounds the repositories a user-scope poll fetches at once; 0 uses the default
+	repoConcurrency atomic.Int32
 }
 
 // NewHTTPClient creates a new Gitea HTTP client
 func NewHTTPClient() *HTTPClient {
-	return &HTTPClient{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	c := &HTTPClient{}
+	c.Configure(0, 0, 0)
+	return c
+}
+
+// Configure replaces the underlying HTTP client; requests in flight finish on the previous one.
+// Zero values keep the defaults; a negative maxRetries disables retries.
+func (c *HTTPClient) Configure(timeout time.Duration, maxIdleConnsPerHost, maxRetries int) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	switch {
+	case maxRetries == 0:
+		c.maxRetries.Store(defaultMaxRetries)
+	case maxRetries < 0:
+		c.maxRetries.Store(0)
+	default:
+		c.maxRetries.Store(int32(maxRetries))
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyForRequest
+	if maxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
 	}
+	c.httpClient.Store(&http.Client{
+		Timeout:   timeout,
+		Transport: instrumentedTransport{next: dumpTransport{next: newTLSTransport(transport), enabled: &c.dump}},
+	})
+}
+
+// SetDump turns logging of every request and response, with credentials redacted, on or off.
+// It is meant for troubleshooting API incompatibilities; dumps include full response bodies.
+func (c *HTTPClient) SetDump(enabled bool) {
+	c.dump.Store(enabled)
+}
+
+// SetRepoConcurrency sets how many repositories' jobs a user-scope poll fetches at once; zero
+// restores the default
+func (c *HTTPClient) SetRepoConcurrency(concurrency int) {
+	c.repoConcurrency.Store(int32(concurrency))
 }
 
 // Repository represents a Gitea repository
@@ -76,6 +253,20 @@ type Repository struct {
 	} `json:"owner"`
 	Name     string `json:"name"`
 	FullName string `json:"full_name"`
+	// HasActions is whether the repository's Actions unit is enabled; older Gitea versions omit it
+	HasActions *bool     `json:"has_actions"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// fullName returns "owner/name" of the repository
+func (r Repository) fullName() string {
+	return r.Owner.Login + "/" + r.Name
+}
+
+// ActionsEnabled reports whether the repository can have workflow jobs. Repositories of Gitea
+// versions that don't report it are assumed to.
+func (r Repository) ActionsEnabled() bool {
+	return r.HasActions == nil || *r.HasActions
 }
 
 // Organization represents a Gitea organization
@@ -84,6 +275,16 @@ type Organization struct {
 	Name     string `json:"name"`
 }
 
+// ServerVersion represents the response of the version endpoint
+type ServerVersion struct {
+	Version string `json:"version"`
+}
+
+// RegistrationToken represents the response of the runner registration token endpoints
+type RegistrationToken struct {
+	Token string `json:"token"`
+}
+
 // ActionWorkflowRunsResponse represents the response structure for workflow runs
 type ActionWorkflowRunsResponse struct {
 	TotalCount   int64               `json:"total_count"`
@@ -101,6 +302,42 @@ type ActionWorkflowRun struct {
 	RunNumber    int64  `json:"run_number"`
 }
 
+// ActionRunnersResponse represents the response structure for runners
+type ActionRunnersResponse struct {
+	TotalCount int64          `json:"total_count"`
+	Runners    []ActionRunner `json:"runners"`
+}
+
+// ActionRunner represents a registered Gitea Actions runner
+type ActionRunner struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Busy   bool   `json:"busy"`
+	// Labels are the labels the runner registered with
+	Labels []ActionRunnerLabel `json:"labels,omitempty"`
+}
+
+// ActionRunnerLabel is a label of a registered runner
+type ActionRunnerLabel struct {
+	Name string `json:"name"`
+}
+
+// Online reports whether the runner is connected to Gitea
+func (r ActionRunner) Online() bool {
+	return r.Status != "offline"
+}
+
+// HasLabel reports whether the runner registered with the label
+func (r ActionRunner) HasLabel(name string) bool {
+	for _, label := range r.Labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // ActionWorkflowJobsResponse represents the response structure for workflow jobs
 type ActionWorkflowJobsResponse struct {
 	TotalCount int64               `json:"total_count"`
@@ -109,13 +346,42 @@ type ActionWorkflowJobsResponse struct {
 
 // ActionWorkflowJob represents a Gitea workflow job with runner labels
 type ActionWorkflowJob struct {
-	ID         int64    `json:"id"`
-	Status     string   `json:"status"`
-	Name       string   `json:"name"`
-	Labels     []string `json:"labels"`
-	RunID      int64    `json:"run_id"`
-	RunnerID   int64    `json:"runner_id"`
-	RunnerName string   `json:"runner_name"`
+	ID         int64     `json:"id"`
+	Status     string    `json:"status"`
+	Name       string    `json:"name"`
+	Labels     []string  `json:"labels"`
+	RunID      int64     `json:"run_id"`
+	RunnerID   int64     `json:"runner_id"`
+	RunnerName string    `json:"runner_name"`
+	URL        string    `json:"url"`
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RepoFullName returns the "owner/repo" the job belongs to, derived from its API or HTML URL.
+// It returns an empty string if neither URL identifies a repository.
+func (j ActionWorkflowJob) RepoFullName() string {
+	// API URL: {giteaURL}/api/v1/repos/{owner}/{repo}/actions/jobs/{id}
+	if u, err := url.Parse(j.URL); err == nil {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i := 0; i+2 < len(segments); i++ {
+			if segments[i] == "repos" {
+				return segments[i+1] + "/" + segments[i+2]
+			}
+		}
+	}
+
+	// HTML URL: {giteaURL}/{owner}/{repo}/actions/runs/{run}/jobs/{index}
+	if u, err := url.Parse(j.HTMLURL); err == nil {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i := 2; i < len(segments); i++ {
+			if segments[i] == "actions" {
+				return segments[i-2] + "/" + segments[i-1]
+			}
+		}
+	}
+
+	return ""
 }
 
 // GetRunnerStats implements the Client interface
@@ -148,32 +414,66 @@ func (c *HTTPClient) GetRunnerStats(
 
 // getRunnerStatsForRepo fetches queued runs for a specific repository
 func (c *HTTPClient) getRunnerStatsForRepo(ctx context.Context, giteaURL, authToken, owner, repo string, labels []string) (*RunnerStats, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/actions/jobs", strings.TrimSuffix(giteaURL, "/"), owner, repo)
-	return c.fetchRunnerStats(ctx, endpoint, authToken, labels)
+	jobs, err := c.fetchRepoQueuedJobs(ctx, giteaURL, authToken, owner, repo, labels)
+	if err != nil {
+		return nil, err
+	}
+	return &RunnerStats{QueuedJobs: jobs}, nil
 }
 
 // getRunnerStatsForOrg fetches queued runs for all repos under an organization
 func (c *HTTPClient) getRunnerStatsForOrg(ctx context.Context, giteaURL, authToken, org string, labels []string) (*RunnerStats, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/orgs/%s/actions/jobs", strings.TrimSuffix(giteaURL, "/"), org)
-	return c.fetchRunnerStats(ctx, endpoint, authToken, labels)
+	base := strings.TrimSuffix(giteaURL, "/")
+	if !c.legacyActions(giteaURL) {
+		stats, err := c.fetchRunnerStats(ctx, fmt.Sprintf("%s/api/v1/orgs/%s/actions/jobs", base, org), authToken, labels)
+		if !c.jobsEndpointMissing(ctx, giteaURL, authToken, fmt.Sprintf("%s/api/v1/orgs/%s", base, org), err) {
+			return stats, err
+		}
+	}
+	repos, err := c.listOrgRepos(ctx, giteaURL, authToken, org)
+	if err != nil {
+		return nil, err
+	}
+	return c.getRunnerStatsFromRepos(ctx, giteaURL, authToken, repos, labels)
 }
 
-// getRunnerStatsForUser fetches queued runs for all repos owned by a user
+// getRunnerStatsForUser fetches queued runs for all repos owned by a user. The repositories are
+// listed every userRepoListTTL; between full scans, only the jobs of repositories updated since
+// the list before or with queued jobs in the last userScanActivityWindow are fetched.
 func (c *HTTPClient) getRunnerStatsForUser(ctx context.Context, giteaURL, authToken, user string, labels []string) (*RunnerStats, error) {
-	repos, err := c.fetchReposForUser(ctx, giteaURL, authToken, user)
+	started := time.Now()
+	reposEndpoint := fmt.Sprintf("%s/api/v1/users/%s/repos", strings.TrimSuffix(giteaURL, "/"), user)
+	listKey := jobPageKey(ctx, reposEndpoint, authToken)
+	list, ok := c.userScans.repoList(listKey, started)
+	if !ok {
+		repos, err := c.listUserRepos(ctx, giteaURL, authToken, user)
+		if err != nil {
+			return nil, err
+		}
+		// Repositories with Actions disabled have no jobs; don't ask for them
+		repos = slices.DeleteFunc(repos, func(repo Repository) bool { return !repo.ActionsEnabled() })
+		list = c.userScans.storeRepoList(listKey, repos, started)
+	}
+	scanKey := jobPageKey(ctx, reposEndpoint+"?labels="+strings.Join(labels, ","), authToken)
+	repos, full := c.userScans.filter(scanKey, list, started)
+	total := len(list.repos)
+	log.FromContext(ctx).WithName("gitea").V(requestLogLevel).Info("Fetching jobs of user repositories",
+		"user", user, "repositories", len(repos), "total", total, "fullScan", full)
+
+	repoJobs, err := c.fetchReposQueuedJobs(ctx, giteaURL, authToken, repos, labels)
 	if err != nil {
 		return nil, err
 	}
 
 	var allQueuedJobs []ActionWorkflowJob
-	for _, repo := range repos {
-		endpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/actions/jobs", strings.TrimSuffix(giteaURL, "/"), repo.Owner.Login, repo.Name)
-		stats, err := c.fetchRunnerStats(ctx, endpoint, authToken, labels)
-		if err != nil {
-			return nil, err
+	var activeRepos []string
+	for i, jobs := range repoJobs {
+		if len(jobs) > 0 {
+			activeRepos = append(activeRepos, repos[i].fullName())
 		}
-		allQueuedJobs = append(allQueuedJobs, stats.QueuedJobs...)
+		allQueuedJobs = append(allQueuedJobs, jobs...)
 	}
+	c.userScans.record(scanKey, started, full, activeRepos)
 
 	return &RunnerStats{
 		QueuedJobs: allQueuedJobs,
@@ -182,8 +482,70 @@ func (c *HTTPClient) getRunnerStatsForUser(ctx context.Context, giteaURL, authTo
 
 // getRunnerStatsGlobal fetches queued runs using admin-level API for global scope
 func (c *HTTPClient) getRunnerStatsGlobal(ctx context.Context, giteaURL, authToken string, labels []string) (*RunnerStats, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/admin/actions/jobs", strings.TrimSuffix(giteaURL, "/"))
-	return c.fetchRunnerStats(ctx, endpoint, authToken, labels)
+	base := strings.TrimSuffix(giteaURL, "/")
+	if !c.legacyActions(giteaURL) {
+		stats, err := c.fetchRunnerStats(ctx, base+"/api/v1/admin/actions/jobs", authToken, labels)
+		if !c.jobsEndpointMissing(ctx, giteaURL, authToken, base+"/api/v1/admin/orgs", err) {
+			return stats, err
+		}
+	}
+	repos, err := c.searchRepos(ctx, giteaURL, authToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.getRunnerStatsFromRepos(ctx, giteaURL, authToken, repos, labels)
+}
+
+// getRunnerStatsFromRepos fetches the queued runs of the repositories
+func (c *HTTPClient) getRunnerStatsFromRepos(ctx context.Context, giteaURL, authToken string, repos []Repository, labels []string) (*RunnerStats, error) {
+	repos = slices.DeleteFunc(repos, func(repo Repository) bool { return !repo.ActionsEnabled() })
+	repoJobs, err := c.fetchReposQueuedJobs(ctx, giteaURL, authToken, repos, labels)
+	if err != nil {
+		return nil, err
+	}
+	return &RunnerStats{QueuedJobs: slices.Concat(repoJobs...)}, nil
+}
+
+// fetchReposQueuedJobs fetches the queued jobs of the repositories concurrently and returns
+// them in repository order
+func (c *HTTPClient) fetchReposQueuedJobs(ctx context.Context, giteaURL, authToken string, repos []Repository, labels []string) ([][]ActionWorkflowJob, error) {
+	concurrency := int(c.repoConcurrency.Load())
+	if concurrency <= 0 {
+		concurrency = defaultRepoConcurrency
+	}
+	repoJobs := make([][]ActionWorkflowJob, len(repos))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for i, repo := range repos {
+		g.Go(func() error {
+			jobs, err := c.fetchRepoQueuedJobs(gctx, giteaURL, authToken, repo.Owner.Login, repo.Name, labels)
+			if err != nil {
+				return err
+			}
+			repoJobs[i] = jobs
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return repoJobs, nil
+}
+
+// fetchRepoQueuedJobs fetches the queued jobs of a repository, or its queued runs if Gitea has
+// no jobs endpoints
+func (c *HTTPClient) fetchRepoQueuedJobs(ctx context.Context, giteaURL, authToken, owner, repo string, labels []string) ([]ActionWorkflowJob, error) {
+	repoEndpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s", strings.TrimSuffix(giteaURL, "/"), owner, repo)
+	if !c.legacyActions(giteaURL) {
+		stats, err := c.fetchRunnerStats(ctx, repoEndpoint+"/actions/jobs", authToken, labels)
+		if !c.jobsEndpointMissing(ctx, giteaURL, authToken, repoEndpoint, err) {
+			if err != nil {
+				return nil, err
+			}
+			return stats.QueuedJobs, nil
+		}
+	}
+	return c.fetchQueuedRuns(ctx, giteaURL, authToken, owner, repo)
 }
 
 func (c *HTTPClient) fetchRunnerStats(ctx context.Context, endpoint, authToken string, labels []string) (*RunnerStats, error) {
@@ -197,8 +559,10 @@ func (c *HTTPClient) fetchRunnerStats(ctx context.Context, endpoint, authToken s
 	}, nil
 }
 
-// fetchWorkflowJobs fetches workflow jobs from a given endpoint with label filtering and pagination
+// fetchWorkflowJobs fetches workflow jobs from a given endpoint with label filtering and pagination.
+// Pages Gitea returned with an ETag are requested conditionally and reused while unchanged.
 func (c *HTTPClient) fetchWorkflowJobs(ctx context.Context, endpoint, authToken string, labels []string, statuses []string) ([]ActionWorkflowJob, error) {
+	logger := log.FromContext(ctx).WithName("gitea")
 	var allJobs []ActionWorkflowJob
 
 	for _, status := range statuses {
@@ -216,46 +580,52 @@ func (c *HTTPClient) fetchWorkflowJobs(ctx context.Context, endpoint, authToken
 			q.Set("limit", fmt.Sprintf("%d", limit))
 			u.RawQuery = q.Encode()
 
-			fmt.Printf("DEBUG: Fetching jobs from %s\n", u.String())
+			logger.V(requestLogLevel).Info("Fetching jobs", "url", u.String())
 
 			req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 			if err != nil {
 				return nil, err
 			}
 
-			req.Header.Set("Authorization", "token "+authToken)
-			req.Header.Set("Accept", "application/json")
+			setRequestHeaders(ctx, req, authToken)
+			cacheKey := jobPageKey(ctx, u.String(), authToken)
+			cached := c.jobPages.get(cacheKey)
+			if cached != nil {
+				req.Header.Set("If-None-Match", cached.etag)
+			}
 
-			resp, err := c.httpClient.Do(req)
+			resp, err := c.do(req)
 			if err != nil {
-				fmt.Printf("DEBUG: Request failed: %v\n", err)
 				return nil, err
 			}
 
-			fmt.Printf("DEBUG: Response status: %s\n", resp.Status)
-
-			if resp.StatusCode != http.StatusOK {
-				body, _ := io.ReadAll(resp.Body)
-				_ = resp.Body.Close()
-				fmt.Printf("DEBUG: Error body: %s\n", string(body))
-				return nil, c.handleHTTPError(resp.StatusCode, body, "fetch workflow jobs")
-			}
-
 			body, _ := io.ReadAll(resp.Body)
 			_ = resp.Body.Close()
-			fmt.Printf("DEBUG: Response body: %s\n", string(body))
+			logger.V(requestLogLevel).Info("Gitea responded", "url", u.String(), "status", resp.StatusCode)
+			logger.V(bodyLogLevel).Info("Gitea response body", "url", u.String(), "body", string(body))
 
 			var result ActionWorkflowJobsResponse
-			if err := json.Unmarshal(body, &result); err != nil {
-				fmt.Printf("DEBUG: Failed to decode response: %v\n", err)
-				return nil, err
+			var matchedJobs []ActionWorkflowJob
+			switch {
+			case resp.StatusCode == http.StatusNotModified && cached != nil:
+				// Unchanged since the last poll; skip decoding and filtering the page again
+				jobPagesNotModified.WithLabelValues(u.Host).Inc()
+				result = cached.result
+				matchedJobs = cached.matchedJobs(c, labels)
+			case resp.StatusCode != http.StatusOK:
+				return nil, c.handleResponseError(resp, body, "fetch workflow jobs")
+			default:
+				if err := json.Unmarshal(body, &result); err != nil {
+					return nil, fmt.Errorf("failed to decode workflow jobs: %w", err)
+				}
+				// Filter and collect matching jobs for this page
+				matchedJobs = c.filterQueuedJobs(result.Jobs, labels)
+				if etag := resp.Header.Get("ETag"); etag != "" {
+					c.jobPages.put(cacheKey, &cachedJobPage{etag: etag, result: result, labels: slices.Clone(labels), matched: matchedJobs})
+				}
 			}
-
-			fmt.Printf("DEBUG: Found %d jobs, total in Gitea: %d\n", len(result.Jobs), result.TotalCount)
-
-			// Filter and collect matching jobs for this page
-			matchedJobs := c.filterQueuedJobs(result.Jobs, labels)
-			fmt.Printf("DEBUG: %d jobs matched labels %v\n", len(matchedJobs), labels)
+			logger.V(requestLogLevel).Info("Fetched jobs", "status", status, "page", page,
+				"jobs", len(result.Jobs), "totalCount", result.TotalCount, "matched", len(matchedJobs), "labels", labels)
 			allJobs = append(allJobs, matchedJobs...)
 
 			// Break if we've fetched all available results
@@ -270,77 +640,332 @@ func (c *HTTPClient) fetchWorkflowJobs(ctx context.Context, endpoint, authToken
 	return allJobs, nil
 }
 
-// fetchReposForUser fetches all repositories owned by a specific user with pagination
-func (c *HTTPClient) fetchReposForUser(ctx context.Context, giteaURL, authToken, username string) ([]Repository, error) {
-	var allRepos []Repository
+// GetWorkflowRun implements the Client interface
+func (c *HTTPClient) GetWorkflowRun(ctx context.Context, giteaURL, authToken, repo string, runID int64) (*ActionWorkflowRun, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/actions/runs/%d", strings.TrimSuffix(giteaURL, "/"), repo, runID)
+	key := jobPageKey(ctx, endpoint, authToken)
+	if run := c.runs.get(key); run != nil {
+		return run, nil
+	}
+
+	body, err := c.doRequest(ctx, "GET", endpoint, authToken, "fetch workflow run")
+	if err != nil {
+		return nil, err
+	}
+
+	var run ActionWorkflowRun
+	if err := json.Unmarshal(body, &run); err != nil {
+		return nil, err
+	}
+	c.runs.put(key, run)
+	return &run, nil
+}
+
+// GetVersion implements the Client interface
+func (c *HTTPClient) GetVersion(ctx context.Context, giteaURL, authToken string) (string, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/version", strings.TrimSuffix(giteaURL, "/"))
+
+	body, err := c.doRequest(ctx, "GET", endpoint, authToken, "fetch version")
+	if err != nil {
+		return "", err
+	}
+
+	var version ServerVersion
+	if err := json.Unmarshal(body, &version); err != nil {
+		return "", fmt.Errorf("failed to decode version: %w", err)
+	}
+	c.rememberCapabilities(giteaURL, CapabilitiesFor(version.Version))
+	return version.Version, nil
+}
+
+// DeregisterRunner implements the Client interface
+func (c *HTTPClient) DeregisterRunner(
+	ctx context.Context,
+	giteaURL string,
+	authToken string,
+	scope v1alpha1.RunnerGroupScope,
+	org string,
+	user string,
+	repo string,
+	name string,
+) error {
+	endpoint, err := runnersEndpoint(giteaURL, scope, org, user, repo)
+	if err != nil {
+		return err
+	}
+	runner, err := c.findRunner(ctx, endpoint, authToken, name)
+	if err != nil || runner == nil {
+		return err
+	}
+	return c.deleteRunner(ctx, endpoint, authToken, runner.ID)
+}
+
+// DeleteRunner implements the Client interface
+func (c *HTTPClient) DeleteRunner(
+	ctx context.Context,
+	giteaURL string,
+	authToken string,
+	scope v1alpha1.RunnerGroupScope,
+	org string,
+	user string,
+	repo string,
+	id int64,
+) error {
+	endpoint, err := runnersEndpoint(giteaURL, scope, org, user, repo)
+	if err != nil {
+		return err
+	}
+	return c.deleteRunner(ctx, endpoint, authToken, id)
+}
+
+// deleteRunner deletes the runner with the ID from the runners listed at endpoint. Gitea may have
+// removed it already, e.g. an ephemeral runner after its job.
+func (c *HTTPClient) deleteRunner(ctx context.Context, endpoint, authToken string, id int64) error {
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/%d", endpoint, id), authToken, "delete runner")
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// GetRunner implements the Client interface
+func (c *HTTPClient) GetRunner(
+	ctx context.Context,
+	giteaURL string,
+	authToken string,
+	scope v1alpha1.RunnerGroupScope,
+	org string,
+	user string,
+	repo string,
+	name string,
+) (*ActionRunner, error) {
+	endpoint, err := runnersEndpoint(giteaURL, scope, org, user, repo)
+	if err != nil {
+		return nil, err
+	}
+	return c.findRunner(ctx, endpoint, authToken, name)
+}
+
+// ListRunners implements the Client interface
+func (c *HTTPClient) ListRunners(
+	ctx context.Context,
+	giteaURL string,
+	authToken string,
+	scope v1alpha1.RunnerGroupScope,
+	org string,
+	user string,
+	repo string,
+) ([]ActionRunner, error) {
+	endpoint, err := runnersEndpoint(giteaURL, scope, org, user, repo)
+	if err != nil {
+		return nil, err
+	}
+	var runners []ActionRunner
+	err = c.forEachRunner(ctx, endpoint, authToken, func(runner *ActionRunner) bool {
+		runners = append(runners, *runner)
+		return true
+	})
+	return runners, err
+}
+
+// runnersEndpoint returns the API endpoint listing the runners registered in a scope
+func runnersEndpoint(giteaURL string, scope v1alpha1.RunnerGroupScope, org, user, repo string) (string, error) {
+	base := strings.TrimSuffix(giteaURL, "/") + "/api/v1"
+	switch scope {
+	case v1alpha1.RunnerGroupScopeRepo:
+		owner := org
+		if user != "" {
+			owner = user
+		}
+		return fmt.Sprintf("%s/repos/%s/%s/actions/runners", base, owner, repo), nil
+	case v1alpha1.RunnerGroupScopeOrg:
+		return fmt.Sprintf("%s/orgs/%s/actions/runners", base, org), nil
+	case v1alpha1.RunnerGroupScopeUser:
+		return base + "/user/actions/runners", nil
+	case v1alpha1.RunnerGroupScopeGlobal:
+		return base + "/admin/actions/runners", nil
+	default:
+		return "", fmt.Errorf("unknown scope: %s", scope)
+	}
+}
+
+// GetRegistrationToken implements the Client interface
+func (c *HTTPClient) GetRegistrationToken(
+	ctx context.Context,
+	giteaURL string,
+	authToken string,
+	scope v1alpha1.RunnerGroupScope,
+	org string,
+	user string,
+	repo string,
+) (string, error) {
+	endpoint, err := registrationTokenEndpoint(giteaURL, scope, org, user, repo)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.doRequest(ctx, "GET", endpoint, authToken, "fetch registration token")
+	if err != nil {
+		return "", err
+	}
+
+	var token RegistrationToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to decode registration token: %w", err)
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("gitea returned an empty registration token")
+	}
+	return token.Token, nil
+}
+
+// registrationTokenEndpoint returns the API endpoint issuing runner registration tokens for a scope
+func registrationTokenEndpoint(giteaURL string, scope v1alpha1.RunnerGroupScope, org, user, repo string) (string, error) {
+	// Gitea serves the instance-wide token outside of admin/actions
+	if scope == v1alpha1.RunnerGroupScopeGlobal {
+		return strings.TrimSuffix(giteaURL, "/") + "/api/v1/admin/runners/registration-token", nil
+	}
+	endpoint, err := runnersEndpoint(giteaURL, scope, org, user, repo)
+	if err != nil {
+		return "", err
+	}
+	return endpoint + "/registration-token", nil
+}
+
+// VerifyScope implements the Client interface
+func (c *HTTPClient) VerifyScope(
+	ctx context.Context,
+	giteaURL string,
+	authToken string,
+	scope v1alpha1.RunnerGroupScope,
+	org string,
+	user string,
+	repo string,
+) error {
+	base := strings.TrimSuffix(giteaURL, "/")
+	// scopeEndpoint tells an existing scope from a missing one on Gitea versions without
+	// jobs endpoints
+	var endpoint, scopeEndpoint, operation string
+	switch scope {
+	case v1alpha1.RunnerGroupScopeRepo:
+		owner := org
+		if user != "" {
+			owner = user
+		}
+		scopeEndpoint = fmt.Sprintf("%s/api/v1/repos/%s/%s", base, owner, repo)
+		endpoint = scopeEndpoint + "/actions/jobs"
+		operation = fmt.Sprintf("verify repository %s/%s", owner, repo)
+	case v1alpha1.RunnerGroupScopeOrg:
+		scopeEndpoint = fmt.Sprintf("%s/api/v1/orgs/%s", base, org)
+		endpoint = scopeEndpoint + "/actions/jobs"
+		operation = fmt.Sprintf("verify organization %s", org)
+	case v1alpha1.RunnerGroupScopeUser:
+		endpoint = fmt.Sprintf("%s/api/v1/users/%s/repos", base, user)
+		operation = fmt.Sprintf("verify user %s", user)
+	case v1alpha1.RunnerGroupScopeGlobal:
+		scopeEndpoint = base + "/api/v1/admin/orgs"
+		endpoint = base + "/api/v1/admin/actions/jobs"
+		operation = "verify global scope"
+	default:
+		return fmt.Errorf("unknown scope: %s", scope)
+	}
+	if c.legacyActions(giteaURL) && scopeEndpoint != "" {
+		endpoint = scopeEndpoint
+	}
+	_, err := c.doRequest(ctx, "GET", endpoint+"?limit=1", authToken, operation)
+	if scopeEndpoint != "" && c.jobsEndpointMissing(ctx, giteaURL, authToken, scopeEndpoint+"?limit=1", err) {
+		return nil
+	}
+	return err
+}
+
+// findRunner pages through the runners of an endpoint for the one with the given name.
+// It returns nil if no such runner is registered.
+func (c *HTTPClient) findRunner(ctx context.Context, endpoint, authToken, name string) (*ActionRunner, error) {
+	var found *ActionRunner
+	err := c.forEachRunner(ctx, endpoint, authToken, func(runner *ActionRunner) bool {
+		if runner.Name == name {
+			found = runner
+			return false
+		}
+		return true
+	})
+	return found, err
+}
+
+// forEachRunner pages through the runners listed at endpoint, calling visit for each until it
+// returns false
+func (c *HTTPClient) forEachRunner(ctx context.Context, endpoint, authToken string, visit func(*ActionRunner) bool) error {
 	page := 1
 	limit := 50
-
 	for {
-		endpoint := fmt.Sprintf("%s/api/v1/users/%s/repos", strings.TrimSuffix(giteaURL, "/"), username)
 		u, err := url.Parse(endpoint)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		q := u.Query()
 		q.Set("page", fmt.Sprintf("%d", page))
 		q.Set("limit", fmt.Sprintf("%d", limit))
 		u.RawQuery = q.Encode()
 
-		fmt.Printf("DEBUG: Fetching repos for user %s from %s\n", username, u.String())
-
-		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		body, err := c.doRequest(ctx, "GET", u.String(), authToken, "list runners")
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		req.Header.Set("Authorization", "token "+authToken)
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			fmt.Printf("DEBUG: Request failed: %v\n", err)
-			return nil, err
+		var result ActionRunnersResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return err
 		}
 
-		fmt.Printf("DEBUG: Response status: %s\n", resp.Status)
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			_ = resp.Body.Close()
-			fmt.Printf("DEBUG: Error body: %s\n", string(body))
-			return nil, c.handleHTTPError(resp.StatusCode, body, "fetch user repos")
+		for i := range result.Runners {
+			if !visit(&result.Runners[i]) {
+				return nil
+			}
 		}
 
-		body, _ := io.ReadAll(resp.Body)
-		_ = resp.Body.Close()
-		// fmt.Printf("DEBUG: Response body: %s\n", string(body))
-
-		var repos []Repository
-		if err := json.Unmarshal(body, &repos); err != nil {
-			fmt.Printf("DEBUG: Failed to decode response: %v\n", err)
-			return nil, err
+		if len(result.Runners) < limit {
+			return nil
 		}
+		page++
+	}
+}
 
-		allRepos = append(allRepos, repos...)
+// doRequest sends an authenticated request and returns the response body of a successful response
+func (c *HTTPClient) doRequest(ctx context.Context, method, endpoint, authToken, operation string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if len(repos) < limit {
-			break
-		}
+	setRequestHeaders(ctx, req, authToken)
 
-		page++
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
 	}
 
-	return allRepos, nil
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	logger := log.FromContext(ctx).WithName("gitea")
+	logger.V(requestLogLevel).Info("Gitea responded", "method", method, "url", endpoint, "status", resp.StatusCode)
+	logger.V(bodyLogLevel).Info("Gitea response body", "method", method, "url", endpoint, "body", string(body))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, c.handleResponseError(resp, body, operation)
+	}
+	return body, nil
 }
 
-// filterQueuedJobs filters workflow jobs by labels
+// filterQueuedJobs filters workflow jobs by labels. Nil runner labels disable filtering,
+// for callers that match jobs themselves.
 func (c *HTTPClient) filterQueuedJobs(jobs []ActionWorkflowJob, runnerLabels []string) []ActionWorkflowJob {
+	if runnerLabels == nil {
+		return jobs
+	}
 	var matched []ActionWorkflowJob
 	for _, job := range jobs {
-		match := c.jobMatchesLabels(job.Labels, runnerLabels)
-		fmt.Printf("DEBUG: Job %d (Status: %s, Labels: %v) matches runner capabilities %v? %v\n", job.ID, job.Status, job.Labels, runnerLabels, match)
-		if match {
+		if JobMatchesLabels(job.Labels, runnerLabels) {
 			matched = append(matched, job)
 		}
 	}
@@ -349,17 +974,24 @@ func (c *HTTPClient) filterQueuedJobs(jobs []ActionWorkflowJob, runnerLabels []s
 
 // jobMatchesLabels checks if a job's requirements are satisfied by the runner's supported labels
 func (c *HTTPClient) jobMatchesLabels(jobLabels, supportedLabels []string) bool {
+	return JobMatchesLabels(jobLabels, supportedLabels)
+}
+
+// JobMatchesLabels checks if a job's requirements are satisfied by the supported labels of a runner.
+// A job without labels matches any runner.
+func JobMatchesLabels(jobLabels, supportedLabels []string) bool {
 	if len(jobLabels) == 0 {
 		return true
 	}
 
-	// For each label required by the job, check if the runner supports it
+	// For each label required by the job, check if the runner supports it.
+	// Both sides are compared by label name, so "ubuntu-latest:docker://node:20" on either
+	// side matches a plain "ubuntu-latest" on the other.
 	for _, req := range jobLabels {
 		found := false
+		reqName := NormalizeLabel(req)
 		for _, supp := range supportedLabels {
-			// Check for exact match or schema match (label:schema)
-			// e.g. Job asks for "ubuntu-latest", Runner has "ubuntu-latest:docker://..."
-			if req == supp || strings.HasPrefix(supp, req+":") {
+			if reqName == NormalizeLabel(supp) {
 				found = true
 				break
 			}
@@ -371,15 +1003,22 @@ func (c *HTTPClient) jobMatchesLabels(jobLabels, supportedLabels []string) bool
 	return true
 }
 
+// NormalizeLabel returns the name of a runner or job label, without surrounding
+// whitespace and without any ":schema" suffix such as ":docker://node:20"
+func NormalizeLabel(label string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(label), ":")
+	return strings.TrimSpace(name)
+}
+
 // handleHTTPError provides specific error handling for different HTTP status codes
 func (c *HTTPClient) handleHTTPError(statusCode int, body []byte, operation string) error {
 	switch statusCode {
 	case http.StatusUnauthorized:
-		return fmt.Errorf("authentication failed for %s: check your token", operation)
+		return fmt.Errorf("%w for %s: check your token", ErrUnauthorized, operation)
 	case http.StatusForbidden:
-		return fmt.Errorf("access denied for %s: insufficient permissions", operation)
+		return fmt.Errorf("%w for %s: insufficient permissions", ErrForbidden, operation)
 	case http.StatusNotFound:
-		return fmt.Errorf("resource not found for %s: check URL and resource exists", operation)
+		return fmt.Errorf("%w for %s: check URL and resource exists", ErrNotFound, operation)
 	case http.StatusTooManyRequests:
 		return fmt.Errorf("rate limit exceeded for %s: please retry later", operation)
 	case http.StatusInternalServerError:
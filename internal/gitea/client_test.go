@@ -25,12 +25,15 @@ package gitea
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	"k8s.io/utils/ptr"
 )
 
 func TestHTTPClient_GetRunnerStats(t *testing.T) {
@@ -139,7 +142,8 @@ func TestHTTPClient_GetRunnerStats(t *testing.T) {
 							Owner: struct {
 								Login string `json:"login"`
 							}{Login: tt.user},
-							FullName: tt.user + "/testrepo",
+							FullName:   tt.user + "/testrepo",
+							HasActions: ptr.To(true),
 						},
 					}
 					_ = json.NewEncoder(w).Encode(repos)
@@ -236,6 +240,30 @@ func TestJobMatchesLabels(t *testing.T) {
 			supportedLabels: []string{"ubuntu-latest:docker://node:16"},
 			expected:        true,
 		},
+		{
+			name:            "schema on job side",
+			jobLabels:       []string{"ubuntu-latest:docker://node:20"},
+			supportedLabels: []string{"ubuntu-latest"},
+			expected:        true,
+		},
+		{
+			name:            "schema on both sides",
+			jobLabels:       []string{"ubuntu-latest:docker://node:20"},
+			supportedLabels: []string{"ubuntu-latest:docker://node:16"},
+			expected:        true,
+		},
+		{
+			name:            "surrounding whitespace",
+			jobLabels:       []string{" linux "},
+			supportedLabels: []string{"linux ", " x64"},
+			expected:        true,
+		},
+		{
+			name:            "prefix is not a match",
+			jobLabels:       []string{"ubuntu"},
+			supportedLabels: []string{"ubuntu-latest"},
+			expected:        false,
+		},
 		{
 			name:            "no match (missing req)",
 			jobLabels:       []string{"linux", "arm64"},
@@ -290,6 +318,11 @@ func TestFilterQueuedJobs(t *testing.T) {
 			supportedLabels: []string{"linux", "x64", "arm64", "windows", "docker"},
 			expectedIDs:     []int64{1, 2, 3, 4},
 		},
+		{
+			name:            "filtering disabled",
+			supportedLabels: nil,
+			expectedIDs:     []int64{1, 2, 3, 4},
+		},
 	}
 
 	for _, tt := range tests {
@@ -365,3 +398,425 @@ func TestHandleHTTPError(t *testing.T) {
 		})
 	}
 }
+
+func TestActionWorkflowJob_RepoFullName(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      ActionWorkflowJob
+		expected string
+	}{
+		{
+			name:     "from API URL",
+			job:      ActionWorkflowJob{URL: "https://gitea.example.com/api/v1/repos/myorg/myrepo/actions/jobs/42"},
+			expected: "myorg/myrepo",
+		},
+		{
+			name:     "from HTML URL with sub path",
+			job:      ActionWorkflowJob{HTMLURL: "https://example.com/gitea/myorg/myrepo/actions/runs/7/jobs/0"},
+			expected: "myorg/myrepo",
+		},
+		{
+			name:     "unknown",
+			job:      ActionWorkflowJob{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.RepoFullName(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_GetWorkflowRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		expectedError bool
+	}{
+		{name: "found", statusCode: http.StatusOK},
+		{name: "not found", statusCode: http.StatusNotFound, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/repos/myorg/myrepo/actions/runs/7" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				_ = json.NewEncoder(w).Encode(ActionWorkflowRun{ID: 7, Event: "push", HeadBranch: "main"})
+			}))
+			defer server.Close()
+
+			run, err := NewHTTPClient().GetWorkflowRun(context.Background(), server.URL, "test-token", "myorg/myrepo", 7)
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if run.Event != "push" || run.HeadBranch != "main" {
+				t.Errorf("Unexpected run %+v", run)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_GetWorkflowRunCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(ActionWorkflowRun{ID: 7, Event: "push", HeadBranch: "main"})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	for range 3 {
+		run, err := client.GetWorkflowRun(context.Background(), server.URL, "test-token", "myorg/myrepo", 7)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if run.Event != "push" {
+			t.Errorf("Unexpected run %+v", run)
+		}
+		run.Event = "modified"
+	}
+	if requests != 1 {
+		t.Errorf("Expected the run to be fetched once, got %d requests", requests)
+	}
+
+	if _, err := client.GetWorkflowRun(context.Background(), server.URL, "other-token", "myorg/myrepo", 7); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected another token to fetch the run again, got %d requests", requests)
+	}
+}
+
+func TestHTTPClient_GetVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		expectedError bool
+	}{
+		{name: "reachable", statusCode: http.StatusOK},
+		{name: "server error", statusCode: http.StatusBadGateway, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/version" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				_ = json.NewEncoder(w).Encode(ServerVersion{Version: "1.22.3"})
+			}))
+			defer server.Close()
+
+			version, err := NewHTTPClient().GetVersion(context.Background(), server.URL+"/", "test-token")
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if version != "1.22.3" {
+				t.Errorf("Expected version 1.22.3, got %q", version)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_DeregisterRunner(t *testing.T) {
+	tests := []struct {
+		name          string
+		runnerName    string
+		expectDeleted bool
+	}{
+		{name: "registered runner", runnerName: "group-abc", expectDeleted: true},
+		{name: "unknown runner", runnerName: "group-xyz", expectDeleted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v1/orgs/myorg/actions/runners":
+					_ = json.NewEncoder(w).Encode(ActionRunnersResponse{TotalCount: 1, Runners: []ActionRunner{{ID: 5, Name: "group-abc"}}})
+				case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/orgs/myorg/actions/runners/5":
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			err := NewHTTPClient().DeregisterRunner(context.Background(), server.URL, "test-token",
+				v1alpha1.RunnerGroupScopeOrg, "myorg", "", "", tt.runnerName)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_DeleteRunner(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{name: "deleted", statusCode: http.StatusNoContent},
+		{name: "already removed", statusCode: http.StatusNotFound},
+		{name: "forbidden", statusCode: http.StatusForbidden, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/api/v1/repos/myorg/api/actions/runners/5" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			err := NewHTTPClient().DeleteRunner(context.Background(), server.URL, "test-token",
+				v1alpha1.RunnerGroupScopeRepo, "myorg", "", "api", 5)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteRunner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_GetRunner(t *testing.T) {
+	tests := []struct {
+		name       string
+		runnerName string
+		expectedID int64
+	}{
+		{name: "registered runner", runnerName: "group-abc", expectedID: 5},
+		{name: "unknown runner", runnerName: "group-xyz", expectedID: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/api/v1/user/actions/runners" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				_ = json.NewEncoder(w).Encode(ActionRunnersResponse{TotalCount: 1, Runners: []ActionRunner{{ID: 5, Name: "group-abc"}}})
+			}))
+			defer server.Close()
+
+			runner, err := NewHTTPClient().GetRunner(context.Background(), server.URL, "test-token",
+				v1alpha1.RunnerGroupScopeUser, "", "myuser", "", tt.runnerName)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			var id int64
+			if runner != nil {
+				id = runner.ID
+			}
+			if id != tt.expectedID {
+				t.Errorf("Expected runner ID %d, got %d", tt.expectedID, id)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_ListRunners(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/orgs/myorg/actions/runners" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		// A full first page and a partial second one
+		var runners []ActionRunner
+		if r.URL.Query().Get("page") == "1" {
+			for i := range 50 {
+				runners = append(runners, ActionRunner{ID: int64(i + 1), Name: fmt.Sprintf("group-%d", i), Status: "online"})
+			}
+		} else {
+			runners = []ActionRunner{{ID: 51, Name: "group-50", Status: "offline"}}
+		}
+		_ = json.NewEncoder(w).Encode(ActionRunnersResponse{TotalCount: 51, Runners: runners})
+	}))
+	defer server.Close()
+
+	runners, err := NewHTTPClient().ListRunners(context.Background(), server.URL, "test-token",
+		v1alpha1.RunnerGroupScopeOrg, "myorg", "", "")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(runners) != 51 {
+		t.Fatalf("Expected 51 runners, got %d", len(runners))
+	}
+	if runners[50].Online() {
+		t.Errorf("Expected runner %s to be offline", runners[50].Name)
+	}
+}
+
+func TestHTTPClient_GetRegistrationToken(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        v1alpha1.RunnerGroupScope
+		org          string
+		user         string
+		repo         string
+		expectedPath string
+	}{
+		{name: "repo scope", scope: v1alpha1.RunnerGroupScopeRepo, org: "myorg", repo: "myrepo",
+			expectedPath: "/api/v1/repos/myorg/myrepo/actions/runners/registration-token"},
+		{name: "org scope", scope: v1alpha1.RunnerGroupScopeOrg, org: "myorg",
+			expectedPath: "/api/v1/orgs/myorg/actions/runners/registration-token"},
+		{name: "user scope", scope: v1alpha1.RunnerGroupScopeUser, user: "myuser",
+			expectedPath: "/api/v1/user/actions/runners/registration-token"},
+		{name: "global scope", scope: v1alpha1.RunnerGroupScopeGlobal,
+			expectedPath: "/api/v1/admin/runners/registration-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != tt.expectedPath {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				_ = json.NewEncoder(w).Encode(RegistrationToken{Token: "reg-token"})
+			}))
+			defer server.Close()
+
+			token, err := NewHTTPClient().GetRegistrationToken(context.Background(), server.URL, "test-token",
+				tt.scope, tt.org, tt.user, tt.repo)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if token != "reg-token" {
+				t.Errorf("Expected token reg-token, got %q", token)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_VerifyScope(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        v1alpha1.RunnerGroupScope
+		org          string
+		user         string
+		repo         string
+		statusCode   int
+		expectedPath string
+		expectedErr  error
+	}{
+		{name: "existing org", scope: v1alpha1.RunnerGroupScopeOrg, org: "myorg", statusCode: http.StatusOK, expectedPath: "/api/v1/orgs/myorg/actions/jobs"},
+		{name: "user owned repo", scope: v1alpha1.RunnerGroupScopeRepo, user: "myuser", repo: "myrepo", statusCode: http.StatusOK, expectedPath: "/api/v1/repos/myuser/myrepo/actions/jobs"},
+		{name: "existing user", scope: v1alpha1.RunnerGroupScopeUser, user: "myuser", statusCode: http.StatusOK, expectedPath: "/api/v1/users/myuser/repos"},
+		{name: "missing repo", scope: v1alpha1.RunnerGroupScopeRepo, org: "myorg", repo: "typo", statusCode: http.StatusNotFound, expectedPath: "/api/v1/repos/myorg/typo/actions/jobs", expectedErr: ErrNotFound},
+		{name: "global scope without admin token", scope: v1alpha1.RunnerGroupScopeGlobal, statusCode: http.StatusForbidden, expectedPath: "/api/v1/admin/actions/jobs", expectedErr: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// A missing jobs endpoint is followed by a check whether the scope itself exists
+				scopePath := strings.TrimSuffix(tt.expectedPath, "/actions/jobs")
+				if r.Method != http.MethodGet || (r.URL.Path != tt.expectedPath && r.URL.Path != scopePath) {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			err := NewHTTPClient().VerifyScope(context.Background(), server.URL, "test-token", tt.scope, tt.org, tt.user, tt.repo)
+			if tt.expectedErr == nil && err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_Sudo(t *testing.T) {
+	tests := []struct {
+		name     string
+		sudo     string
+		expected string
+	}{
+		{name: "without sudo", sudo: "", expected: ""},
+		{name: "impersonating a user", sudo: "org-bot", expected: "org-bot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Sudo"); got != tt.expected {
+					t.Errorf("Sudo header = %q, want %q", got, tt.expected)
+				}
+				if got := r.Header.Get("Authorization"); got != "token admin-token" {
+					t.Errorf("Authorization header = %q", got)
+				}
+				_ = json.NewEncoder(w).Encode(ActionWorkflowJobsResponse{})
+			}))
+			defer server.Close()
+
+			ctx := WithSudo(context.Background(), tt.sudo)
+			_, err := NewHTTPClient().GetRunnerStats(ctx, server.URL, "admin-token", v1alpha1.RunnerGroupScopeGlobal, "", "", "", nil)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_BasicAuth(t *testing.T) {
+	tests := []struct {
+		name      string
+		authToken string
+		username  string
+		password  string
+		basicAuth bool
+	}{
+		{name: "token", authToken: "admin-token"},
+		{name: "username and password", authToken: BasicAuth("ci-bot", "s3cr:et"), username: "ci-bot", password: "s3cr:et", basicAuth: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				if ok != tt.basicAuth || username != tt.username || password != tt.password {
+					t.Errorf("BasicAuth() = %q, %q, %v", username, password, ok)
+				}
+				if !tt.basicAuth && r.Header.Get("Authorization") != "token "+tt.authToken {
+					t.Errorf("Authorization header = %q", r.Header.Get("Authorization"))
+				}
+				_ = json.NewEncoder(w).Encode(ActionWorkflowJobsResponse{})
+			}))
+			defer server.Close()
+
+			_, err := NewHTTPClient().GetRunnerStats(context.Background(), server.URL, tt.authToken, v1alpha1.RunnerGroupScopeGlobal, "", "", "", nil)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"sync/atomic"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// sensitiveHeaders carry credentials in requests or responses
+	sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+	// sensitiveQueryParams carry credentials in request URLs
+	sensitiveQueryParams = []string{"token", "access_token"}
+	// sensitiveHeaderLines matches the credential header lines of a dump
+	sensitiveHeaderLines = regexp.MustCompile(`(?im)^(Authorization|Cookie|Set-Cookie):[^\r\n]*`)
+	// sensitiveJSONFields matches JSON string fields holding tokens, secrets or passwords
+	sensitiveJSONFields = regexp.MustCompile(`(?i)("[a-z_]*(?:token|secret|password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// dumpTransport logs every request and response passing through it while enabled, with
+// credentials redacted
+type dumpTransport struct {
+	next    http.RoundTripper
+	enabled *atomic.Bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (t dumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled.Load() {
+		return t.next.RoundTrip(req)
+	}
+	logger := log.FromContext(req.Context()).WithName("gitea")
+
+	requestDump, err := httputil.DumpRequestOut(sanitizeRequest(req), false)
+	if err != nil {
+		logger.Error(err, "Failed to dump Gitea request")
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		logger.Info("Gitea HTTP exchange failed", "request", string(requestDump), "error", err.Error())
+		return nil, err
+	}
+	// DumpResponse buffers the body and hands resp a copy to read
+	responseDump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		logger.Error(err, "Failed to dump Gitea response")
+	}
+	logger.Info("Gitea HTTP exchange", "request", string(requestDump), "response", sanitizeDump(responseDump))
+	return resp, nil
+}
+
+// sanitizeRequest returns a copy of the request with its credentials redacted
+func sanitizeRequest(req *http.Request) *http.Request {
+	sanitized := req.Clone(req.Context())
+	for _, header := range sensitiveHeaders {
+		if sanitized.Header.Get(header) != "" {
+			sanitized.Header.Set(header, redacted)
+		}
+	}
+	query := sanitized.URL.Query()
+	for _, param := range sensitiveQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	sanitized.URL.RawQuery = query.Encode()
+	return sanitized
+}
+
+// sanitizeDump redacts credential headers and JSON fields from a dumped response
+func sanitizeDump(dump []byte) string {
+	dump = sensitiveHeaderLines.ReplaceAll(dump, []byte("$1: "+redacted))
+	return string(sensitiveJSONFields.ReplaceAll(dump, []byte(`$1"`+redacted+`"`)))
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://gitea.example.com/api/v1/user/repos?token=abc&page=2", nil)
+	req.Header.Set("Authorization", "token secret-token")
+	req.Header.Set("Sudo", "alice")
+
+	sanitized := sanitizeRequest(req)
+	if got := sanitized.Header.Get("Authorization"); got != redacted {
+		t.Errorf("Expected Authorization to be redacted, got %q", got)
+	}
+	if got := sanitized.Header.Get("Sudo"); got != "alice" {
+		t.Errorf("Expected Sudo header to be kept, got %q", got)
+	}
+	if got := sanitized.URL.Query().Get("token"); got != redacted {
+		t.Errorf("Expected token query parameter to be redacted, got %q", got)
+	}
+	if got := sanitized.URL.Query().Get("page"); got != "2" {
+		t.Errorf("Expected page query parameter to be kept, got %q", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "token secret-token" {
+		t.Errorf("Expected the original request to keep its token, got %q", got)
+	}
+}
+
+func TestSanitizeDump(t *testing.T) {
+	tests := []struct {
+		name        string
+		dump        string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "cookie header",
+			dump:        "HTTP/1.1 200 OK\r\nSet-Cookie: i_like_gitea=abc\r\nContent-Type: application/json\r\n\r\n{}",
+			contains:    []string{"Set-Cookie: " + redacted + "\r\n", "Content-Type: application/json"},
+			notContains: []string{"i_like_gitea"},
+		},
+		{
+			name:        "token fields",
+			dump:        `{"token": "AAAA", "runner_token":"BB\"B", "name": "runner"}`,
+			contains:    []string{`"token": "` + redacted + `"`, `"runner_token":"` + redacted + `"`, `"name": "runner"`},
+			notContains: []string{"AAAA", `BB\"B`},
+		},
+		{
+			name:     "no credentials",
+			dump:     `{"total_count": 0, "jobs": []}`,
+			contains: []string{`{"total_count": 0, "jobs": []}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeDump([]byte(tt.dump))
+			for _, s := range tt.contains {
+				if !strings.Contains(got, s) {
+					t.Errorf("Expected dump to contain %q, got %q", s, got)
+				}
+			}
+			for _, s := range tt.notContains {
+				if strings.Contains(got, s) {
+					t.Errorf("Expected dump not to contain %q, got %q", s, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPClient_Dump(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(ActionWorkflowRun{ID: 3, Event: "push"})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	client.SetDump(true)
+	// The dump must leave the response body for the client to decode
+	run, err := client.GetWorkflowRun(context.Background(), server.URL, "test-token", "acme/web", 3)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if run.ID != 3 || run.Event != "push" {
+		t.Errorf("Expected run 3 of a push, got %+v", run)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// jobPageCacheSize bounds the job listing pages kept for conditional requests
+	jobPageCacheSize = 1024
+	// jobPageCacheTTL is how long an unused job listing page is kept
+	jobPageCacheTTL = 10 * time.Minute
+)
+
+// jobPagesNotModified counts job listing pages Gitea reported unchanged since the last poll
+var jobPagesNotModified = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "gitea_client_job_pages_not_modified_total",
+	Help: "Job listing pages Gitea answered with 304 Not Modified, served from the client's cache.",
+}, []string{"host"})
+
+func init() {
+	metrics.Registry.MustRegister(jobPagesNotModified)
+}
+
+// cachedJobPage is a job listing page and the ETag Gitea returned with it. Entries are shared
+// between requests and never modified.
+type cachedJobPage struct {
+	etag   string
+	result ActionWorkflowJobsResponse
+	// labels and matched are the runner labels the page was last filtered by and its matching jobs
+	labels  []string
+	matched []ActionWorkflowJob
+}
+
+// matchedJobs returns the page's jobs matching labels, filtering again only if they changed
+func (p *cachedJobPage) matchedJobs(c *HTTPClient, labels []string) []ActionWorkflowJob {
+	if (labels == nil) == (p.labels == nil) && slices.Equal(labels, p.labels) {
+		return p.matched
+	}
+	return c.filterQueuedJobs(p.result.Jobs, labels)
+}
+
+// jobPageCache holds the job listing pages last returned with an ETag, keyed by page URL and
+// the credentials they were fetched with
+type jobPageCache struct {
+	once  sync.Once
+	pages *cache.LRUExpireCache
+}
+
+// get returns the cached page, or nil
+func (c *jobPageCache) get(key string) *cachedJobPage {
+	c.once.Do(c.init)
+	page, ok := c.pages.Get(key)
+	if !ok {
+		return nil
+	}
+	return page.(*cachedJobPage)
+}
+
+// put caches a page
+func (c *jobPageCache) put(key string, page *cachedJobPage) {
+	c.once.Do(c.init)
+	c.pages.Add(key, page, jobPageCacheTTL)
+}
+
+func (c *jobPageCache) init() {
+	c.pages = cache.NewLRUExpireCache(jobPageCacheSize)
+}
+
+// jobPageKey identifies a page fetched from pageURL with the request's credentials, since
+// different tokens or sudo users may see different jobs. Credentials are only kept hashed.
+func jobPageKey(ctx context.Context, pageURL, authToken string) string {
+	identity := sha256.Sum256([]byte(authToken + "\x00" + SudoFromContext(ctx)))
+	return pageURL + " " + hex.EncodeToString(identity[:])
+}
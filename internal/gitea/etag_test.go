@@ -0,0 +1,94 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+func TestHTTPClient_JobListETag(t *testing.T) {
+	var conditional, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.URL.Query().Get("status") + `-v1"`
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		var jobs []ActionWorkflowJob
+		if r.URL.Query().Get("status") == "queued" {
+			jobs = []ActionWorkflowJob{
+				{ID: 1, Status: "queued", Labels: []string{"ubuntu-latest"}},
+				{ID: 2, Status: "queued", Labels: []string{"windows"}},
+			}
+		}
+		w.Header().Set("ETag", etag)
+		_ = json.NewEncoder(w).Encode(ActionWorkflowJobsResponse{Jobs: jobs, TotalCount: int64(len(jobs))})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	getStats := func(token string, labels []string) *RunnerStats {
+		t.Helper()
+		stats, err := client.GetRunnerStats(context.Background(), server.URL, token, v1alpha1.RunnerGroupScopeOrg, "myorg", "", "", labels)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return stats
+	}
+
+	tests := []struct {
+		name            string
+		token           string
+		labels          []string
+		wantJobs        int
+		wantNotModified int
+	}{
+		{name: "first poll fetches every page", token: "token", labels: []string{"ubuntu-latest"}, wantJobs: 1},
+		{name: "unchanged pages come from the cache", token: "token", labels: []string{"ubuntu-latest"}, wantJobs: 1, wantNotModified: 3},
+		{name: "cached pages are filtered by new labels", token: "token", labels: []string{"ubuntu-latest", "windows"}, wantJobs: 2, wantNotModified: 3},
+		{name: "other credentials don't share the cache", token: "other-token", labels: []string{"ubuntu-latest"}, wantJobs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditional, notModified = 0, 0
+			stats := getStats(tt.token, tt.labels)
+			if len(stats.QueuedJobs) != tt.wantJobs {
+				t.Errorf("QueuedJobs = %d, want %d", len(stats.QueuedJobs), tt.wantJobs)
+			}
+			if notModified != tt.wantNotModified || conditional != tt.wantNotModified {
+				t.Errorf("conditional requests = %d, not modified = %d, want %d", conditional, notModified, tt.wantNotModified)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// legacyActions reports whether the Gitea instance was found to lack the Actions jobs endpoints,
+// by its version or by answering them with 404, so its queued work is polled through each
+// repository's workflow runs instead
+func (c *HTTPClient) legacyActions(giteaURL string) bool {
+	if caps, ok := c.knownCapabilities(giteaURL); ok && !caps.JobsEndpoints {
+		return true
+	}
+	_, legacy := c.legacyHosts.Load(strings.TrimSuffix(giteaURL, "/"))
+	return legacy
+}
+
+// jobsEndpointMissing reports whether err is a jobs endpoint of Gitea answering 404 although the
+// scope it belongs to, read from scopeEndpoint, exists. That is what Gitea versions without the
+// jobs endpoints do; the instance is then remembered as one.
+func (c *HTTPClient) jobsEndpointMissing(ctx context.Context, giteaURL, authToken, scopeEndpoint string, err error) bool {
+	if !errors.Is(err, ErrNotFound) {
+		return false
+	}
+	if _, scopeErr := c.doRequest(ctx, "GET", scopeEndpoint, authToken, "check scope exists"); scopeErr != nil {
+		return false
+	}
+	if _, known := c.legacyHosts.LoadOrStore(strings.TrimSuffix(giteaURL, "/"), true); !known {
+		log.FromContext(ctx).Info("Gitea has no Actions jobs endpoints, polling workflow runs of each repository instead",
+			"giteaURL", giteaURL)
+	}
+	return true
+}
+
+// fetchQueuedRuns fetches the queued workflow runs of a repository as jobs. Runs don't tell which
+// labels their jobs need, so the jobs have none and match any runner.
+func (c *HTTPClient) fetchQueuedRuns(ctx context.Context, giteaURL, authToken, owner, repo string) ([]ActionWorkflowJob, error) {
+	base := strings.TrimSuffix(giteaURL, "/")
+	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/actions/runs", base, owner, repo)
+	var jobs []ActionWorkflowJob
+
+	for _, status := range []string{"queued", "waiting", "pending"} {
+		page := 1
+		limit := 50
+
+		for {
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				return nil, err
+			}
+			q := u.Query()
+			q.Set("status", status)
+			q.Set("page", fmt.Sprintf("%d", page))
+			q.Set("limit", fmt.Sprintf("%d", limit))
+			u.RawQuery = q.Encode()
+
+			body, err := c.doRequest(ctx, "GET", u.String(), authToken, "fetch workflow runs")
+			if err != nil {
+				return nil, err
+			}
+			var result ActionWorkflowRunsResponse
+			if err := json.Unmarshal(body, &result); err != nil {
+				return nil, fmt.Errorf("failed to decode workflow runs: %w", err)
+			}
+
+			for _, run := range result.WorkflowRuns {
+				jobs = append(jobs, ActionWorkflowJob{
+					ID:      run.ID,
+					Status:  run.Status,
+					Name:    run.DisplayTitle,
+					RunID:   run.ID,
+					URL:     fmt.Sprintf("%s/%d", endpoint, run.ID),
+					HTMLURL: fmt.Sprintf("%s/%s/%s/actions/runs/%d", base, owner, repo, run.RunNumber),
+				})
+			}
+
+			if len(result.WorkflowRuns) < limit {
+				break
+			}
+			page++
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+// legacyGitea serves a Gitea without the Actions jobs endpoints, with one queued run in every
+// repository of myorg
+func legacyGitea(jobsRequests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/actions/jobs"):
+			jobsRequests.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/api/v1/orgs/myorg", r.URL.Path == "/api/v1/repos/myorg/api", r.URL.Path == "/api/v1/admin/orgs":
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/v1/orgs/myorg/repos":
+			_, _ = w.Write([]byte(`[{"name": "api", "owner": {"login": "myorg"}, "has_actions": true}, {"name": "web", "owner": {"login": "myorg"}, "has_actions": false}]`))
+		case r.URL.Path == "/api/v1/repos/search":
+			_, _ = w.Write([]byte(`{"ok": true, "data": [{"name": "api", "owner": {"login": "myorg"}, "has_actions": true}]}`))
+		case r.URL.Path == "/api/v1/repos/myorg/api/actions/runs":
+			if r.URL.Query().Get("status") != "queued" {
+				_, _ = w.Write([]byte(`{"total_count": 0, "workflow_runs": []}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"total_count": 1, "workflow_runs": [{"id": 42, "status": "queued", "display_title": "CI", "run_number": 7}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestHTTPClient_LegacyActions(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope v1alpha1.RunnerGroupScope
+		org   string
+		repo  string
+	}{
+		{name: "repo scope", scope: v1alpha1.RunnerGroupScopeRepo, org: "myorg", repo: "api"},
+		{name: "org scope", scope: v1alpha1.RunnerGroupScopeOrg, org: "myorg"},
+		{name: "global scope", scope: v1alpha1.RunnerGroupScopeGlobal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jobsRequests atomic.Int32
+			server := legacyGitea(&jobsRequests)
+			defer server.Close()
+
+			client := NewHTTPClient()
+			if err := client.VerifyScope(context.Background(), server.URL, "test-token", tt.scope, tt.org, "", tt.repo); err != nil {
+				t.Fatalf("VerifyScope() error = %v", err)
+			}
+			for range 2 {
+				stats, err := client.GetRunnerStats(context.Background(), server.URL, "test-token", tt.scope, tt.org, "", tt.repo, []string{"ubuntu-latest"})
+				if err != nil {
+					t.Fatalf("GetRunnerStats() error = %v", err)
+				}
+				if len(stats.QueuedJobs) != 1 {
+					t.Fatalf("QueuedJobs = %d, want 1", len(stats.QueuedJobs))
+				}
+				job := stats.QueuedJobs[0]
+				if job.ID != 42 || job.RepoFullName() != "myorg/api" {
+					t.Errorf("job = %+v, want run 42 of myorg/api", job)
+				}
+			}
+			// Once detected, the jobs endpoints aren't asked again
+			if jobsRequests.Load() != 1 {
+				t.Errorf("jobs endpoint requests = %d, want 1", jobsRequests.Load())
+			}
+		})
+	}
+}
+
+func TestHTTPClient_LegacyActionsMissingScope(t *testing.T) {
+	var jobsRequests atomic.Int32
+	server := legacyGitea(&jobsRequests)
+	defer server.Close()
+
+	client := NewHTTPClient()
+	err := client.VerifyScope(context.Background(), server.URL, "test-token", v1alpha1.RunnerGroupScopeOrg, "typo", "", "")
+	if err == nil {
+		t.Fatal("VerifyScope() of a missing org succeeded")
+	}
+	if client.legacyActions(server.URL) {
+		t.Error("missing org mistaken for missing jobs endpoints")
+	}
+}
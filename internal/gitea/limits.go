@@ -0,0 +1,147 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// requestQueueDuration is how long requests waited for the client-side limits of their host
+var requestQueueDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "gitea_client_request_queue_duration_seconds",
+	Help:    "Time Gitea API requests waited for the request rate and in-flight limits of their host.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"host"})
+
+func init() {
+	metrics.Registry.MustRegister(requestQueueDuration)
+}
+
+// HostLimits caps the requests sent to a Gitea host. Zero values don't limit.
+type HostLimits struct {
+	// QPS is the sustained number of requests per second
+	QPS float32
+	// Burst is how many requests may be sent at once above QPS; at least 1 with a QPS
+	Burst int
+	// MaxInFlight is how many requests may be waiting for a response at the same time
+	MaxInFlight int
+}
+
+// hostLimitsKey is the context key of the limits of the requested host
+type hostLimitsKey struct{}
+
+// WithHostLimits returns a context whose Gitea requests are subject to limits. The limits are
+// shared by all requests to the same host, and the limits of the latest request apply.
+func WithHostLimits(ctx context.Context, limits HostLimits) context.Context {
+	return context.WithValue(ctx, hostLimitsKey{}, limits)
+}
+
+// HostLimitsFromContext returns the limits of requests made with ctx
+func HostLimitsFromContext(ctx context.Context) HostLimits {
+	limits, _ := ctx.Value(hostLimitsKey{}).(HostLimits)
+	return limits
+}
+
+// hostLimiters holds the request rate limiter and in-flight slots of every limited host
+type hostLimiters struct {
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// hostLimiter enforces the limits of one host
+type hostLimiter struct {
+	limits   HostLimits
+	tokens   flowcontrol.RateLimiter
+	inFlight chan struct{}
+}
+
+// get returns the host's limiter, replacing it if its limits changed. Requests holding a slot of
+// a replaced limiter release it there.
+func (l *hostLimiters) get(host string, limits HostLimits) *hostLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limiter := l.hosts[host]; limiter != nil && limiter.limits == limits {
+		return limiter
+	}
+	limiter := &hostLimiter{limits: limits}
+	if limits.QPS > 0 {
+		limiter.tokens = flowcontrol.NewTokenBucketRateLimiter(limits.QPS, max(limits.Burst, 1))
+	}
+	if limits.MaxInFlight > 0 {
+		limiter.inFlight = make(chan struct{}, limits.MaxInFlight)
+	}
+	if l.hosts == nil {
+		l.hosts = make(map[string]*hostLimiter)
+	}
+	if previous := l.hosts[host]; previous != nil && previous.tokens != nil {
+		previous.tokens.Stop()
+	}
+	l.hosts[host] = limiter
+	return limiter
+}
+
+// acquire waits until a request to host may be sent under limits. The returned function frees
+// the request's in-flight slot.
+func (l *hostLimiters) acquire(ctx context.Context, host string, limits HostLimits) (func(), error) {
+	if limits == (HostLimits{}) {
+		return func() {}, nil
+	}
+	limiter := l.get(host, limits)
+	start := time.Now()
+	defer func() { requestQueueDuration.WithLabelValues(host).Observe(time.Since(start).Seconds()) }()
+
+	if limiter.tokens != nil {
+		if err := limiter.tokens.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if limiter.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case limiter.inFlight <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-limiter.inFlight }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releasingBody frees a request's in-flight slot once its response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer
+func (b releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
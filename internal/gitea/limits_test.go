@@ -0,0 +1,132 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostLimiters(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits HostLimits
+		// blocked is whether a second request waits while the first holds its slot
+		blocked bool
+	}{
+		{name: "no limits", limits: HostLimits{}},
+		{name: "one in flight", limits: HostLimits{MaxInFlight: 1}, blocked: true},
+		{name: "two in flight", limits: HostLimits{MaxInFlight: 2}},
+		{name: "rate limited", limits: HostLimits{QPS: 1, Burst: 1}, blocked: true},
+		{name: "rate limited with burst", limits: HostLimits{QPS: 1, Burst: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiters := &hostLimiters{}
+			release, err := limiters.acquire(context.Background(), "gitea", tt.limits)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer release()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			second, err := limiters.acquire(ctx, "gitea", tt.limits)
+			if blocked := err != nil; blocked != tt.blocked {
+				t.Fatalf("second request blocked = %v, want %v (err %v)", blocked, tt.blocked, err)
+			}
+			if err == nil {
+				second()
+			}
+
+			// Other hosts have their own limits
+			other, err := limiters.acquire(context.Background(), "other", tt.limits)
+			if err != nil {
+				t.Fatalf("request to another host blocked: %v", err)
+			}
+			other()
+		})
+	}
+}
+
+func TestHostLimiters_Release(t *testing.T) {
+	limiters := &hostLimiters{}
+	limits := HostLimits{MaxInFlight: 1}
+	release, err := limiters.acquire(context.Background(), "gitea", limits)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	release()
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := limiters.acquire(ctx, "gitea", limits); err != nil {
+		t.Fatalf("slot not freed: %v", err)
+	}
+	if _, err := limiters.acquire(ctx, "gitea", limits); err == nil {
+		t.Fatal("releasing twice freed two slots")
+	}
+}
+
+func TestHTTPClient_MaxInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":"1.22.0"}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	ctx := WithHostLimits(context.Background(), HostLimits{MaxInFlight: 2})
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetVersion(ctx, server.URL, "admin-token"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() != 2 {
+		t.Errorf("peak in-flight requests = %d, want 2", peak.Load())
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// requestsTotal counts the requests sent to Gitea
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_client_requests_total",
+		Help: "Requests sent to the Gitea API.",
+	}, []string{"host", "endpoint"})
+
+	// requestErrors counts the failed requests by HTTP status code, "error" if no response arrived
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_client_request_errors_total",
+		Help: "Gitea API requests that failed, by HTTP status code or \"error\" if no response arrived.",
+	}, []string{"host", "endpoint", "status"})
+
+	// requestDuration is how long Gitea took to answer
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gitea_client_request_duration_seconds",
+		Help:    "Time until the Gitea API returned response headers.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"host", "endpoint"})
+
+	// pagesFetched counts the pages of paginated listings read from Gitea
+	pagesFetched = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitea_client_pages_fetched_total",
+		Help: "Pages of paginated Gitea API listings fetched successfully.",
+	}, []string{"host", "endpoint"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, requestErrors, requestDuration, pagesFetched)
+}
+
+// instrumentedTransport records metrics for every request sent through it
+type instrumentedTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host, endpoint := req.URL.Host, endpointTemplate(req.URL.Path)
+	requestsTotal.WithLabelValues(host, endpoint).Inc()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	requestDuration.WithLabelValues(host, endpoint).Observe(time.Since(start).Seconds())
+
+	switch {
+	case err != nil:
+		requestErrors.WithLabelValues(host, endpoint, "error").Inc()
+	case resp.StatusCode >= http.StatusBadRequest:
+		requestErrors.WithLabelValues(host, endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	case req.URL.Query().Has("page") && resp.StatusCode != http.StatusNotModified:
+		pagesFetched.WithLabelValues(host, endpoint).Inc()
+	}
+	return resp, err
+}
+
+// endpointTemplate replaces the owner, repository and ID segments of an API path with
+// placeholders, so metrics have one series per endpoint rather than per repository
+func endpointTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(segments); i++ {
+		switch {
+		case segments[i] == "repos" && i+2 < len(segments):
+			segments[i+1], segments[i+2] = "{owner}", "{repo}"
+			i += 2
+		case (segments[i] == "orgs" || segments[i] == "users") && i+1 < len(segments):
+			segments[i+1] = "{" + strings.TrimSuffix(segments[i], "s") + "}"
+			i++
+		default:
+			if _, err := strconv.ParseInt(segments[i], 10, 64); err == nil {
+				segments[i] = "{id}"
+			}
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/api/v1/repos/acme/web/actions/jobs", expected: "/api/v1/repos/{owner}/{repo}/actions/jobs"},
+		{path: "/api/v1/repos/acme/web/actions/runs/42", expected: "/api/v1/repos/{owner}/{repo}/actions/runs/{id}"},
+		{path: "/api/v1/orgs/acme/actions/runners/7", expected: "/api/v1/orgs/{org}/actions/runners/{id}"},
+		{path: "/api/v1/users/bob/repos", expected: "/api/v1/users/{user}/repos"},
+		{path: "/api/v1/user/actions/runners", expected: "/api/v1/user/actions/runners"},
+		{path: "/api/v1/admin/actions/jobs", expected: "/api/v1/admin/actions/jobs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := endpointTemplate(tt.path); got != tt.expected {
+				t.Errorf("endpointTemplate(%q) = %q, want %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/repos/acme/missing/actions/runs/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"total_count": 0, "jobs": []}`))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	host := u.Host
+
+	client := NewHTTPClient()
+	if _, err := client.GetRunnerStats(context.Background(), server.URL, "test-token", v1alpha1.RunnerGroupScopeRepo, "acme", "", "web", nil); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if _, err := client.GetWorkflowRun(context.Background(), server.URL, "test-token", "acme/missing", 1); err == nil {
+		t.Fatal("Expected an error for a missing workflow run")
+	}
+
+	jobs := "/api/v1/repos/{owner}/{repo}/actions/jobs"
+	runs := "/api/v1/repos/{owner}/{repo}/actions/runs/{id}"
+	// One page per queued status
+	if got := testutil.ToFloat64(pagesFetched.WithLabelValues(host, jobs)); got != 3 {
+		t.Errorf("Expected 3 pages fetched, got %v", got)
+	}
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues(host, jobs)); got != 3 {
+		t.Errorf("Expected 3 job requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(requestErrors.WithLabelValues(host, runs, "404")); got != 1 {
+		t.Errorf("Expected 1 not found error, got %v", got)
+	}
+	if got := testutil.CollectAndCount(requestDuration, "gitea_client_request_duration_seconds"); got < 2 {
+		t.Errorf("Expected request durations for both endpoints, got %d series", got)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// oauth2TokenPath is Gitea's OAuth2 token endpoint, which also exchanges refresh tokens
+const oauth2TokenPath = "/login/oauth/access_token"
+
+// OAuth2Token is an access token issued by Gitea's OAuth2 provider
+type OAuth2Token struct {
+	AccessToken string
+	// RefreshToken replaces the refresh token the access token was obtained with. Gitea may
+	// invalidate the old one, so it has to be stored for the next refresh.
+	RefreshToken string
+	Expiry       time.Time
+}
+
+// oauth2TokenResponse is the body of a successful or failed token request
+type oauth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// BearerAuth returns credentials authenticating with an OAuth2 access token. They can be passed
+// wherever the client takes an auth token.
+func BearerAuth(accessToken string) string {
+	return bearerAuthPrefix + accessToken
+}
+
+// RefreshOAuth2Token implements the Client interface
+func (c *HTTPClient) RefreshOAuth2Token(ctx context.Context, giteaURL, clientID, clientSecret, refreshToken string) (*OAuth2Token, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"refresh_token": {refreshToken},
+	}
+	endpoint := strings.TrimSuffix(giteaURL, "/") + oauth2TokenPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	log.FromContext(ctx).WithName("gitea").V(requestLogLevel).Info("Gitea responded", "method", http.MethodPost, "url", endpoint, "status", resp.StatusCode)
+
+	var token oauth2TokenResponse
+	_ = json.Unmarshal(body, &token)
+	// A revoked or expired refresh token or wrong client credentials leave the operator locked
+	// out just like a rejected API token
+	if token.Error == "invalid_grant" || token.Error == "invalid_client" || token.Error == "unauthorized_client" {
+		return nil, fmt.Errorf("%w for refresh OAuth2 token: %s %s", ErrUnauthorized, token.Error, token.Description)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, c.handleResponseError(resp, body, "refresh OAuth2 token")
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("gitea returned an empty OAuth2 access token")
+	}
+	refreshed := &OAuth2Token{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = refreshToken
+	}
+	return refreshed, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClient_RefreshOAuth2Token(t *testing.T) {
+	tests := []struct {
+		name                 string
+		statusCode           int
+		response             oauth2TokenResponse
+		expectedRefreshToken string
+		expectedErr          error
+	}{
+		{
+			name:                 "rotated refresh token",
+			statusCode:           http.StatusOK,
+			response:             oauth2TokenResponse{AccessToken: "access", TokenType: "bearer", ExpiresIn: 3600, RefreshToken: "refresh-2"},
+			expectedRefreshToken: "refresh-2",
+		},
+		{
+			name:                 "refresh token kept",
+			statusCode:           http.StatusOK,
+			response:             oauth2TokenResponse{AccessToken: "access", TokenType: "bearer", ExpiresIn: 3600},
+			expectedRefreshToken: "refresh-1",
+		},
+		{
+			name:        "revoked refresh token",
+			statusCode:  http.StatusBadRequest,
+			response:    oauth2TokenResponse{Error: "invalid_grant", Description: "token was already used"},
+			expectedErr: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != oauth2TokenPath || r.Method != http.MethodPost {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "refresh-1" ||
+					r.FormValue("client_id") != "client" || r.FormValue("client_secret") != "secret" {
+					t.Errorf("unexpected form %v", r.Form)
+				}
+				w.WriteHeader(tt.statusCode)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer server.Close()
+
+			token, err := NewHTTPClient().RefreshOAuth2Token(context.Background(), server.URL, "client", "secret", "refresh-1")
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("Expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if token.AccessToken != "access" || token.RefreshToken != tt.expectedRefreshToken || token.Expiry.IsZero() {
+				t.Errorf("unexpected token %+v", token)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// proxyKey is the context key of the proxy requests go through
+type proxyKey struct{}
+
+// WithProxy returns a context whose Gitea requests go through the proxy at proxyURL, an http,
+// https or socks5 URL. An empty URL falls back to the operator's HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables.
+func WithProxy(ctx context.Context, proxyURL string) context.Context {
+	return context.WithValue(ctx, proxyKey{}, proxyURL)
+}
+
+// ProxyFromContext returns the proxy URL requests made with ctx go through, or "" if none
+func ProxyFromContext(ctx context.Context) string {
+	proxyURL, _ := ctx.Value(proxyKey{}).(string)
+	return proxyURL
+}
+
+// proxyForRequest is the Proxy function of the client's transports. The transports pool
+// connections per proxy, so groups behind different proxies never share a connection.
+func proxyForRequest(req *http.Request) (*url.URL, error) {
+	proxyURL := ProxyFromContext(req.Context())
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment(req)
+	}
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	return parsed, nil
+}
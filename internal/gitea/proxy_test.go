@@ -0,0 +1,63 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClient_Proxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_, _ = w.Write([]byte(`{"version":"1.24.0"}`))
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name          string
+		proxyURL      string
+		expectedHost  string
+		expectedError bool
+	}{
+		{name: "through the group's proxy", proxyURL: proxy.URL, expectedHost: "gitea.invalid"},
+		{name: "invalid proxy URL", proxyURL: "http://[::1", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxiedHost = ""
+			ctx := WithProxy(context.Background(), tt.proxyURL)
+			_, err := NewHTTPClient().GetVersion(ctx, "http://gitea.invalid", "admin-token")
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("GetVersion() error = %v, expectedError %v", err, tt.expectedError)
+			}
+			if proxiedHost != tt.expectedHost {
+				t.Errorf("proxied host = %q, want %q", proxiedHost, tt.expectedHost)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned for throttled requests, with how long the server asked to wait
+type RateLimitError struct {
+	// RetryAfter is how long to wait before the next request, 0 if the server didn't say
+	RetryAfter time.Duration
+	operation  string
+}
+
+// Error implements error
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s for %s: retry after %s", ErrRateLimited, e.operation, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s for %s: please retry later", ErrRateLimited, e.operation)
+}
+
+// Is makes RateLimitErrors match ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RetryAfter returns how long the server that rate limited a request asked to wait, or how long
+// an open circuit stays open. It is 0 for other errors or if the server didn't say.
+func RetryAfter(err error) time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.RetryAfter
+	}
+	var circuitErr *CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return circuitErr.RetryAfter
+	}
+	return 0
+}
+
+// rateLimitError returns a RateLimitError if the response throttles the operator: a 429, or a
+// 403 with X-RateLimit-Remaining: 0 as sent by some proxies. The delay is read from Retry-After,
+// in seconds or as a date, or else from X-RateLimit-Reset, a Unix time or seconds from now.
+func rateLimitError(resp *http.Response, operation string, now time.Time) error {
+	throttled := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0")
+	if !throttled {
+		return nil
+	}
+	return &RateLimitError{RetryAfter: retryAfter(resp.Header, now), operation: operation}
+}
+
+// retryAfter parses the delay a throttled response asks for, 0 if there is none
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0)
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0)
+		}
+	}
+	if value := header.Get("X-RateLimit-Reset"); value != "" {
+		if reset, err := strconv.ParseInt(value, 10, 64); err == nil {
+			// Values this large are Unix times, smaller ones seconds from now
+			if reset > 1_000_000_000 {
+				return max(time.Unix(reset, 0).Sub(now), 0)
+			}
+			return max(time.Duration(reset)*time.Second, 0)
+		}
+	}
+	return 0
+}
+
+// handleResponseError returns the error for an unsuccessful response, including its rate limit
+func (c *HTTPClient) handleResponseError(resp *http.Response, body []byte, operation string) error {
+	if err := rateLimitError(resp, operation, time.Now()); err != nil {
+		return err
+	}
+	return c.handleHTTPError(resp.StatusCode, body, operation)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitError(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		header        map[string]string
+		expectedLimit bool
+		expectedDelay time.Duration
+	}{
+		{name: "not throttled", statusCode: http.StatusForbidden},
+		{name: "429 without delay", statusCode: http.StatusTooManyRequests, expectedLimit: true},
+		{name: "Retry-After seconds", statusCode: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "120"},
+			expectedLimit: true, expectedDelay: 2 * time.Minute},
+		{name: "Retry-After date", statusCode: http.StatusTooManyRequests, header: map[string]string{"Retry-After": now.Add(90 * time.Second).Format(http.TimeFormat)},
+			expectedLimit: true, expectedDelay: 90 * time.Second},
+		{name: "X-RateLimit-Reset Unix time", statusCode: http.StatusTooManyRequests,
+			header:        map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Add(time.Minute).Unix(), 10)},
+			expectedLimit: true, expectedDelay: time.Minute},
+		{name: "exhausted quota on a 403", statusCode: http.StatusForbidden,
+			header:        map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"},
+			expectedLimit: true, expectedDelay: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.statusCode, Header: http.Header{}}
+			for name, value := range tt.header {
+				resp.Header.Set(name, value)
+			}
+			err := rateLimitError(resp, "test operation", now)
+			if (err != nil) != tt.expectedLimit {
+				t.Fatalf("rateLimitError() = %v, expectedLimit %v", err, tt.expectedLimit)
+			}
+			if got := RetryAfter(err); got != tt.expectedDelay {
+				t.Errorf("RetryAfter() = %s, want %s", got, tt.expectedDelay)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_RateLimited(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient().GetVersion(context.Background(), server.URL, "admin-token")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if RetryAfter(err) != time.Minute {
+		t.Errorf("RetryAfter() = %s, want 1m", RetryAfter(err))
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// registerRunnerPath is the runner protocol's Register RPC, which act_runner itself registers
+// through. It is served with the Connect protocol, which accepts plain JSON over HTTP POST.
+const registerRunnerPath = "/api/actions/runner.v1.RunnerService/Register"
+
+// runnerVersion is the version runners registered by the operator report to Gitea
+const runnerVersion = "gitea-runner-operator"
+
+// RegisteredRunner is a runner registered in Gitea together with the credentials it connects with
+type RegisteredRunner struct {
+	ID        int64
+	UUID      string
+	Token     string
+	Name      string
+	Labels    []string
+	Ephemeral bool
+}
+
+// registerRunnerRequest is the body of the Register RPC
+type registerRunnerRequest struct {
+	Name      string   `json:"name"`
+	Token     string   `json:"token"`
+	Version   string   `json:"version"`
+	Labels    []string `json:"labels"`
+	Ephemeral bool     `json:"ephemeral"`
+}
+
+// registerRunnerResponse is the response of the Register RPC. Protobuf's JSON mapping encodes
+// 64-bit integers as strings, which json.Number accepts as well.
+type registerRunnerResponse struct {
+	Runner struct {
+		ID        json.Number `json:"id"`
+		UUID      string      `json:"uuid"`
+		Token     string      `json:"token"`
+		Name      string      `json:"name"`
+		Labels    []string    `json:"labels"`
+		Ephemeral bool        `json:"ephemeral"`
+	} `json:"runner"`
+}
+
+// RegisterRunner implements the Client interface
+func (c *HTTPClient) RegisterRunner(ctx context.Context, giteaURL, registrationToken, name string, labels []string, ephemeral bool) (*RegisteredRunner, error) {
+	payload, err := json.Marshal(registerRunnerRequest{
+		Name:      name,
+		Token:     registrationToken,
+		Version:   runnerVersion,
+		Labels:    labels,
+		Ephemeral: ephemeral,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSuffix(giteaURL, "/") + registerRunnerPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	// The registration token authenticates the request; it is not an API token
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Connect-Protocol-Version", "1")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	log.FromContext(ctx).WithName("gitea").V(requestLogLevel).Info("Gitea responded", "method", http.MethodPost, "url", endpoint, "status", resp.StatusCode)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, c.handleResponseError(resp, body, "register runner")
+	}
+
+	var registered registerRunnerResponse
+	if err := json.Unmarshal(body, &registered); err != nil {
+		return nil, fmt.Errorf("failed to decode registered runner: %w", err)
+	}
+	id, err := registered.Runner.ID.Int64()
+	if err != nil || registered.Runner.UUID == "" || registered.Runner.Token == "" {
+		return nil, fmt.Errorf("gitea returned incomplete runner credentials")
+	}
+	return &RegisteredRunner{
+		ID:        id,
+		UUID:      registered.Runner.UUID,
+		Token:     registered.Runner.Token,
+		Name:      registered.Runner.Name,
+		Labels:    registered.Runner.Labels,
+		Ephemeral: registered.Runner.Ephemeral,
+	}, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClient_RegisterRunner(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		response      string
+		expectedError bool
+	}{
+		{
+			name:       "registered",
+			statusCode: http.StatusOK,
+			response:   `{"runner":{"id":"42","uuid":"abc","token":"secret","name":"group-1","labels":["linux"],"ephemeral":true}}`,
+		},
+		{name: "registration token rejected", statusCode: http.StatusUnauthorized, response: `{"code":"unauthenticated"}`, expectedError: true},
+		{name: "incomplete credentials", statusCode: http.StatusOK, response: `{"runner":{"id":"42"}}`, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != registerRunnerPath {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "" {
+					t.Error("The registration token must not be sent as an API token")
+				}
+				var request registerRunnerRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Errorf("Failed to decode request: %v", err)
+				}
+				if request.Token != "reg-token" || request.Name != "group-1" || !request.Ephemeral {
+					t.Errorf("Unexpected request %+v", request)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			runner, err := NewHTTPClient().RegisterRunner(context.Background(), server.URL, "reg-token", "group-1", []string{"linux"}, true)
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if runner.ID != 42 || runner.UUID != "abc" || runner.Token != "secret" || !runner.Ephemeral {
+				t.Errorf("Unexpected runner %+v", runner)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// listPageSize is the number of items requested per page of a listing
+const listPageSize = 50
+
+// RepositorySearchResponse represents the response of a repository search
+type RepositorySearchResponse struct {
+	Data []Repository `json:"data"`
+}
+
+// listUserRepos returns all repositories owned by user
+func (c *HTTPClient) listUserRepos(ctx context.Context, giteaURL, authToken, user string) ([]Repository, error) {
+	return c.fetchRepos(ctx, fmt.Sprintf("%s/api/v1/users/%s/repos", strings.TrimSuffix(giteaURL, "/"), user), authToken, "fetch user repos")
+}
+
+// listOrgRepos returns all repositories of org
+func (c *HTTPClient) listOrgRepos(ctx context.Context, giteaURL, authToken, org string) ([]Repository, error) {
+	return c.fetchRepos(ctx, fmt.Sprintf("%s/api/v1/orgs/%s/repos", strings.TrimSuffix(giteaURL, "/"), org), authToken, "fetch repos")
+}
+
+// searchRepos returns all repositories the token can see
+func (c *HTTPClient) searchRepos(ctx context.Context, giteaURL, authToken string) ([]Repository, error) {
+	return c.fetchRepos(ctx, strings.TrimSuffix(giteaURL, "/")+"/api/v1/repos/search", authToken, "fetch repos")
+}
+
+// fetchRepos pages through a repository listing until a page comes back short. Listings are
+// either arrays or, for repository searches, wrapped in a "data" field. Gitea versions that
+// don't report whether a repository's Actions unit is enabled leave HasActions unset.
+func (c *HTTPClient) fetchRepos(ctx context.Context, endpoint, authToken, operation string) ([]Repository, error) {
+	var all []Repository
+	for page := 1; ; page++ {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		q := u.Query()
+		q.Set("page", fmt.Sprintf("%d", page))
+		q.Set("limit", fmt.Sprintf("%d", listPageSize))
+		u.RawQuery = q.Encode()
+
+		body, err := c.doRequest(ctx, "GET", u.String(), authToken, operation)
+		if err != nil {
+			return nil, err
+		}
+		var repos []Repository
+		if err := json.Unmarshal(body, &repos); err != nil {
+			var search RepositorySearchResponse
+			if searchErr := json.Unmarshal(body, &search); searchErr != nil {
+				return nil, fmt.Errorf("failed to decode repositories: %w", err)
+			}
+			repos = search.Data
+		}
+		all = append(all, repos...)
+		if len(repos) < listPageSize {
+			return all, nil
+		}
+	}
+}
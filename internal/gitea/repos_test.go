@@ -0,0 +1,105 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestHTTPClient_ListOrgReposPages(t *testing.T) {
+	const repoCount = listPageSize + 8
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/orgs/myorg/repos" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "token test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("Sudo"); got != "alice" {
+			t.Errorf("Sudo = %q, want alice", got)
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var repos []map[string]any
+		for i := (page - 1) * limit; i < min(page*limit, repoCount); i++ {
+			repos = append(repos, map[string]any{"name": fmt.Sprintf("repo%d", i), "owner": map[string]any{"login": "myorg"}, "has_actions": i%2 == 0})
+		}
+		_ = json.NewEncoder(w).Encode(repos)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	repos, err := client.listOrgRepos(WithSudo(context.Background(), "alice"), server.URL, "test-token", "myorg")
+	if err != nil {
+		t.Fatalf("listOrgRepos() error = %v", err)
+	}
+	if len(repos) != repoCount {
+		t.Fatalf("listed %d repositories, want %d", len(repos), repoCount)
+	}
+	if len(pages) != 2 {
+		t.Errorf("requested pages %v, want 2", pages)
+	}
+	last := repos[repoCount-1]
+	if last.fullName() != fmt.Sprintf("myorg/repo%d", repoCount-1) || last.ActionsEnabled() {
+		t.Errorf("last repository = %+v", last)
+	}
+}
+
+func TestHTTPClient_RepoListingErrors(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{statusCode: http.StatusUnauthorized, want: ErrUnauthorized},
+		{statusCode: http.StatusForbidden, want: ErrForbidden},
+		{statusCode: http.StatusNotFound, want: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"message": "nope"}`))
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient()
+			if _, err := client.listUserRepos(context.Background(), server.URL, "test-token", "myuser"); !errors.Is(err, tt.want) {
+				t.Errorf("listUserRepos() error = %v, want %v", err, tt.want)
+			}
+			if _, err := client.GetVersion(context.Background(), server.URL, "test-token"); !errors.Is(err, tt.want) {
+				t.Errorf("GetVersion() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
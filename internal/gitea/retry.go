@@ -0,0 +1,139 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// defaultMaxRetries is how often a failed request is retried unless configured otherwise
+	defaultMaxRetries = 3
+	// retryBaseDelay is the backoff before the first retry; it doubles for every further one
+	retryBaseDelay = 500 * time.Millisecond
+	// retryMaxDelay caps the backoff between retries
+	retryMaxDelay = 10 * time.Second
+)
+
+// requestRetries counts the requests retried after a transient failure
+var requestRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "gitea_client_request_retries_total",
+	Help: "Gitea API requests retried after a network error or 5xx response.",
+}, []string{"host", "endpoint"})
+
+func init() {
+	metrics.Registry.MustRegister(requestRetries)
+}
+
+// doWithRetries sends a request, retrying network errors and 5xx responses with capped
+// exponential backoff and full jitter. Only requests that are safe to repeat are retried:
+// idempotent methods without a body, or with one that can be replayed. Each attempt gets the
+// client's full timeout. Every attempt waits for the limits of its host.
+func (c *HTTPClient) doWithRetries(req *http.Request) (*http.Response, error) {
+	client := c.httpClient.Load()
+	maxRetries := 0
+	if retryable(req) {
+		maxRetries = int(c.maxRetries.Load())
+	}
+
+	for attempt := 0; ; attempt++ {
+		release, err := c.limiters.acquire(req.Context(), req.URL.Host, HostLimitsFromContext(req.Context()))
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			release()
+		} else {
+			resp.Body = releasingBody{ReadCloser: resp.Body, release: release}
+		}
+		if attempt >= maxRetries || !transientFailure(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		delay := backoff(attempt)
+		log.FromContext(req.Context()).WithName("gitea").V(requestLogLevel).Info("Retrying Gitea request",
+			"method", req.Method, "url", req.URL.String(), "attempt", attempt+1, "delay", delay, "error", err)
+		requestRetries.WithLabelValues(req.URL.Host, endpointTemplate(req.URL.Path)).Inc()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable returns whether a request can be sent again without side effects
+func retryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	default:
+		return false
+	}
+}
+
+// transientFailure returns whether a request failed in a way a retry may fix
+func transientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns a random delay of up to retryBaseDelay doubled per attempt, capped at retryMaxDelay
+func backoff(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		delay = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+	return rand.N(delay) + 1
+}
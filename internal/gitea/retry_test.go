@@ -0,0 +1,98 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHTTPClient_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxRetries       int
+		failures         int32
+		failureStatus    int
+		expectedRequests int32
+		expectedError    bool
+	}{
+		{name: "recovers from a 503", maxRetries: 3, failures: 2, failureStatus: http.StatusServiceUnavailable, expectedRequests: 3},
+		{name: "gives up after the last retry", maxRetries: 2, failures: 5, failureStatus: http.StatusBadGateway, expectedRequests: 3, expectedError: true},
+		{name: "does not retry client errors", maxRetries: 3, failures: 5, failureStatus: http.StatusNotFound, expectedRequests: 1, expectedError: true},
+		{name: "retries disabled", maxRetries: -1, failures: 1, failureStatus: http.StatusInternalServerError, expectedRequests: 1, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(tt.failureStatus)
+					return
+				}
+				_, _ = w.Write([]byte(`{"version":"1.24.0"}`))
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient()
+			client.Configure(0, 0, tt.maxRetries)
+			_, err := client.GetVersion(context.Background(), server.URL, "admin-token")
+			if (err != nil) != tt.expectedError {
+				t.Errorf("GetVersion() error = %v, expectedError %v", err, tt.expectedError)
+			}
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("requests = %d, want %d", got, tt.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_RetryNotForPost(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient().RegisterRunner(context.Background(), server.URL, "registration-token", "runner", nil, true)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 40; attempt++ {
+		delay := backoff(attempt)
+		limit := min(retryBaseDelay<<min(attempt, 16), retryMaxDelay)
+		if delay <= 0 || delay > limit {
+			t.Errorf("backoff(%d) = %s, want within (0, %s]", attempt, delay, limit)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// basicAuthPrefix and bearerAuthPrefix mark auth credentials built by BasicAuth and BearerAuth.
+// Gitea tokens never contain spaces, so they can't be mistaken for these.
+const (
+	basicAuthPrefix  = "Basic "
+	bearerAuthPrefix = "Bearer "
+)
+
+// BasicAuth returns credentials authenticating as a Gitea user with a password, for installs
+// that don't hand out API tokens. They can be passed wherever the client takes an auth token.
+func BasicAuth(username, password string) string {
+	return basicAuthPrefix + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// sudoKey is the context key of the Gitea user requests impersonate
+type sudoKey struct{}
+
+// WithSudo returns a context whose Gitea API requests act on behalf of the given user, using
+// Gitea's sudo support. This requires a site admin auth token. An empty username disables it.
+func WithSudo(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, sudoKey{}, username)
+}
+
+// SudoFromContext returns the Gitea user requests made with ctx impersonate, or "" if none
+func SudoFromContext(ctx context.Context) string {
+	username, _ := ctx.Value(sudoKey{}).(string)
+	return username
+}
+
+// setRequestHeaders authenticates a Gitea API request, impersonating the context's sudo user if any
+func setRequestHeaders(ctx context.Context, req *http.Request, authToken string) {
+	if strings.HasPrefix(authToken, basicAuthPrefix) || strings.HasPrefix(authToken, bearerAuthPrefix) {
+		req.Header.Set("Authorization", authToken)
+	} else {
+		req.Header.Set("Authorization", "token "+authToken)
+	}
+	req.Header.Set("Accept", "application/json")
+	if username := SudoFromContext(ctx); username != "" {
+		req.Header.Set("Sudo", username)
+	}
+}
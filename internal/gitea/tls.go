@@ -0,0 +1,72 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+)
+
+// insecureSkipVerifyKey is the context key of whether requests skip TLS certificate verification
+type insecureSkipVerifyKey struct{}
+
+// WithInsecureSkipVerify returns a context whose Gitea requests skip verifying the server's TLS
+// certificate if skip is set. This leaves the auth token open to anyone able to intercept the
+// connection, so it's meant for instances with certificates that can't be verified, like
+// self-signed ones in test setups.
+func WithInsecureSkipVerify(ctx context.Context, skip bool) context.Context {
+	return context.WithValue(ctx, insecureSkipVerifyKey{}, skip)
+}
+
+// InsecureSkipVerifyFromContext returns whether requests made with ctx skip TLS verification
+func InsecureSkipVerifyFromContext(ctx context.Context) bool {
+	skip, _ := ctx.Value(insecureSkipVerifyKey{}).(bool)
+	return skip
+}
+
+// tlsTransport sends requests through next, or through insecure if their context skips TLS
+// verification. The two keep separate connection pools, so a connection made without
+// verification is never reused for a request that asked for it.
+type tlsTransport struct {
+	next     http.RoundTripper
+	insecure http.RoundTripper
+}
+
+// newTLSTransport returns a tlsTransport over transport and an unverified clone of it
+func newTLSTransport(transport *http.Transport) tlsTransport {
+	insecure := transport.Clone()
+	if insecure.TLSClientConfig == nil {
+		insecure.TLSClientConfig = &tls.Config{}
+	}
+	insecure.TLSClientConfig.InsecureSkipVerify = true
+	return tlsTransport{next: transport, insecure: insecure}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t tlsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if InsecureSkipVerifyFromContext(req.Context()) {
+		return t.insecure.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// userScanFullInterval is how often a user-scope poll fetches the jobs of every repository,
+	// catching jobs queued without the repository being updated, e.g. by schedules or re-runs
+	userScanFullInterval = 5 * time.Minute
+	// userRepoListTTL is how long a user's repository list is reused before it is listed again
+	userRepoListTTL = time.Minute
+	// userScanActivityWindow is how long after queued jobs were last found in a repository its
+	// jobs are fetched on every poll, catching the later jobs of multi-job workflow runs
+	userScanActivityWindow = 15 * time.Minute
+	// userScanClockSkew is how much earlier than the previous repository list a repository's
+	// update is still considered new, to tolerate clock differences between the operator and Gitea
+	userScanClockSkew = time.Minute
+)
+
+// userRepoList is a user's repositories as listed at listedAt. Repositories updated after since,
+// when the list before it was taken, count as changed until the list is taken again.
+type userRepoList struct {
+	repos    []Repository
+	listedAt time.Time
+	since    time.Time
+}
+
+// userScan is what the polls of a user's repositories found: when the last full scan started
+// and when each repository last had queued jobs
+type userScan struct {
+	lastFull    time.Time
+	activeRepos map[string]time.Time
+}
+
+// userScans remembers the repositories of each user and what the polls of them found, so a poll
+// only fetches the jobs of repositories that were updated or recently had queued jobs
+type userScans struct {
+	mu        sync.Mutex
+	repoLists map[string]userRepoList
+	scans     map[string]userScan
+}
+
+// repoList returns the user's repositories listed under key if they were listed less than
+// userRepoListTTL before now
+func (s *userScans) repoList(key string, now time.Time) (userRepoList, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list, ok := s.repoLists[key]
+	if !ok || now.Sub(list.listedAt) >= userRepoListTTL {
+		return userRepoList{}, false
+	}
+	return list, true
+}
+
+// storeRepoList remembers the user's repositories listed at now and returns them
+func (s *userScans) storeRepoList(key string, repos []Repository, now time.Time) userRepoList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.repoLists == nil {
+		s.repoLists = make(map[string]userRepoList)
+	}
+	// The first list has no earlier one; the first poll scans everything anyway
+	since := now
+	if previous, ok := s.repoLists[key]; ok {
+		since = previous.listedAt
+	}
+	list := userRepoList{repos: repos, listedAt: now, since: since}
+	s.repoLists[key] = list
+	return list
+}
+
+// filter returns the repositories of list whose jobs a poll starting at now fetches, and whether
+// it is a full scan
+func (s *userScans) filter(key string, list userRepoList, now time.Time) ([]Repository, bool) {
+	s.mu.Lock()
+	last, ok := s.scans[key]
+	s.mu.Unlock()
+	if !ok || now.Sub(last.lastFull) >= userScanFullInterval {
+		return list.repos, true
+	}
+
+	since := list.since.Add(-userScanClockSkew)
+	var changed []Repository
+	for _, repo := range list.repos {
+		active, found := last.activeRepos[repo.fullName()]
+		if repo.UpdatedAt.After(since) || (found && now.Sub(active) < userScanActivityWindow) {
+			changed = append(changed, repo)
+		}
+	}
+	return changed, false
+}
+
+// record remembers a completed poll that started at started and found queued jobs in activeRepos
+func (s *userScans) record(key string, started time.Time, full bool, activeRepos []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.scans == nil {
+		s.scans = make(map[string]userScan)
+	}
+	last := s.scans[key]
+	scan := userScan{lastFull: last.lastFull, activeRepos: make(map[string]time.Time)}
+	if full {
+		scan.lastFull = started
+	}
+	for repo, active := range last.activeRepos {
+		if started.Sub(active) < userScanActivityWindow {
+			scan.activeRepos[repo] = active
+		}
+	}
+	for _, repo := range activeRepos {
+		scan.activeRepos[repo] = started
+	}
+	s.scans[key] = scan
+}
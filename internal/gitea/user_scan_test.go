@@ -0,0 +1,144 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserScans(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := func(name string, updatedAt time.Time) Repository {
+		r := Repository{Name: name, UpdatedAt: updatedAt}
+		r.Owner.Login = "myuser"
+		return r
+	}
+	// The list before this one was taken at start
+	list := userRepoList{
+		repos: []Repository{
+			repo("quiet", start.Add(-time.Hour)),
+			repo("busy", start.Add(-time.Hour)),
+			repo("pushed", start.Add(30*time.Second)),
+			repo("skewed", start.Add(-30*time.Second)),
+			repo("earlier", start.Add(-time.Hour)),
+		},
+		listedAt: start.Add(time.Minute),
+		since:    start,
+	}
+
+	tests := []struct {
+		name     string
+		record   bool
+		now      time.Time
+		want     []string
+		wantFull bool
+	}{
+		{name: "first poll scans everything", now: start, want: []string{"quiet", "busy", "pushed", "skewed", "earlier"}, wantFull: true},
+		{name: "later polls fetch updated and recently active repositories", record: true, now: start.Add(time.Minute),
+			want: []string{"busy", "pushed", "skewed"}},
+		{name: "periodic full scan", record: true, now: start.Add(userScanFullInterval), want: []string{"quiet", "busy", "pushed", "skewed", "earlier"}, wantFull: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scans := &userScans{}
+			if tt.record {
+				scans.record("key", start.Add(-userScanActivityWindow), true, []string{"myuser/earlier"})
+				scans.record("key", start, true, []string{"myuser/busy"})
+			}
+			got, full := scans.filter("key", list, tt.now)
+			if full != tt.wantFull {
+				t.Errorf("full = %v, want %v", full, tt.wantFull)
+			}
+			var names []string
+			for _, r := range got {
+				names = append(names, r.Name)
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("fetched %v, want %v", names, tt.want)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Errorf("fetched %v, want %v", names, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestUserScans_KeepsRecentActivity(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	busy := Repository{Name: "busy", UpdatedAt: start.Add(-time.Hour)}
+	busy.Owner.Login = "myuser"
+	list := userRepoList{repos: []Repository{busy}, listedAt: start, since: start}
+
+	scans := &userScans{}
+	scans.record("key", start, true, []string{"myuser/busy"})
+	// The next poll finds no queued jobs, e.g. while the first job of a run is running
+	scans.record("key", start.Add(10*time.Second), false, nil)
+
+	if got, _ := scans.filter("key", list, start.Add(time.Minute)); len(got) != 1 {
+		t.Errorf("fetched %v, want the repository that had queued jobs within the activity window", got)
+	}
+	scans.record("key", start.Add(userScanActivityWindow), true, nil)
+	if got, _ := scans.filter("key", list, start.Add(userScanActivityWindow+time.Second)); len(got) != 0 {
+		t.Errorf("fetched %v after the activity window", got)
+	}
+}
+
+func TestUserScans_RepoList(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	scans := &userScans{}
+	if _, ok := scans.repoList("key", start); ok {
+		t.Fatal("repository list cached before it was listed")
+	}
+	first := scans.storeRepoList("key", nil, start)
+	if !first.since.Equal(start) {
+		t.Errorf("first list counts changes since %v, want %v", first.since, start)
+	}
+	if _, ok := scans.repoList("key", start.Add(userRepoListTTL-time.Second)); !ok {
+		t.Error("repository list not reused within its TTL")
+	}
+	if _, ok := scans.repoList("key", start.Add(userRepoListTTL)); ok {
+		t.Error("repository list reused after its TTL")
+	}
+	if second := scans.storeRepoList("key", nil, start.Add(userRepoListTTL)); !second.since.Equal(start) {
+		t.Errorf("second list counts changes since %v, want the first list's %v", second.since, start)
+	}
+}
+
+func TestUserScans_KeepsLastFullScan(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	scans := &userScans{}
+	scans.record("key", start, true, nil)
+	scans.record("key", start.Add(time.Minute), false, nil)
+
+	if _, full := scans.filter("key", userRepoList{}, start.Add(2*time.Minute)); full {
+		t.Error("full scan before the interval passed")
+	}
+	if _, full := scans.filter("key", userRepoList{}, start.Add(userScanFullInterval)); !full {
+		t.Error("no full scan after the interval, counting from the last full scan")
+	}
+}
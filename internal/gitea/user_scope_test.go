@@ -0,0 +1,158 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+func TestHTTPClient_UserScopeConcurrency(t *testing.T) {
+	const repoCount = 10
+	tests := []struct {
+		name        string
+		concurrency int
+		wantPeak    int32
+	}{
+		{name: "bounded", concurrency: 3, wantPeak: 3},
+		{name: "serial", concurrency: 1, wantPeak: 1},
+		{name: "default", wantPeak: defaultRepoConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v1/users/myuser/repos" {
+					var repos []map[string]any
+					for i := range repoCount {
+						repos = append(repos, map[string]any{"name": fmt.Sprintf("repo%d", i), "owner": map[string]any{"login": "myuser"}, "has_actions": true})
+					}
+					_ = json.NewEncoder(w).Encode(repos)
+					return
+				}
+
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					previous := peak.Load()
+					if current <= previous || peak.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+
+				var jobs []ActionWorkflowJob
+				if r.URL.Query().Get("status") == "queued" {
+					repo := strings.Split(r.URL.Path, "/")[5]
+					jobs = []ActionWorkflowJob{{ID: 1, Name: repo, Labels: []string{"ubuntu-latest"}}}
+				}
+				_ = json.NewEncoder(w).Encode(ActionWorkflowJobsResponse{Jobs: jobs, TotalCount: int64(len(jobs))})
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient()
+			client.SetRepoConcurrency(tt.concurrency)
+			stats, err := client.GetRunnerStats(context.Background(), server.URL, "test-token", v1alpha1.RunnerGroupScopeUser, "", "myuser", "", nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if peak.Load() != tt.wantPeak {
+				t.Errorf("peak concurrent job requests = %d, want %d", peak.Load(), tt.wantPeak)
+			}
+			if len(stats.QueuedJobs) != repoCount {
+				t.Fatalf("QueuedJobs = %d, want %d", len(stats.QueuedJobs), repoCount)
+			}
+			// Jobs stay in repository order
+			for i, job := range stats.QueuedJobs {
+				if want := fmt.Sprintf("repo%d", i); job.Name != want {
+					t.Errorf("QueuedJobs[%d] from %s, want %s", i, job.Name, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPClient_UserScopeSkipsActionsDisabled(t *testing.T) {
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/users/myuser/repos" {
+			_, _ = w.Write([]byte(`[
+				{"name": "enabled", "owner": {"login": "myuser"}, "has_actions": true},
+				{"name": "disabled", "owner": {"login": "myuser"}, "has_actions": false}
+			]`))
+			return
+		}
+		if r.URL.Query().Get("status") == "queued" {
+			fetched = append(fetched, strings.Split(r.URL.Path, "/")[5])
+		}
+		_ = json.NewEncoder(w).Encode(ActionWorkflowJobsResponse{})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	client.SetRepoConcurrency(1)
+	if _, err := client.GetRunnerStats(context.Background(), server.URL, "test-token", v1alpha1.RunnerGroupScopeUser, "", "myuser", "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"enabled"}; strings.Join(fetched, ",") != strings.Join(want, ",") {
+		t.Errorf("fetched jobs of %v, want %v", fetched, want)
+	}
+}
+
+func TestHTTPClient_UserScopeActionsUnreported(t *testing.T) {
+	var fetched []string
+	server := httptest.NewServer(versionHandler("1.19.4", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/users/myuser/repos" {
+			_, _ = w.Write([]byte(`[{"name": "unreported", "owner": {"login": "myuser"}}]`))
+			return
+		}
+		if r.URL.Query().Get("status") == "queued" {
+			fetched = append(fetched, strings.Split(r.URL.Path, "/")[5])
+		}
+		_ = json.NewEncoder(w).Encode(ActionWorkflowJobsResponse{})
+	})))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	if _, err := client.GetVersion(context.Background(), server.URL, "test-token"); err != nil {
+		t.Fatalf("GetVersion() error = %v", err)
+	}
+	if _, err := client.GetRunnerStats(context.Background(), server.URL, "test-token", v1alpha1.RunnerGroupScopeUser, "", "myuser", "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Gitea versions that don't report the Actions unit have their repositories polled
+	if want := []string{"unreported"}; strings.Join(fetched, ",") != strings.Join(want, ",") {
+		t.Errorf("fetched jobs of %v, want %v", fetched, want)
+	}
+}
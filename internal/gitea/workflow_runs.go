@@ -0,0 +1,66 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gitea
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// workflowRunCacheSize bounds the workflow runs kept
+	workflowRunCacheSize = 4096
+	// workflowRunCacheTTL is how long a fetched workflow run is reused
+	workflowRunCacheTTL = 10 * time.Minute
+)
+
+// workflowRunCache holds fetched workflow runs, keyed by their URL and the credentials they were
+// fetched with. A run's event, branch and commit never change, so every job of a run and every
+// poll while it is queued reuse one lookup.
+type workflowRunCache struct {
+	once sync.Once
+	runs *cache.LRUExpireCache
+}
+
+// get returns a copy of the cached run, or nil
+func (c *workflowRunCache) get(key string) *ActionWorkflowRun {
+	c.once.Do(c.init)
+	run, ok := c.runs.Get(key)
+	if !ok {
+		return nil
+	}
+	runCopy := run.(ActionWorkflowRun)
+	return &runCopy
+}
+
+// put caches a run
+func (c *workflowRunCache) put(key string, run ActionWorkflowRun) {
+	c.once.Do(c.init)
+	c.runs.Add(key, run, workflowRunCacheTTL)
+}
+
+func (c *workflowRunCache) init() {
+	c.runs = cache.NewLRUExpireCache(workflowRunCacheSize)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package kedascaler serves the KEDA external scaler protocol, reporting each RunnerGroup's
+// waiting jobs so KEDA can scale runner Deployments from the operator's Gitea polling.
+package kedascaler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	pb "github.com/bapung/gitea-runner-operator/internal/kedascaler/externalscaler"
+)
+
+const (
+	// metricName is the name the waiting job count is reported under
+	metricName = "gitea-queued-jobs"
+
+	// Scaler metadata keys a ScaledObject's trigger sets
+	runnerGroupKey      = "runnerGroup"
+	namespaceKey        = "namespace"
+	targetQueuedJobsKey = "targetQueuedJobs"
+
+	// defaultStreamInterval is how often StreamIsActive re-reads the RunnerGroup
+	defaultStreamInterval = 10 * time.Second
+)
+
+// Server serves the KEDA external scaler gRPC API. It implements manager.Runnable so it runs
+// alongside the controllers.
+type Server struct {
+	pb.UnimplementedExternalScalerServer
+
+	// Client reads RunnerGroups
+	Client client.Reader
+	// BindAddress is the address the scaler listens on, e.g. ":9090"
+	BindAddress string
+	// StreamInterval is how often StreamIsActive checks the RunnerGroup; defaults to 10s
+	StreamInterval time.Duration
+}
+
+// Start runs the gRPC server until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("kedascaler")
+
+	listener, err := net.Listen("tcp", s.BindAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.BindAddress, err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterExternalScalerServer(srv, s)
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("Serving KEDA external scaler", "address", s.BindAddress)
+		if err := srv.Serve(listener); err != nil {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		srv.GracefulStop()
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// NeedLeaderElection lets every replica serve the scaler; RunnerGroup status is the same everywhere
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// IsActive reports whether the RunnerGroup has waiting jobs
+func (s *Server) IsActive(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
+	waiting, err := s.waitingJobs(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsActiveResponse{Result: waiting > 0}, nil
+}
+
+// StreamIsActive pushes the RunnerGroup's activity to KEDA whenever it changes
+func (s *Server) StreamIsActive(ref *pb.ScaledObjectRef, stream pb.ExternalScaler_StreamIsActiveServer) error {
+	interval := s.StreamInterval
+	if interval <= 0 {
+		interval = defaultStreamInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *bool
+	for {
+		waiting, err := s.waitingJobs(stream.Context(), ref)
+		if err != nil {
+			return err
+		}
+		if active := waiting > 0; last == nil || *last != active {
+			if err := stream.Send(&pb.IsActiveResponse{Result: active}); err != nil {
+				return err
+			}
+			last = &active
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetMetricSpec tells KEDA how many queued jobs one replica of the scaled workload handles
+func (s *Server) GetMetricSpec(_ context.Context, ref *pb.ScaledObjectRef) (*pb.GetMetricSpecResponse, error) {
+	target, err := targetQueuedJobs(ref.GetScalerMetadata())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetMetricSpecResponse{
+		MetricSpecs: []*pb.MetricSpec{{MetricName: metricName, TargetSize: target}},
+	}, nil
+}
+
+// GetMetrics reports the RunnerGroup's waiting job count
+func (s *Server) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	waiting, err := s.waitingJobs(ctx, req.GetScaledObjectRef())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetMetricsResponse{
+		MetricValues: []*pb.MetricValue{{MetricName: req.GetMetricName(), MetricValue: int64(waiting)}},
+	}, nil
+}
+
+// waitingJobs reads the waiting job count of the RunnerGroup a ScaledObject's trigger names:
+// the queued jobs of its last poll that match its labels and that neither it nor a
+// higher-priority group spawned a runner for
+func (s *Server) waitingJobs(ctx context.Context, ref *pb.ScaledObjectRef) (int, error) {
+	key, err := runnerGroupKeyFor(ref)
+	if err != nil {
+		return 0, err
+	}
+	runnerGroup := &giteav1alpha1.RunnerGroup{}
+	if err := s.Client.Get(ctx, key, runnerGroup); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return 0, status.Errorf(codes.NotFound, "RunnerGroup %s not found", key)
+		}
+		return 0, status.Errorf(codes.Unavailable, "failed to get RunnerGroup %s: %v", key, err)
+	}
+	return runnerGroup.Status.WaitingJobs, nil
+}
+
+// runnerGroupKeyFor resolves the RunnerGroup named in the trigger metadata, defaulting to the
+// ScaledObject's namespace
+func runnerGroupKeyFor(ref *pb.ScaledObjectRef) (types.NamespacedName, error) {
+	metadata := ref.GetScalerMetadata()
+	name := metadata[runnerGroupKey]
+	if name == "" {
+		return types.NamespacedName{}, status.Errorf(codes.InvalidArgument, "trigger metadata must set %q", runnerGroupKey)
+	}
+	namespace := metadata[namespaceKey]
+	if namespace == "" {
+		namespace = ref.GetNamespace()
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// targetQueuedJobs parses the per-replica target, defaulting to one queued job per replica
+func targetQueuedJobs(metadata map[string]string) (int64, error) {
+	value, ok := metadata[targetQueuedJobsKey]
+	if !ok {
+		return 1, nil
+	}
+	target, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || target < 1 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be a positive integer, got %q", targetQueuedJobsKey, value)
+	}
+	return target, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package kedascaler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+	pb "github.com/bapung/gitea-runner-operator/internal/kedascaler/externalscaler"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := giteav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "linux", Namespace: "ci"},
+			// Two of the queued jobs already have runners of the group itself
+			Status: giteav1alpha1.RunnerGroupStatus{QueuedJobs: 6, WaitingJobs: 4},
+		},
+		&giteav1alpha1.RunnerGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "ci"},
+		},
+	).Build()
+	return &Server{Client: c}
+}
+
+func TestServer_GetMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        *pb.ScaledObjectRef
+		wantValue  int64
+		wantActive bool
+		wantCode   codes.Code
+	}{
+		{
+			name:       "queued jobs",
+			ref:        &pb.ScaledObjectRef{Namespace: "ci", ScalerMetadata: map[string]string{"runnerGroup": "linux"}},
+			wantValue:  4,
+			wantActive: true,
+		},
+		{
+			name: "no queued jobs",
+			ref:  &pb.ScaledObjectRef{Namespace: "ci", ScalerMetadata: map[string]string{"runnerGroup": "idle"}},
+		},
+		{
+			name: "namespace from metadata",
+			ref: &pb.ScaledObjectRef{Namespace: "apps", ScalerMetadata: map[string]string{
+				"runnerGroup": "linux", "namespace": "ci",
+			}},
+			wantValue:  4,
+			wantActive: true,
+		},
+		{
+			name:     "missing RunnerGroup",
+			ref:      &pb.ScaledObjectRef{Namespace: "ci", ScalerMetadata: map[string]string{"runnerGroup": "windows"}},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "no RunnerGroup in metadata",
+			ref:      &pb.ScaledObjectRef{Namespace: "ci"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	s := newTestServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, err := s.GetMetrics(context.Background(), &pb.GetMetricsRequest{ScaledObjectRef: tt.ref, MetricName: metricName})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetMetrics() error = %v, want code %v", err, tt.wantCode)
+			}
+			active, err := s.IsActive(context.Background(), tt.ref)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("IsActive() error = %v, want code %v", err, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			if got := metrics.MetricValues[0].MetricValue; got != tt.wantValue {
+				t.Errorf("GetMetrics() value = %d, want %d", got, tt.wantValue)
+			}
+			if active.Result != tt.wantActive {
+				t.Errorf("IsActive() = %v, want %v", active.Result, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestServer_GetMetricSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		metadata   map[string]string
+		wantTarget int64
+		wantCode   codes.Code
+	}{
+		{
+			name:       "default target",
+			metadata:   map[string]string{"runnerGroup": "linux"},
+			wantTarget: 1,
+		},
+		{
+			name:       "custom target",
+			metadata:   map[string]string{"runnerGroup": "linux", "targetQueuedJobs": "3"},
+			wantTarget: 3,
+		},
+		{
+			name:     "invalid target",
+			metadata: map[string]string{"runnerGroup": "linux", "targetQueuedJobs": "0"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	s := newTestServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := s.GetMetricSpec(context.Background(), &pb.ScaledObjectRef{Namespace: "ci", ScalerMetadata: tt.metadata})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetMetricSpec() error = %v, want code %v", err, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			if got := spec.MetricSpecs[0]; got.MetricName != metricName || got.TargetSize != tt.wantTarget {
+				t.Errorf("GetMetricSpec() = %s/%d, want %s/%d", got.MetricName, got.TargetSize, metricName, tt.wantTarget)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package metricspush
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// otlpMetricsPath is where OTLP/HTTP receivers take metrics
+const otlpMetricsPath = "/v1/metrics"
+
+// OTLPExporter periodically exports all gathered metrics to an OpenTelemetry collector over
+// OTLP/HTTP with protobuf encoding. It implements manager.Runnable.
+type OTLPExporter struct {
+	// Endpoint is the collector's OTLP/HTTP address, e.g. "http://otel-collector.monitoring:4318";
+	// metrics are posted to its /v1/metrics path
+	Endpoint string
+	// ServiceName is the service.name resource attribute the metrics are exported under
+	ServiceName string
+	// Instance is the service.instance.id resource attribute, usually the pod name
+	Instance string
+	// Interval is how often metrics are exported
+	Interval time.Duration
+	// Gatherer collects the metrics to export
+	Gatherer prometheus.Gatherer
+	// HTTPClient sends the exports; nil uses a client with a 10 second timeout
+	HTTPClient *http.Client
+
+	// started is when the exporter started, the start of its cumulative sums
+	started time.Time
+}
+
+// Start exports metrics every interval until the context is cancelled, then exports a last time
+// so the final values aren't lost
+func (e *OTLPExporter) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("metrics-otlp")
+	logger.Info("Exporting metrics over OTLP", "endpoint", e.Endpoint, "interval", e.Interval)
+	e.started = time.Now()
+
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			exportCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := e.export(exportCtx, time.Now()); err != nil {
+				logger.Error(err, "Failed to export metrics on shutdown")
+			}
+			return nil
+		case <-ticker.C:
+			if err := e.export(ctx, time.Now()); err != nil {
+				logger.Error(err, "Failed to export metrics")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica export its own metrics, told apart by instance
+func (e *OTLPExporter) NeedLeaderElection() bool {
+	return false
+}
+
+// export posts the gathered metrics to the collector
+func (e *OTLPExporter) export(ctx context.Context, now time.Time) error {
+	families, err := e.Gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+	body, err := proto.Marshal(e.request(families, now))
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(e.Endpoint, "/")+otlpMetricsPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	client := e.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector responded with status %d: %s", resp.StatusCode, message)
+	}
+	return nil
+}
+
+// request converts gathered Prometheus metric families into an OTLP export request. Counters
+// become cumulative monotonic sums, gauges and untyped metrics gauges, and histograms and
+// summaries keep their kind.
+func (e *OTLPExporter) request(families []*dto.MetricFamily, now time.Time) *collectormetrics.ExportMetricsServiceRequest {
+	start, timestamp := unixNano(e.started), unixNano(now)
+	metrics := make([]*metricsv1.Metric, 0, len(families))
+	for _, family := range families {
+		metric := &metricsv1.Metric{Name: family.GetName(), Description: family.GetHelp()}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := &metricsv1.Sum{
+				AggregationTemporality: metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}
+			for _, m := range family.GetMetric() {
+				sum.DataPoints = append(sum.DataPoints, numberDataPoint(m, m.GetCounter().GetValue(), start, timestamp))
+			}
+			metric.Data = &metricsv1.Metric_Sum{Sum: sum}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			gauge := &metricsv1.Gauge{}
+			for _, m := range family.GetMetric() {
+				value := m.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				gauge.DataPoints = append(gauge.DataPoints, numberDataPoint(m, value, 0, timestamp))
+			}
+			metric.Data = &metricsv1.Metric_Gauge{Gauge: gauge}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			histogram := &metricsv1.Histogram{
+				AggregationTemporality: metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}
+			for _, m := range family.GetMetric() {
+				histogram.DataPoints = append(histogram.DataPoints, histogramDataPoint(m, start, timestamp))
+			}
+			metric.Data = &metricsv1.Metric_Histogram{Histogram: histogram}
+		case dto.MetricType_SUMMARY:
+			summary := &metricsv1.Summary{}
+			for _, m := range family.GetMetric() {
+				summary.DataPoints = append(summary.DataPoints, summaryDataPoint(m, start, timestamp))
+			}
+			metric.Data = &metricsv1.Metric_Summary{Summary: summary}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	resource := &resourcev1.Resource{Attributes: []*commonv1.KeyValue{stringAttribute("service.name", e.ServiceName)}}
+	if e.Instance != "" {
+		resource.Attributes = append(resource.Attributes, stringAttribute("service.instance.id", e.Instance))
+	}
+	return &collectormetrics.ExportMetricsServiceRequest{ResourceMetrics: []*metricsv1.ResourceMetrics{{
+		Resource: resource,
+		ScopeMetrics: []*metricsv1.ScopeMetrics{{
+			Scope:   &commonv1.InstrumentationScope{Name: "github.com/bapung/gitea-runner-operator"},
+			Metrics: metrics,
+		}},
+	}}}
+}
+
+// numberDataPoint converts a counter, gauge or untyped sample
+func numberDataPoint(m *dto.Metric, value float64, start, timestamp uint64) *metricsv1.NumberDataPoint {
+	return &metricsv1.NumberDataPoint{
+		Attributes:        attributes(m),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      timestamp,
+		Value:             &metricsv1.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+// histogramDataPoint converts a histogram sample. Prometheus buckets count every observation up
+// to their bound, OTLP buckets only those above the previous bound; the +Inf bucket is implied.
+func histogramDataPoint(m *dto.Metric, start, timestamp uint64) *metricsv1.HistogramDataPoint {
+	h := m.GetHistogram()
+	sum := h.GetSampleSum()
+	point := &metricsv1.HistogramDataPoint{
+		Attributes:        attributes(m),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      timestamp,
+		Count:             h.GetSampleCount(),
+		Sum:               &sum,
+	}
+	var below uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-below)
+		below = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-below)
+	return point
+}
+
+// summaryDataPoint converts a summary sample
+func summaryDataPoint(m *dto.Metric, start, timestamp uint64) *metricsv1.SummaryDataPoint {
+	s := m.GetSummary()
+	point := &metricsv1.SummaryDataPoint{
+		Attributes:        attributes(m),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      timestamp,
+		Count:             s.GetSampleCount(),
+		Sum:               s.GetSampleSum(),
+	}
+	for _, q := range s.GetQuantile() {
+		point.QuantileValues = append(point.QuantileValues, &metricsv1.SummaryDataPoint_ValueAtQuantile{
+			Quantile: q.GetQuantile(), Value: q.GetValue(),
+		})
+	}
+	return point
+}
+
+// attributes converts a sample's labels
+func attributes(m *dto.Metric) []*commonv1.KeyValue {
+	attrs := make([]*commonv1.KeyValue, 0, len(m.GetLabel()))
+	for _, label := range m.GetLabel() {
+		attrs = append(attrs, stringAttribute(label.GetName(), label.GetValue()))
+	}
+	return attrs
+}
+
+// stringAttribute returns a string-valued OTLP attribute
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+// unixNano returns t as OTLP timestamps are given, 0 for the zero time
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package metricspush
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLPExporter_Start(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_queued_jobs", Help: "Test gauge."})
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_spawned_total", Help: "Test counter."}, []string{"runnergroup"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_wait_seconds", Help: "Test histogram.", Buckets: []float64{1, 10}})
+	registry.MustRegister(gauge, counter, histogram)
+	gauge.Set(3)
+	counter.WithLabelValues("linux").Add(2)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	histogram.Observe(50)
+
+	exported := make(chan *collectormetrics.ExportMetricsServiceRequest, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/metrics" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("unexpected export request: %s %s %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		req := &collectormetrics.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("failed to decode export request: %v", err)
+		}
+		exported <- req
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	e := &OTLPExporter{Endpoint: srv.URL + "/", ServiceName: "gitea-runner-operator", Instance: "pod-0", Interval: 10 * time.Millisecond, Gatherer: registry}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- e.Start(ctx) }()
+
+	var req *collectormetrics.ExportMetricsServiceRequest
+	select {
+	case req = <-exported:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no metrics exported")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+
+	resource := req.GetResourceMetrics()[0].GetResource().GetAttributes()
+	if len(resource) != 2 || resource[0].GetValue().GetStringValue() != "gitea-runner-operator" || resource[1].GetValue().GetStringValue() != "pod-0" {
+		t.Errorf("unexpected resource attributes: %v", resource)
+	}
+	metrics := make(map[string]*metricsv1.Metric)
+	for _, m := range req.GetResourceMetrics()[0].GetScopeMetrics()[0].GetMetrics() {
+		metrics[m.GetName()] = m
+	}
+
+	if got := metrics["test_queued_jobs"].GetGauge().GetDataPoints(); len(got) != 1 || got[0].GetAsDouble() != 3 {
+		t.Errorf("unexpected gauge data points: %v", got)
+	}
+
+	sum := metrics["test_spawned_total"].GetSum()
+	if !sum.GetIsMonotonic() || sum.GetAggregationTemporality() != metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE {
+		t.Errorf("counter should be a cumulative monotonic sum: %v", sum)
+	}
+	if got := sum.GetDataPoints(); len(got) != 1 || got[0].GetAsDouble() != 2 ||
+		got[0].GetAttributes()[0].GetKey() != "runnergroup" || got[0].GetAttributes()[0].GetValue().GetStringValue() != "linux" {
+		t.Errorf("unexpected counter data points: %v", got)
+	}
+
+	points := metrics["test_wait_seconds"].GetHistogram().GetDataPoints()
+	if len(points) != 1 {
+		t.Fatalf("unexpected histogram data points: %v", points)
+	}
+	if p := points[0]; p.GetCount() != 3 || p.GetSum() != 55.5 ||
+		!slices.Equal(p.GetExplicitBounds(), []float64{1, 10}) || !slices.Equal(p.GetBucketCounts(), []uint64{1, 1, 1}) {
+		t.Errorf("unexpected histogram data point: %v", p)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package metricspush pushes the operator's metrics to a Prometheus Pushgateway or exports them
+// to an OpenTelemetry collector over OTLP, for clusters where nothing scrapes the metrics
+// endpoint.
+package metricspush
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Pusher periodically pushes all gathered metrics. It implements manager.Runnable.
+type Pusher struct {
+	// URL is the Pushgateway address, e.g. "http://pushgateway.monitoring:9091"
+	URL string
+	// Job is the job name the metrics are grouped under
+	Job string
+	// Instance distinguishes the metrics of operator replicas, usually the pod name
+	Instance string
+	// Interval is how often metrics are pushed
+	Interval time.Duration
+	// Gatherer collects the metrics to push
+	Gatherer prometheus.Gatherer
+}
+
+// Start pushes metrics every interval until the context is cancelled, then deletes this
+// instance's group from the Pushgateway so a stopped replica's metrics don't linger as if current
+func (p *Pusher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("metrics-push")
+	logger.Info("Pushing metrics", "url", p.URL, "job", p.Job, "interval", p.Interval)
+
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := p.pusher().Client(&http.Client{Timeout: 5 * time.Second}).Delete(); err != nil {
+				logger.Error(err, "Failed to delete pushed metrics on shutdown")
+			}
+			return nil
+		case <-ticker.C:
+			if err := p.push(ctx); err != nil {
+				logger.Error(err, "Failed to push metrics")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica push its own metrics, grouped by instance
+func (p *Pusher) NeedLeaderElection() bool {
+	return false
+}
+
+// push replaces the metrics of this job and instance on the Pushgateway
+func (p *Pusher) push(ctx context.Context) error {
+	return p.pusher().Gatherer(p.Gatherer).PushContext(ctx)
+}
+
+// pusher returns a Pushgateway client for this job and instance's group
+func (p *Pusher) pusher() *push.Pusher {
+	pusher := push.New(p.URL, p.Job)
+	if p.Instance != "" {
+		pusher = pusher.Grouping("instance", p.Instance)
+	}
+	return pusher
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package metricspush
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPusher_Start(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_queued_jobs", Help: "Test gauge."})
+	registry.MustRegister(gauge)
+	gauge.Set(3)
+
+	pushed := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		pushed <- r.Method + " " + r.URL.Path + " " + string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := &Pusher{URL: srv.URL, Job: "gitea-runner-operator", Instance: "pod-0", Interval: 10 * time.Millisecond, Gatherer: registry}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Start(ctx) }()
+
+	select {
+	case got := <-pushed:
+		if !strings.HasPrefix(got, "PUT /metrics/job/gitea-runner-operator/instance/pod-0 ") {
+			t.Errorf("unexpected push request: %q", got)
+		}
+		if !strings.Contains(got, "test_queued_jobs") {
+			t.Errorf("pushed metrics missing test_queued_jobs: %q", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no metrics pushed")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+	var last string
+	for len(pushed) > 0 {
+		last = <-pushed
+	}
+	if last != "DELETE /metrics/job/gitea-runner-operator/instance/pod-0 " {
+		t.Errorf("expected the group to be deleted on shutdown, last request was %q", last)
+	}
+}
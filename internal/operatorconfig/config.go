@@ -0,0 +1,218 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package operatorconfig loads operator-wide defaults from a config file, typically a mounted
+// ConfigMap, and reloads them when the file changes without restarting the manager.
+package operatorconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultReloadInterval is how often the config file is checked for changes by default
+const defaultReloadInterval = 10 * time.Second
+
+// OrphanedJobPolicy is what happens to runner Jobs that lost their Runner or RunnerGroup
+type OrphanedJobPolicy string
+
+const (
+	// OrphanedJobPolicyAdopt gives orphaned Jobs a Runner of their RunnerGroup, or deletes them if
+	// the group doesn't exist in their namespace
+	OrphanedJobPolicyAdopt OrphanedJobPolicy = "Adopt"
+	// OrphanedJobPolicyDelete deletes orphaned Jobs
+	OrphanedJobPolicyDelete OrphanedJobPolicy = "Delete"
+	// OrphanedJobPolicyKeep only logs orphaned Jobs
+	OrphanedJobPolicyKeep OrphanedJobPolicy = "Keep"
+)
+
+// Config holds the operator-wide defaults. Zero values leave the built-in defaults in place.
+type Config struct {
+	// PollInterval is how often RunnerGroups poll Gitea
+	PollInterval metav1.Duration `json:"pollInterval,omitempty"`
+	// DefaultRunnerImage is the runner image used when --runner-images isn't set
+	DefaultRunnerImage string `json:"defaultRunnerImage,omitempty"`
+	// MaxActiveRunners caps the active runners of all RunnerGroups together
+	MaxActiveRunners int `json:"maxActiveRunners,omitempty"`
+	// StaleRunnerAge is how long a runner the operator registered may stay offline in Gitea
+	// before it is deleted there; zero keeps offline runners
+	StaleRunnerAge metav1.Duration `json:"staleRunnerAge,omitempty"`
+	// OrphanedJobPolicy is what happens to runner Jobs whose Runner or RunnerGroup is gone,
+	// Adopt if empty
+	OrphanedJobPolicy OrphanedJobPolicy `json:"orphanedJobPolicy,omitempty"`
+	// Gitea tunes the HTTP client used for Gitea API requests
+	Gitea HTTPClientConfig `json:"gitea,omitempty"`
+}
+
+// HTTPClientConfig tunes an HTTP client
+type HTTPClientConfig struct {
+	// Timeout limits each request, including reading the response body
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// MaxIdleConnsPerHost is how many idle keep-alive connections are kept per host
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty"`
+	// MaxRetries is how often a request failing with a network error or 5xx response is
+	// retried; -1 disables retries
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// RepoConcurrency is how many repositories' jobs a user-scope poll fetches at once
+	RepoConcurrency int `json:"repoConcurrency,omitempty"`
+}
+
+// Parse parses and validates a YAML or JSON config. Unknown fields are rejected so typos don't
+// go unnoticed.
+func Parse(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse operator config: %w", err)
+	}
+	if config.PollInterval.Duration != 0 && config.PollInterval.Duration < time.Second {
+		return nil, fmt.Errorf("pollInterval must be at least 1s, got %s", config.PollInterval.Duration)
+	}
+	if config.MaxActiveRunners < 0 {
+		return nil, fmt.Errorf("maxActiveRunners must not be negative, got %d", config.MaxActiveRunners)
+	}
+	if config.StaleRunnerAge.Duration != 0 && config.StaleRunnerAge.Duration < time.Minute {
+		return nil, fmt.Errorf("staleRunnerAge must be at least 1m, got %s", config.StaleRunnerAge.Duration)
+	}
+	switch config.OrphanedJobPolicy {
+	case "", OrphanedJobPolicyAdopt, OrphanedJobPolicyDelete, OrphanedJobPolicyKeep:
+	default:
+		return nil, fmt.Errorf("orphanedJobPolicy must be Adopt, Delete or Keep, got %q", config.OrphanedJobPolicy)
+	}
+	if config.Gitea.Timeout.Duration < 0 {
+		return nil, fmt.Errorf("gitea.timeout must not be negative, got %s", config.Gitea.Timeout.Duration)
+	}
+	if config.Gitea.MaxIdleConnsPerHost < 0 {
+		return nil, fmt.Errorf("gitea.maxIdleConnsPerHost must not be negative, got %d", config.Gitea.MaxIdleConnsPerHost)
+	}
+	if config.Gitea.MaxRetries < -1 {
+		return nil, fmt.Errorf("gitea.maxRetries must be -1 or more, got %d", config.Gitea.MaxRetries)
+	}
+	if config.Gitea.RepoConcurrency < 0 {
+		return nil, fmt.Errorf("gitea.repoConcurrency must not be negative, got %d", config.Gitea.RepoConcurrency)
+	}
+	return config, nil
+}
+
+// Watcher holds the config loaded from a file and reloads it when the file changes. It
+// implements manager.Runnable. A nil Watcher serves an empty config.
+type Watcher struct {
+	// Path is the config file
+	Path string
+	// Interval is how often the file is checked for changes, 10s if zero
+	Interval time.Duration
+
+	current atomic.Pointer[Config]
+
+	mu        sync.Mutex
+	data      []byte
+	listeners []func(*Config)
+}
+
+// Load reads the config file. Call it before starting the manager so a broken config fails startup.
+func (w *Watcher) Load() error {
+	_, err := w.reload()
+	return err
+}
+
+// Config returns the current config
+func (w *Watcher) Config() *Config {
+	if w == nil {
+		return &Config{}
+	}
+	if config := w.current.Load(); config != nil {
+		return config
+	}
+	return &Config{}
+}
+
+// OnChange registers a function called with every config loaded after it was registered
+func (w *Watcher) OnChange(listener func(*Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, listener)
+}
+
+// Start checks the file for changes until the context is cancelled. A config that fails to load
+// is logged and the previous one kept.
+func (w *Watcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("operator-config")
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := w.reload()
+			if err != nil {
+				logger.Error(err, "Failed to reload operator config, keeping the previous one", "path", w.Path)
+				continue
+			}
+			if changed {
+				logger.Info("Reloaded operator config", "path", w.Path)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica follow the config, not just the leader
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// reload loads the file if its content changed and notifies the listeners
+func (w *Watcher) reload() (bool, error) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read operator config: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.current.Load() != nil && bytes.Equal(data, w.data) {
+		return false, nil
+	}
+	config, err := Parse(data)
+	if err != nil {
+		return false, err
+	}
+	w.data = data
+	w.current.Store(config)
+	for _, listener := range w.listeners {
+		listener(config)
+	}
+	return true, nil
+}
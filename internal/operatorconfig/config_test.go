@@ -0,0 +1,129 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Config
+		wantErr bool
+	}{
+		{name: "empty", data: ""},
+		{
+			name: "all fields",
+			data: "pollInterval: 30s\ndefaultRunnerImage: registry.example.com/act_runner:v1\nmaxActiveRunners: 50\nstaleRunnerAge: 1h\norphanedJobPolicy: Delete\ngitea:\n  timeout: 5s\n  maxIdleConnsPerHost: 20\n  maxRetries: 5\n  repoConcurrency: 16\n",
+			want: Config{DefaultRunnerImage: "registry.example.com/act_runner:v1", MaxActiveRunners: 50},
+		},
+		{name: "unknown field", data: "pollIntervall: 30s\n", wantErr: true},
+		{name: "poll interval too short", data: "pollInterval: 100ms\n", wantErr: true},
+		{name: "negative max runners", data: "maxActiveRunners: -1\n", wantErr: true},
+		{name: "retries disabled", data: "gitea:\n  maxRetries: -1\n"},
+		{name: "invalid max retries", data: "gitea:\n  maxRetries: -2\n", wantErr: true},
+		{name: "negative repo concurrency", data: "gitea:\n  repoConcurrency: -1\n", wantErr: true},
+		{name: "stale runner age too short", data: "staleRunnerAge: 10s\n", wantErr: true},
+		{name: "unknown orphaned job policy", data: "orphanedJobPolicy: Ignore\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.DefaultRunnerImage != tt.want.DefaultRunnerImage || got.MaxActiveRunners != tt.want.MaxActiveRunners {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	config, err := Parse([]byte("pollInterval: 30s\ngitea:\n  timeout: 5s\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.PollInterval.Duration != 30*time.Second || config.Gitea.Timeout.Duration != 5*time.Second {
+		t.Errorf("durations not parsed: %+v", config)
+	}
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("maxActiveRunners: 10\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &Watcher{Path: path, Interval: 10 * time.Millisecond}
+	if err := w.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := w.Config().MaxActiveRunners; got != 10 {
+		t.Fatalf("MaxActiveRunners = %d, want 10", got)
+	}
+
+	changes := make(chan *Config, 10)
+	w.OnChange(func(config *Config) { changes <- config })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx) }()
+
+	// A broken config keeps the previous one
+	if err := os.WriteFile(path, []byte("maxActiveRunners: -5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := w.Config().MaxActiveRunners; got != 10 {
+		t.Fatalf("MaxActiveRunners = %d after broken config, want 10", got)
+	}
+
+	if err := os.WriteFile(path, []byte("maxActiveRunners: 20\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case config := <-changes:
+		if config.MaxActiveRunners != 20 {
+			t.Errorf("reloaded MaxActiveRunners = %d, want 20", config.MaxActiveRunners)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+	if got := w.Config().MaxActiveRunners; got != 20 {
+		t.Errorf("MaxActiveRunners = %d, want 20", got)
+	}
+}
+
+func TestWatcher_Nil(t *testing.T) {
+	var w *Watcher
+	if got := w.Config(); got == nil || got.MaxActiveRunners != 0 {
+		t.Errorf("nil Watcher Config() = %+v, want empty config", got)
+	}
+}
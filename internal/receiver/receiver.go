@@ -0,0 +1,309 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package receiver accepts Gitea webhook deliveries so RunnerGroups poll for queued jobs right
+// away instead of waiting for the next poll interval. One receiver serves any number of
+// GiteaInstances.
+package receiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+const (
+	// maxPayloadBytes limits the size of accepted deliveries
+	maxPayloadBytes = 1 << 20
+	// deliveryTTL is how long accepted deliveries are remembered to reject replays
+	deliveryTTL = time.Hour
+	// maxTrackedDeliveries caps the remembered deliveries; the oldest are forgotten first
+	maxTrackedDeliveries = 10000
+)
+
+// triggeringActions are the workflow_job actions that change what RunnerGroups should run: a job
+// was queued, or a finished job freed a runner slot
+var triggeringActions = map[string]bool{"queued": true, "completed": true}
+
+// WorkflowJobEvent is a verified workflow_job delivery
+type WorkflowJobEvent struct {
+	// GiteaURL is the URL of the GiteaInstance the delivery came from
+	GiteaURL string
+	// Action is the job's transition, e.g. "queued" or "completed"
+	Action string
+	// Repository is the "owner/name" of the job's repository, empty if the payload doesn't name one
+	Repository string
+	// Delivery identifies the delivery by its instance and signed payload, the same whichever
+	// replica received it
+	Delivery string
+}
+
+// workflowJobPayload holds the fields the receiver uses from a workflow_job payload
+type workflowJobPayload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// errUnverified is returned when no GiteaInstance's secret matches a delivery's signature
+var errUnverified = errors.New("delivery signature does not match any GiteaInstance")
+
+// Server receives Gitea webhook deliveries. It implements manager.Runnable.
+type Server struct {
+	// Client reads GiteaInstances, and their webhook secrets unless SecretReader is set
+	Client client.Reader
+	// SecretReader reads the Secrets webhook secrets are stored in. They may live outside the
+	// namespaces a cache watches, so this is usually an uncached reader.
+	SecretReader client.Reader
+	// BindAddress is the address the receiver listens on, e.g. ":8083"
+	BindAddress string
+	// Trigger is called for verified workflow_job deliveries that queued or completed a job
+	Trigger func(ctx context.Context, event WorkflowJobEvent)
+
+	mu sync.Mutex
+	// deliveries maps the instance and payload hash of accepted deliveries to when they were accepted
+	deliveries map[string]time.Time
+}
+
+// Start runs the HTTP server until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("webhook-receiver")
+
+	srv := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("Receiving Gitea webhooks", "address", s.BindAddress)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// NeedLeaderElection lets every replica accept deliveries; only the leader acts on them
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the receiver's HTTP handler. Deliveries to /hooks/{instance} are verified with
+// the named GiteaInstance's secret; deliveries to /hooks are matched to the instance whose secret
+// verifies them.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /hooks/{instance}", s.serveHook)
+	mux.HandleFunc("POST /hooks", s.serveHook)
+	return mux
+}
+
+func (s *Server) serveHook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx).WithName("webhook-receiver")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	deliveryID := r.Header.Get("X-Gitea-Delivery")
+	if deliveryID == "" {
+		http.Error(w, "missing delivery ID", http.StatusBadRequest)
+		return
+	}
+
+	instance, err := s.resolveInstance(ctx, r.PathValue("instance"), body, r.Header.Get("X-Gitea-Signature"))
+	if err != nil {
+		logger.V(1).Info("Rejected webhook delivery", "instance", r.PathValue("instance"), "reason", err.Error())
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// The signature doesn't cover a timestamp, so a captured delivery stays valid; accept each once.
+	// Neither does it cover the delivery ID, so deliveries are told apart by the signed payload.
+	delivery := deliveryKey(instance.Name, body)
+	if !s.firstDelivery(delivery, time.Now()) {
+		logger.Info("Ignored replayed webhook delivery", "giteaInstance", instance.Name, "delivery", deliveryID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	event := r.Header.Get("X-Gitea-Event")
+	logger.V(1).Info("Received webhook delivery", "giteaInstance", instance.Name, "event", event, "delivery", deliveryID)
+	if event != "workflow_job" || s.Trigger == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	payload := &workflowJobPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		http.Error(w, "invalid workflow_job payload", http.StatusBadRequest)
+		return
+	}
+	if triggeringActions[payload.Action] {
+		s.Trigger(ctx, WorkflowJobEvent{
+			GiteaURL:   instance.Spec.URL,
+			Action:     payload.Action,
+			Repository: payload.Repository.FullName,
+			Delivery:   delivery,
+		})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deliveryKey identifies a delivery to an instance by the SHA-256 of its payload
+func deliveryKey(instance string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return instance + "/" + hex.EncodeToString(sum[:])
+}
+
+// firstDelivery records the delivery and reports whether it wasn't seen within deliveryTTL
+func (s *Server) firstDelivery(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deliveries == nil {
+		s.deliveries = make(map[string]time.Time)
+	}
+	if seen, ok := s.deliveries[key]; ok && now.Sub(seen) < deliveryTTL {
+		return false
+	}
+	if len(s.deliveries) >= maxTrackedDeliveries {
+		s.forgetDeliveries(now)
+	}
+	s.deliveries[key] = now
+	return true
+}
+
+// forgetDeliveries drops expired deliveries, and the oldest ones if that doesn't make room
+func (s *Server) forgetDeliveries(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, seen := range s.deliveries {
+		if now.Sub(seen) >= deliveryTTL {
+			delete(s.deliveries, key)
+			continue
+		}
+		if oldestKey == "" || seen.Before(oldest) {
+			oldestKey, oldest = key, seen
+		}
+	}
+	if len(s.deliveries) >= maxTrackedDeliveries {
+		delete(s.deliveries, oldestKey)
+	}
+}
+
+// resolveInstance returns the GiteaInstance whose webhook secret verifies the delivery: the named
+// one if a name is given, otherwise the first one that matches
+func (s *Server) resolveInstance(ctx context.Context, name string, body []byte, signature string) (*giteav1alpha1.GiteaInstance, error) {
+	if name != "" {
+		instance := &giteav1alpha1.GiteaInstance{}
+		if err := s.Client.Get(ctx, types.NamespacedName{Name: name}, instance); err != nil {
+			return nil, err
+		}
+		if !s.verify(ctx, instance, body, signature) {
+			return nil, errUnverified
+		}
+		return instance, nil
+	}
+
+	instanceList := &giteav1alpha1.GiteaInstanceList{}
+	if err := s.Client.List(ctx, instanceList); err != nil {
+		return nil, err
+	}
+	for i := range instanceList.Items {
+		if s.verify(ctx, &instanceList.Items[i], body, signature) {
+			return &instanceList.Items[i], nil
+		}
+	}
+	return nil, errUnverified
+}
+
+// verify reports whether the signature is the HMAC-SHA256 of the body with the instance's webhook secret
+func (s *Server) verify(ctx context.Context, instance *giteav1alpha1.GiteaInstance, body []byte, signature string) bool {
+	secret, err := s.webhookSecret(ctx, instance)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to read webhook secret", "giteaInstance", instance.Name)
+		return false
+	}
+	if secret == nil {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// webhookSecret returns the instance's webhook secret, or nil if it has none configured
+func (s *Server) webhookSecret(ctx context.Context, instance *giteav1alpha1.GiteaInstance) ([]byte, error) {
+	ref := instance.Spec.WebhookSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	reader := s.SecretReader
+	if reader == nil {
+		reader = s.Client
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok || len(value) == 0 {
+		return nil, fmt.Errorf("key %s not found in secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+	}
+	return value, nil
+}
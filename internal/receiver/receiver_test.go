@@ -0,0 +1,285 @@
+/*
+Copyright 2026 bapung.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package receiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	giteav1alpha1 "github.com/bapung/gitea-runner-operator/api/v1alpha1"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newTestServer(t *testing.T, triggered *[]string) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := giteav1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	instance := func(name, url string) *giteav1alpha1.GiteaInstance {
+		return &giteav1alpha1.GiteaInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: giteav1alpha1.GiteaInstanceSpec{
+				URL:              url,
+				WebhookSecretRef: &giteav1alpha1.SecretKeyReference{Namespace: "ops", Name: name + "-webhook", Key: "secret"},
+			},
+		}
+	}
+	secret := func(name, value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ops", Name: name},
+			Data:       map[string][]byte{"secret": []byte(value)},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		instance("internal", "https://git.internal.example.com"),
+		secret("internal-webhook", "internal-secret"),
+		instance("public", "https://gitea.example.com"),
+		secret("public-webhook", "public-secret"),
+		&giteav1alpha1.GiteaInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "unsigned"},
+			Spec:       giteav1alpha1.GiteaInstanceSpec{URL: "https://unsigned.example.com"},
+		},
+	).Build()
+	return &Server{Client: c, Trigger: func(_ context.Context, event WorkflowJobEvent) {
+		*triggered = append(*triggered, event.GiteaURL+" "+event.Repository)
+	}}
+}
+
+func TestServer_Handler(t *testing.T) {
+	const body = `{"action":"queued","workflow_job":{"id":1},"repository":{"full_name":"myorg/api"}}`
+	const inProgress = `{"action":"in_progress","workflow_job":{"id":1},"repository":{"full_name":"myorg/api"}}`
+
+	tests := []struct {
+		name          string
+		path          string
+		event         string
+		body          string
+		signature     string
+		wantStatus    int
+		wantTriggered []string
+	}{
+		{
+			name:          "routed by path",
+			path:          "/hooks/public",
+			event:         "workflow_job",
+			signature:     sign("public-secret", body),
+			wantStatus:    http.StatusNoContent,
+			wantTriggered: []string{"https://gitea.example.com myorg/api"},
+		},
+		{
+			name:       "routed by path with another instance's secret",
+			path:       "/hooks/public",
+			event:      "workflow_job",
+			signature:  sign("internal-secret", body),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "resolved by secret",
+			path:          "/hooks",
+			event:         "workflow_job",
+			signature:     sign("internal-secret", body),
+			wantStatus:    http.StatusNoContent,
+			wantTriggered: []string{"https://git.internal.example.com myorg/api"},
+		},
+		{
+			name:       "unknown secret",
+			path:       "/hooks",
+			event:      "workflow_job",
+			signature:  sign("other-secret", body),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown instance",
+			path:       "/hooks/missing",
+			event:      "workflow_job",
+			signature:  sign("public-secret", body),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "instance without secret",
+			path:       "/hooks/unsigned",
+			event:      "workflow_job",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "other event",
+			path:       "/hooks/public",
+			event:      "push",
+			signature:  sign("public-secret", body),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "job started",
+			path:       "/hooks/public",
+			event:      "workflow_job",
+			body:       inProgress,
+			signature:  sign("public-secret", inProgress),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "malformed payload",
+			path:       "/hooks/public",
+			event:      "workflow_job",
+			body:       "{",
+			signature:  sign("public-secret", "{"),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var triggered []string
+			s := newTestServer(t, &triggered)
+
+			payload := tt.body
+			if payload == "" {
+				payload = body
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(payload))
+			req.Header.Set("X-Gitea-Event", tt.event)
+			req.Header.Set("X-Gitea-Delivery", "b3c1c7e0-6f4e-4a1e-9a57-0d2c3c1d2f10")
+			req.Header.Set("X-Gitea-Signature", tt.signature)
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if strings.Join(triggered, ",") != strings.Join(tt.wantTriggered, ",") {
+				t.Errorf("triggered = %v, want %v", triggered, tt.wantTriggered)
+			}
+		})
+	}
+}
+
+func TestServer_Replay(t *testing.T) {
+	const body = `{"action":"queued","workflow_job":{"id":1},"repository":{"full_name":"myorg/api"}}`
+	const other = `{"action":"queued","workflow_job":{"id":2},"repository":{"full_name":"myorg/api"}}`
+	var triggered []string
+	s := newTestServer(t, &triggered)
+
+	deliver := func(deliveryID, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/hooks/public", strings.NewReader(body))
+		req.Header.Set("X-Gitea-Event", "workflow_job")
+		req.Header.Set("X-Gitea-Signature", sign("public-secret", body))
+		if deliveryID != "" {
+			req.Header.Set("X-Gitea-Delivery", deliveryID)
+		}
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := deliver("delivery-1", body); code != http.StatusNoContent {
+		t.Fatalf("first delivery status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := deliver("delivery-1", body); code != http.StatusNoContent {
+		t.Fatalf("replayed delivery status = %d, want %d", code, http.StatusNoContent)
+	}
+	// The delivery ID isn't signed; a replay under a new one is still a replay
+	if code := deliver("delivery-1-resent", body); code != http.StatusNoContent {
+		t.Fatalf("replayed delivery with new ID status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := deliver("", other); code != http.StatusBadRequest {
+		t.Fatalf("delivery without ID status = %d, want %d", code, http.StatusBadRequest)
+	}
+	if code := deliver("delivery-2", other); code != http.StatusNoContent {
+		t.Fatalf("second delivery status = %d, want %d", code, http.StatusNoContent)
+	}
+	if len(triggered) != 2 {
+		t.Errorf("triggered %d times, want 2: %v", len(triggered), triggered)
+	}
+}
+
+func TestServer_SecretReader(t *testing.T) {
+	const body = `{"action":"queued","workflow_job":{"id":1},"repository":{"full_name":"myorg/api"}}`
+	var triggered []string
+	s := newTestServer(t, &triggered)
+	// The cached client doesn't see the secrets' namespace; only the secret reader does
+	s.SecretReader = s.Client
+	instances := &giteav1alpha1.GiteaInstanceList{}
+	if err := s.Client.List(context.Background(), instances); err != nil {
+		t.Fatal(err)
+	}
+	var objects []client.Object
+	for i := range instances.Items {
+		objects = append(objects, &instances.Items[i])
+	}
+	s.Client = fake.NewClientBuilder().WithScheme(s.Client.(client.Client).Scheme()).WithObjects(objects...).Build()
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks/public", strings.NewReader(body))
+	req.Header.Set("X-Gitea-Event", "workflow_job")
+	req.Header.Set("X-Gitea-Delivery", "delivery-1")
+	req.Header.Set("X-Gitea-Signature", sign("public-secret", body))
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent || len(triggered) != 1 {
+		t.Errorf("status = %d, triggered = %v; want the delivery verified with the secret reader", rec.Code, triggered)
+	}
+}
+
+func TestServer_FirstDelivery(t *testing.T) {
+	s := &Server{}
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	if !s.firstDelivery("public/a", now) {
+		t.Fatal("new delivery reported as replay")
+	}
+	if s.firstDelivery("public/a", now.Add(time.Minute)) {
+		t.Error("replay within the TTL accepted")
+	}
+	if !s.firstDelivery("public/a", now.Add(deliveryTTL+time.Minute)) {
+		t.Error("delivery after the TTL reported as replay")
+	}
+
+	for i := range maxTrackedDeliveries + 10 {
+		s.firstDelivery(fmt.Sprintf("public/%d", i), now.Add(2*deliveryTTL))
+	}
+	if len(s.deliveries) > maxTrackedDeliveries {
+		t.Errorf("tracking %d deliveries, want at most %d", len(s.deliveries), maxTrackedDeliveries)
+	}
+}